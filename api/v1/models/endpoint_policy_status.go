@@ -22,6 +22,12 @@ import (
 // swagger:model EndpointPolicyStatus
 type EndpointPolicyStatus struct {
 
+	// Size of the egress network policy enforced in the proxy for this endpoint
+	ProxyPolicyEgress *ProxyPolicyCounts `json:"proxy-policy-egress,omitempty"`
+
+	// Size of the ingress network policy enforced in the proxy for this endpoint
+	ProxyPolicyIngress *ProxyPolicyCounts `json:"proxy-policy-ingress,omitempty"`
+
 	// The policy revision currently enforced in the proxy for this endpoint
 	ProxyPolicyRevision int64 `json:"proxy-policy-revision,omitempty"`
 
@@ -39,6 +45,14 @@ type EndpointPolicyStatus struct {
 func (m *EndpointPolicyStatus) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateProxyPolicyEgress(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateProxyPolicyIngress(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateProxyStatistics(formats); err != nil {
 		res = append(res, err)
 	}
@@ -57,6 +71,44 @@ func (m *EndpointPolicyStatus) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *EndpointPolicyStatus) validateProxyPolicyEgress(formats strfmt.Registry) error {
+	if swag.IsZero(m.ProxyPolicyEgress) { // not required
+		return nil
+	}
+
+	if m.ProxyPolicyEgress != nil {
+		if err := m.ProxyPolicyEgress.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("proxy-policy-egress")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("proxy-policy-egress")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *EndpointPolicyStatus) validateProxyPolicyIngress(formats strfmt.Registry) error {
+	if swag.IsZero(m.ProxyPolicyIngress) { // not required
+		return nil
+	}
+
+	if m.ProxyPolicyIngress != nil {
+		if err := m.ProxyPolicyIngress.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("proxy-policy-ingress")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("proxy-policy-ingress")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *EndpointPolicyStatus) validateProxyStatistics(formats strfmt.Registry) error {
 	if swag.IsZero(m.ProxyStatistics) { // not required
 		return nil
@@ -125,6 +177,14 @@ func (m *EndpointPolicyStatus) validateSpec(formats strfmt.Registry) error {
 func (m *EndpointPolicyStatus) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.contextValidateProxyPolicyEgress(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateProxyPolicyIngress(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateProxyStatistics(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -143,6 +203,48 @@ func (m *EndpointPolicyStatus) ContextValidate(ctx context.Context, formats strf
 	return nil
 }
 
+func (m *EndpointPolicyStatus) contextValidateProxyPolicyEgress(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.ProxyPolicyEgress != nil {
+
+		if swag.IsZero(m.ProxyPolicyEgress) { // not required
+			return nil
+		}
+
+		if err := m.ProxyPolicyEgress.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("proxy-policy-egress")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("proxy-policy-egress")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *EndpointPolicyStatus) contextValidateProxyPolicyIngress(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.ProxyPolicyIngress != nil {
+
+		if swag.IsZero(m.ProxyPolicyIngress) { // not required
+			return nil
+		}
+
+		if err := m.ProxyPolicyIngress.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("proxy-policy-ingress")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("proxy-policy-ingress")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *EndpointPolicyStatus) contextValidateProxyStatistics(ctx context.Context, formats strfmt.Registry) error {
 
 	for i := 0; i < len(m.ProxyStatistics); i++ {
@@ -30,6 +30,9 @@ type ProxyStatus struct {
 	// Enum: ["embedded","external"]
 	EnvoyDeploymentMode string `json:"envoy-deployment-mode,omitempty"`
 
+	// Readiness of the Envoy xDS resource types, keyed by type URL. True once Envoy has subscribed to the resource type on an xDS stream.
+	EnvoyResourceReadiness map[string]bool `json:"envoy-resource-readiness,omitempty"`
+
 	// IP address that the proxy listens on
 	IP string `json:"ip,omitempty"`
 
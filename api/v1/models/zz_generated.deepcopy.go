@@ -728,6 +728,13 @@ func (in *ProxyStatistics) DeepCopy() *ProxyStatistics {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyStatus) DeepCopyInto(out *ProxyStatus) {
 	*out = *in
+	if in.EnvoyResourceReadiness != nil {
+		in, out := &in.EnvoyResourceReadiness, &out.EnvoyResourceReadiness
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Redirects != nil {
 		in, out := &in.Redirects, &out.Redirects
 		*out = make([]*ProxyRedirect, len(*in))
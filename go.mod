@@ -322,6 +322,10 @@ require (
 // as to why we are using a private fork.
 replace sigs.k8s.io/controller-tools => github.com/cilium/controller-tools v0.16.5-1
 
+// Using the in-tree fork of the cilium/proxy protobuf API so that the
+// cilium.* protos can be extended from this repository. See proxy/README.md.
+replace github.com/cilium/proxy => ./proxy
+
 tool (
 	github.com/cilium/deepequal-gen
 	github.com/cilium/linters
@@ -29,6 +29,13 @@ const (
 
 	// HeartbeatTimeout is the timeout used to GC identities from k8s
 	HeartbeatTimeout = "identity-heartbeat-timeout"
+
+	// KeyMinAge is the minimum age an identity must have before it is
+	// considered for GC
+	KeyMinAge = "identity-gc-key-min-age"
+
+	// DryRun reports identities that GC would delete without deleting them
+	DryRun = "identity-gc-dry-run"
 )
 
 // Cell is a cell that implements a periodic Cilium identities
@@ -55,6 +62,9 @@ type Config struct {
 
 	RateInterval time.Duration `mapstructure:"identity-gc-rate-interval"`
 	RateLimit    int64         `mapstructure:"identity-gc-rate-limit"`
+
+	KeyMinAge time.Duration `mapstructure:"identity-gc-key-min-age"`
+	DryRun    bool          `mapstructure:"identity-gc-dry-run"`
 }
 
 var defaultConfig = Config{
@@ -63,6 +73,9 @@ var defaultConfig = Config{
 
 	RateInterval: time.Minute,
 	RateLimit:    2500,
+
+	KeyMinAge: 0,
+	DryRun:    false,
 }
 
 func (def Config) Flags(flags *pflag.FlagSet) {
@@ -72,6 +85,10 @@ func (def Config) Flags(flags *pflag.FlagSet) {
 		"Interval used for rate limiting the GC of security identities")
 	flags.Int64(RateLimit, def.RateLimit,
 		fmt.Sprintf("Maximum number of security identities that will be deleted within the %s", RateInterval))
+	flags.Duration(KeyMinAge, def.KeyMinAge,
+		"Minimum age of a security identity before it is considered for garbage collection (0 to disable)")
+	flags.Bool(DryRun, def.DryRun,
+		"Report security identities that garbage collection would delete without deleting them")
 }
 
 // SharedConfig contains the configuration that is shared between
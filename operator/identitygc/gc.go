@@ -65,6 +65,8 @@ type GC struct {
 	heartbeatTimeout time.Duration
 	gcRateInterval   time.Duration
 	gcRateLimit      int64
+	gcKeyMinAge      time.Duration
+	gcDryRun         bool
 
 	wp             *workerpool.WorkerPool
 	heartbeatStore *heartbeatStore
@@ -106,6 +108,8 @@ func registerGC(p params) {
 		heartbeatTimeout:    p.Cfg.HeartbeatTimeout,
 		gcRateInterval:      p.Cfg.RateInterval,
 		gcRateLimit:         p.Cfg.RateLimit,
+		gcKeyMinAge:         p.Cfg.KeyMinAge,
+		gcDryRun:            p.Cfg.DryRun,
 		heartbeatStore: newHeartbeatStore(
 			p.Cfg.HeartbeatTimeout,
 			p.Logger,
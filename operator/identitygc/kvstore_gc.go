@@ -41,19 +41,29 @@ func (igc *GC) runKVStoreModeGC(ctx context.Context) error {
 	for {
 		now := time.Now()
 
-		keysToDelete, gcStats, err := igc.allocator.RunGC(ctx, igc.rateLimiter, keysToDeletePrev)
+		keysToDelete, gcCandidates, gcStats, err := igc.allocator.RunGC(ctx, igc.rateLimiter, keysToDeletePrev, igc.gcKeyMinAge, igc.gcDryRun)
 		gcDuration := time.Since(now)
 		if err != nil {
 			igc.logger.Warn("Unable to run kvstore security identity garbage collector", logfields.Error, err)
 			igc.metrics.IdentityGCRuns.WithLabelValues(LabelValueOutcomeFail, LabelIdentityTypeKVStore).Inc()
 			igc.metrics.IdentityGCLatency.WithLabelValues(LabelValueOutcomeFail, LabelIdentityTypeKVStore).Set(float64(time.Since(now).Seconds()))
 		} else {
-			// Best effort to run auth identity GC
-			err = igc.runAuthGC(ctx, keysToDeletePrev)
-			if err != nil {
-				igc.logger.Warn("Unable to run kvstore auth identity garbage collector",
-					logfields.IdentitiesToDelete, keysToDeletePrev,
-					logfields.Error, err)
+			if igc.gcDryRun {
+				for _, candidate := range gcCandidates {
+					igc.logger.Info("Dry run: identity would have been garbage collected",
+						logfields.Identity, candidate.ID,
+						logfields.Key, candidate.Key,
+						logfields.Reason, candidate.Reason)
+				}
+				igc.metrics.IdentityGCSize.WithLabelValues(LabelValueOutcomeCandidate, LabelIdentityTypeKVStore).Set(float64(len(gcCandidates)))
+			} else {
+				// Best effort to run auth identity GC
+				err = igc.runAuthGC(ctx, keysToDeletePrev)
+				if err != nil {
+					igc.logger.Warn("Unable to run kvstore auth identity garbage collector",
+						logfields.IdentitiesToDelete, keysToDeletePrev,
+						logfields.Error, err)
+				}
 			}
 
 			keysToDeletePrev = keysToDelete
@@ -32,6 +32,10 @@ const (
 	// LabelValueOutcomeDeleted is used as outcome of deleted identity entries
 	LabelValueOutcomeDeleted = "deleted"
 
+	// LabelValueOutcomeCandidate is used as outcome of identity entries that
+	// a dry run would have deleted
+	LabelValueOutcomeCandidate = "candidate"
+
 	// LabelIdentityTypeKVStore is used to indicate that the identity was garbage collected from the KVStore
 	LabelIdentityTypeKVStore = "kvstore"
 
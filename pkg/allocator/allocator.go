@@ -4,10 +4,26 @@
 package allocator
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cilium/stream"
+	"github.com/google/renameio/v2"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/cilium/cilium/pkg/backoff"
 	"github.com/cilium/cilium/pkg/idpool"
@@ -15,6 +31,7 @@ import (
 	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging"
 	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/metrics"
 	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/rate"
 	"github.com/cilium/cilium/pkg/time"
@@ -32,6 +49,23 @@ const (
 	// defaultMaxAllocAttempts is the default number of attempted allocation
 	// requests performed before failing.
 	defaultMaxAllocAttempts = 16
+
+	// defaultLeakedLeaseThreshold is the default age past which a leased
+	// but neither used nor released ID is reported as leaked.
+	defaultLeakedLeaseThreshold = 5 * time.Minute
+
+	// healthCheckTimeout bounds the backend read performed by CheckHealth.
+	healthCheckTimeout = 5 * time.Second
+
+	// defaultBackendErrorWindow is the duration over which backend
+	// operations must fail consistently before the allocator enters
+	// degraded mode.
+	defaultBackendErrorWindow = 30 * time.Second
+
+	// defaultWarmupListFraction is the default fraction of the initial
+	// list timeout to wait for the initial key list before the warmup
+	// snapshot provided via WithWarmupSnapshot is activated.
+	defaultWarmupListFraction = 0.5
 )
 
 // Allocator is a distributed ID allocator backed by a KVstore. It maps
@@ -86,12 +120,33 @@ const (
 //  3. If the node goes down, all slave keys of that node are removed after
 //     the TTL expires (auto release).
 type Allocator struct {
-	logger *slog.Logger
+	// logger is held in an atomic pointer so that SetLogger can swap it at
+	// runtime without synchronizing every log call.
+	logger atomic.Pointer[slog.Logger]
 
 	// events is a channel which will receive AllocatorEvent as IDs are
 	// added, modified or removed from the allocator
 	events AllocatorEventSendChan
 
+	// eventBufferingMode selects how events are delivered when the events
+	// channel is full. The zero value blocks until the consumer drains the
+	// channel. See WithEventBuffering.
+	eventBufferingMode EventBufferingMode
+
+	// eventBuffer holds events that did not fit into the events channel.
+	// Only set in EventBufferingRing mode.
+	eventBuffer *eventRingBuffer
+
+	// eventDropMutex protects the dropped event accounting below
+	eventDropMutex lock.Mutex
+
+	// eventsDropped counts the events dropped since the allocator was
+	// created, eventsDroppedReported the portion thereof already reported
+	// at lastEventDropWarning
+	eventsDropped         uint64
+	eventsDroppedReported uint64
+	lastEventDropWarning  time.Time
+
 	// keyType is an instance of the type to be used as allocator key.
 	keyType AllocatorKey
 
@@ -107,13 +162,69 @@ type Allocator struct {
 	// allocation
 	prefixMask idpool.ID
 
+	// manualRange is set when the ID range was configured explicitly via
+	// WithMin, WithMax or WithIDRange
+	manualRange bool
+
+	// manualPrefixMask is set when the prefix mask was configured
+	// explicitly via WithPrefixMask
+	manualPrefixMask bool
+
+	// clusterID and maxClusters, if maxClusters is non-zero, make
+	// NewAllocator derive the ID range and prefix mask from the cluster's
+	// block of the ID space. See WithClusterID.
+	clusterID   uint32
+	maxClusters uint32
+
 	// localKeys contains all keys including their reference count for keys
 	// which have been allocated and are in local use
 	localKeys *localKeys
 
+	// releaseGracePeriod, if non-zero, defers the backend release of a key
+	// after its last local reference is dropped. See WithReleaseGracePeriod.
+	releaseGracePeriod time.Duration
+
+	// pendingReleases contains the keys whose backend release is deferred
+	// by the release grace period, indexed by their canonical
+	// representation. Protected by slaveKeysMutex.
+	pendingReleases map[string]*pendingRelease
+
+	// keyVersion, if non-zero, is the key serialization version prefixed to
+	// all kvstore keys. See WithKeyVersion.
+	keyVersion uint8
+
+	// keyAliases, if set via WithKeyAliases, returns the canonical
+	// representations under which the given key may have been written by
+	// agents using an older encoding.
+	keyAliases func(key AllocatorKey) []string
+
+	// cacheLookupResults makes forward lookups insert associations resolved
+	// from the backend into the main cache. See WithLookupCachePopulation.
+	cacheLookupResults bool
+
+	// idSelectionStrategy determines how candidate IDs are picked from the
+	// local pool. See WithIDSelectionStrategy.
+	idSelectionStrategy idpool.SelectionStrategy
+
+	// metrics receives observations about the allocator state. It is never
+	// nil; unless WithMetrics is used, all observations are discarded.
+	metrics Metrics
+
+	// preAllocateHook, if set, is invoked at the start of every allocation
+	// attempt with the key about to be allocated.
+	preAllocateHook func(key AllocatorKey)
+
+	// postAllocateHook, if set, is invoked with the result of every
+	// allocation attempt, after all allocator locks have been released.
+	postAllocateHook func(key AllocatorKey, id idpool.ID, isNew bool, err error)
+
 	// backoffTemplate is the backoff configuration while allocating
 	backoffTemplate backoff.Exponential
 
+	// backoffStrategy, if set via WithBackoffStrategy, replaces
+	// backoffTemplate in the allocation retry loops
+	backoffStrategy backoff.BackoffInterface
+
 	// slaveKeysMutex protects the concurrent access of the slave key by this
 	// agent.
 	slaveKeysMutex lock.Mutex
@@ -129,9 +240,22 @@ type Allocator struct {
 	// in addition to the main cache
 	remoteCaches map[string]*remoteCache
 
+	// remoteCachePriorities orders the remote caches during ID resolution,
+	// keyed by remote cache name. Lower numbers are consulted first.
+	remoteCachePriorities map[string]int
+
 	// stopGC is the channel used to stop the garbage collector
 	stopGC chan struct{}
 
+	// shutdownOnce guarantees that the teardown performed by Shutdown
+	// happens at most once, making Delete and Shutdown idempotent.
+	shutdownOnce sync.Once
+
+	// backgroundWG tracks the background goroutines and remote cache
+	// watchers owned by this allocator, so that Shutdown can wait for
+	// them to terminate.
+	backgroundWG sync.WaitGroup
+
 	// initialListDone is a channel that is closed when the initial
 	// synchronization has completed
 	initialListDone waitChan
@@ -139,6 +263,19 @@ type Allocator struct {
 	// idPool maintains a pool of available ids for allocation.
 	idPool *idpool.IDPool
 
+	// leasedIDsMutex protects leasedIDs
+	leasedIDsMutex lock.Mutex
+
+	// leasedIDs records when each ID was leased from the idPool and not yet
+	// marked as used or released again. A lease remaining here past
+	// leakedLeaseThreshold indicates an error path that missed the
+	// Use/Release bookkeeping, silently shrinking the usable ID space.
+	leasedIDs map[idpool.ID]time.Time
+
+	// leakedLeaseThreshold is the age past which a lease that was neither
+	// used nor released is reported as leaked.
+	leakedLeaseThreshold time.Duration
+
 	// enableMasterKeyProtection if true, causes master keys that are still in
 	// local use to be automatically re-created
 	enableMasterKeyProtection bool
@@ -152,10 +289,20 @@ type Allocator struct {
 	// operatorIDManagement indicates if cilium-operator is managing Cilium Identities.
 	operatorIDManagement bool
 
+	// waitIncludeRemoteCaches makes WaitForID also consider the watched
+	// remote caches when waiting for a key to appear.
+	waitIncludeRemoteCaches bool
+
 	// maxAllocAttempts is the number of attempted allocation requests
 	// performed before failing.
 	maxAllocAttempts int
 
+	// backendOpTimeout bounds each individual backend operation performed
+	// during allocation and local key synchronization. If zero, allocation
+	// operations are bounded by the caller context only and
+	// synchronization operations fall back to backendOpTimeout.
+	backendOpTimeout time.Duration
+
 	// syncInterval is the interval for local keys refresh.
 	syncInterval time.Duration
 
@@ -163,6 +310,114 @@ type Allocator struct {
 	// to ensure that they belong to the expected range.
 	cacheValidators []CacheValidator
 
+	// rejectedEvents, if set via WithRejectedEventSink, receives the events
+	// rejected by the cache validators for diagnostics
+	rejectedEvents AllocatorEventSendChan
+
+	// validationWarnLimiter rate-limits the warnings about events rejected
+	// by the cache validators
+	validationWarnLimiter logging.Limiter
+
+	// initialListTimeout is how long to wait for the initial key list from
+	// the backend before the timeout handling kicks in. It defaults to the
+	// daemon's AllocatorListTimeout configuration.
+	initialListTimeout time.Duration
+
+	// initialListTimeoutHandler, if set via WithInitialListTimeoutHandler,
+	// is invoked when the initial key list does not complete within
+	// initialListTimeout, instead of fatally exiting. The list keeps
+	// retrying in the background regardless.
+	initialListTimeoutHandler func()
+
+	// warmupSnapshot is a previously observed allocator state used to serve
+	// allocations in a degraded read-only mode when the initial key list from
+	// the backend does not complete in time.
+	warmupSnapshot []AllocatorEntry
+
+	// warmupKeys indexes warmupSnapshot by canonical key representation. It
+	// is built once during initialization and never mutated afterwards.
+	warmupKeys map[string]idpool.ID
+
+	// warmupActive is closed when the allocator starts serving allocations
+	// from the warmup snapshot. It is nil if no snapshot was provided.
+	warmupActive waitChan
+
+	// warmupOnce guarantees that the warmup snapshot is activated at most
+	// once.
+	warmupOnce sync.Once
+
+	// warmupListFraction is the fraction of initialListTimeout to wait for
+	// the initial key list before the warmup snapshot is activated.
+	warmupListFraction float64
+
+	// snapshotPath, if set, is the path to which the allocator cache is
+	// exported after each successful local key synchronization pass, and
+	// from which the warmup snapshot is imported at initialization when
+	// none was provided explicitly.
+	snapshotPath string
+
+	// healthMonitorInterval is the interval at which the background health
+	// monitor started via WithHealthMonitor probes backend connectivity. A
+	// zero interval disables both the monitor and the degraded mode.
+	healthMonitorInterval time.Duration
+
+	// watchdogInterval is the interval within which the watchdog started
+	// via WithListWatchWatchdog expects the main cache to receive at least
+	// one event. A zero interval disables the watchdog.
+	watchdogInterval time.Duration
+
+	// backendErrorWindow is the duration over which backend operations
+	// must fail consistently before the allocator enters degraded mode.
+	backendErrorWindow time.Duration
+
+	// backendHealthy is cleared while the allocator is in degraded mode
+	// due to persistent backend errors. It is never cleared when the
+	// health monitor is not enabled.
+	backendHealthy atomic.Bool
+
+	// backendHealthMutex protects firstBackendError, backendRecovered and
+	// deferredWrites.
+	backendHealthMutex lock.Mutex
+
+	// firstBackendError is the time of the first backend error of the
+	// current consecutive error streak, or the zero time outside of one.
+	firstBackendError time.Time
+
+	// backendRecovered is closed when the allocator leaves degraded mode.
+	// It is re-created each time degraded mode is entered.
+	backendRecovered waitChan
+
+	// deferredWrites contains the keys served from the main cache while in
+	// degraded mode, indexed by their canonical representation. The
+	// backend writes for these keys are replayed once the backend
+	// recovers.
+	deferredWrites map[string]AllocatorKey
+
+	// healthReporter, if set via WithHealthReporter, receives updates
+	// about the health of the watch and sync loops.
+	healthReporter HealthReporter
+
+	// healthConditionsMutex protects healthConditions, watchRestarts and
+	// watchHealthyTimer.
+	healthConditionsMutex lock.Mutex
+
+	// healthConditions is the set of currently degraded health conditions,
+	// with the error that caused each, if any.
+	healthConditions map[string]error
+
+	// watchRestarts counts the unexpected watch stream terminations since
+	// the watch last stayed up for watchRestartResetAfter.
+	watchRestarts int
+
+	// watchHealthyTimer resets the watch restart streak once the watch has
+	// stayed up for watchRestartResetAfter.
+	watchHealthyTimer *time.Timer
+
+	// failedSyncRounds counts the consecutive local key synchronization
+	// rounds that encountered errors. It is only accessed from the sync
+	// routine.
+	failedSyncRounds int
+
 	// backend is the upstream, shared, backend to which we syncronize local
 	// information
 	backend Backend
@@ -182,11 +437,12 @@ type CacheValidator func(kind AllocatorChangeKind, id idpool.ID, key AllocatorKe
 //   - WithMax(id) - maximum ID to allocate (default max(uint64))
 func NewAllocatorForGC(rootLogger *slog.Logger, backend Backend, opts ...AllocatorOption) *Allocator {
 	a := &Allocator{
-		logger:  rootLogger.With(subsysLogAttr...),
 		backend: backend,
+		metrics: noopMetrics{},
 		min:     idpool.ID(1),
 		max:     idpool.ID(^uint64(0)),
 	}
+	a.logger.Store(rootLogger.With(subsysLogAttr...))
 
 	for _, fn := range opts {
 		fn(a)
@@ -201,6 +457,32 @@ type GCStats struct {
 
 	// Deleted is the number of identities deleted
 	Deleted int
+
+	// SkippedYoung is the number of identities skipped because they were
+	// created or modified more recently than the minimum key age
+	SkippedYoung int
+
+	// Pages is the number of paginated backend list calls the GC round
+	// required to scan the identity space
+	Pages int
+
+	// ScanDuration is the total time the GC round spent scanning the
+	// identity space, across all pages
+	ScanDuration time.Duration
+}
+
+// GCCandidate describes a master key that a garbage collection run in
+// dry-run mode would have deleted.
+type GCCandidate struct {
+	// ID is the identity ID the master key maps to
+	ID idpool.ID
+
+	// Key is the kvstore key of the master key
+	Key string
+
+	// Reason is a human-readable explanation of why the key is a deletion
+	// candidate
+	Reason string
 }
 
 // Backend represents clients to remote ID allocation systems, such as KV
@@ -266,6 +548,12 @@ type Backend interface {
 	// This may have been created by other agents.
 	GetByID(ctx context.Context, id idpool.ID) (AllocatorKey, error)
 
+	// GetByIDBatch returns the keys associated with the given IDs, as seen by
+	// the Backend. IDs with no associated key are omitted from the result.
+	// Implementations may satisfy the lookup with fewer round-trips than one
+	// GetByID call per ID.
+	GetByIDBatch(ctx context.Context, ids []idpool.ID) (map[idpool.ID]AllocatorKey, error)
+
 	// Lock provides an opaque lock object that can be used, later, to ensure
 	// that the key has not changed since the lock was created. This can be done
 	// with GetIfLocked.
@@ -281,9 +569,30 @@ type Backend interface {
 	// RunGC reaps stale or unused identities within the Backend and makes them
 	// available for reuse. It is used by the cilium-operator and is not invoked
 	// by cilium-agent.
+	// A single call scans at most one page of the identity space, starting
+	// after the given continuation token; an empty token starts a new round.
+	// The returned token resumes the scan on the next call, and is empty once
+	// the round is complete. The Allocator RunGC wrapper drives the pages of a
+	// round and merges the per-page results.
+	// Keys created or modified less than minKeyAge ago are skipped regardless
+	// of their reference count, so that a master key is not reaped while the
+	// slave key write of another node may still be in flight.
+	// When dryRun is true the scan and staleness evaluation run as usual, but
+	// no keys are deleted; the keys that would have been deleted are returned
+	// as candidates instead. The returned stale keys are the same either way,
+	// so a subsequent real run makes the same decisions.
 	// Note: not all Backend implemenations rely on this, such as the kvstore
 	// backends, and may use leases to expire keys.
-	RunGC(ctx context.Context, rateLimit *rate.Limiter, staleKeysPrevRound map[string]uint64, minID idpool.ID, maxID idpool.ID) (map[string]uint64, *GCStats, error)
+	RunGC(ctx context.Context, rateLimit *rate.Limiter, staleKeysPrevRound map[string]uint64, minKeyAge time.Duration, dryRun bool, minID idpool.ID, maxID idpool.ID, continuation string) (map[string]uint64, string, []GCCandidate, *GCStats, error)
+
+	// MigrateKeys rewrites all keys stored with the old key serialization
+	// version to carry the new one, as configured with WithKeyVersion. It is
+	// invoked once during an upgrade, before agents configured with the new
+	// version start; keys already carrying the new version are left untouched,
+	// so the migration is safe to re-run after a partial failure.
+	// Note: not all Backend implementations store versionable keys; those
+	// implement this as a no-op.
+	MigrateKeys(ctx context.Context, oldVersion, newVersion uint8) error
 
 	// RunLocksGC reaps stale or unused locks within the Backend. It is used by
 	// the cilium-operator and is not invoked by cilium-agent. Returns
@@ -309,29 +618,61 @@ type Backend interface {
 // Release()
 func NewAllocator(rootLogger *slog.Logger, typ AllocatorKey, backend Backend, opts ...AllocatorOption) (*Allocator, error) {
 	a := &Allocator{
-		logger:       rootLogger.With(subsysLogAttr...),
-		keyType:      typ,
-		backend:      backend,
-		min:          idpool.ID(1),
-		max:          idpool.ID(^uint64(0)),
-		localKeys:    newLocalKeys(rootLogger),
-		stopGC:       make(chan struct{}),
-		remoteCaches: map[string]*remoteCache{},
+		keyType:         typ,
+		backend:         backend,
+		min:             idpool.ID(1),
+		max:             idpool.ID(^uint64(0)),
+		localKeys:       newLocalKeys(rootLogger),
+		leasedIDs:       map[idpool.ID]time.Time{},
+		pendingReleases: map[string]*pendingRelease{},
+		metrics:         noopMetrics{},
+		stopGC:          make(chan struct{}),
+		remoteCaches:    map[string]*remoteCache{},
 		backoffTemplate: backoff.Exponential{
 			Logger: rootLogger.With(subsysLogAttr...),
 			Min:    time.Duration(20) * time.Millisecond,
 			Factor: 2.0,
 		},
-		maxAllocAttempts: defaultMaxAllocAttempts,
-		syncInterval:     DefaultSyncInterval,
+		maxAllocAttempts:      defaultMaxAllocAttempts,
+		idSelectionStrategy:   idpool.SelectionSequential,
+		leakedLeaseThreshold:  defaultLeakedLeaseThreshold,
+		syncInterval:          DefaultSyncInterval,
+		initialListTimeout:    option.Config.AllocatorListTimeout,
+		warmupListFraction:    defaultWarmupListFraction,
+		validationWarnLimiter: logging.NewLimiter(10*time.Second, 3),
+		backendErrorWindow:    defaultBackendErrorWindow,
 	}
+	a.logger.Store(rootLogger.With(subsysLogAttr...))
+	a.backendHealthy.Store(true)
 
 	for _, fn := range opts {
 		fn(a)
 	}
 
+	if len(a.warmupSnapshot) > 0 {
+		a.installWarmupSnapshot(a.warmupSnapshot)
+	} else if a.snapshotPath != "" {
+		if err := a.ImportFromFile(a.snapshotPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			a.getLogger().Warn("Unable to import allocator snapshot file",
+				logfields.Error, err,
+				logfields.Path, a.snapshotPath,
+			)
+		}
+	}
+
 	a.mainCache = newCache(a)
 
+	if a.maxClusters > 0 {
+		if a.manualRange || a.manualPrefixMask {
+			return nil, errors.New("WithClusterID conflicts with a manually configured ID range or prefix mask")
+		}
+		var err error
+		a.min, a.max, a.prefixMask, err = clusterIDRange(a.clusterID, a.maxClusters)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if a.min < 1 {
 		return nil, errors.New("minimum ID must be >= 1")
 	}
@@ -340,7 +681,11 @@ func NewAllocator(rootLogger *slog.Logger, typ AllocatorKey, backend Backend, op
 		return nil, fmt.Errorf("maximum ID must be greater than minimum ID: configured max %v, min %v", a.max, a.min)
 	}
 
-	a.idPool = idpool.NewIDPool(a.min, a.max)
+	a.idPool = idpool.NewIDPoolWithStrategy(a.min, a.max, a.idSelectionStrategy)
+
+	if a.events != nil && a.eventBuffer != nil {
+		go a.flushEvents()
+	}
 
 	if !a.disableAutostart {
 		a.start()
@@ -349,18 +694,222 @@ func NewAllocator(rootLogger *slog.Logger, typ AllocatorKey, backend Backend, op
 	return a, nil
 }
 
+// getLogger returns the logger the allocator currently writes through.
+func (a *Allocator) getLogger() *slog.Logger {
+	return a.logger.Load()
+}
+
+// SetLogger replaces the logger of the allocator and its local key store.
+// It may be called at runtime, e.g. to apply a changed log level without
+// restarting the agent.
+func (a *Allocator) SetLogger(logger *slog.Logger) {
+	a.logger.Store(logger.With(subsysLogAttr...))
+	if a.localKeys != nil {
+		a.localKeys.setLogger(logger)
+	}
+}
+
 func (a *Allocator) start() {
 	a.initialListDone = a.mainCache.start()
-	if !a.disableGC {
+	if a.healthReporter != nil {
+		a.backgroundWG.Add(1)
+		go func() {
+			defer a.backgroundWG.Done()
+			select {
+			case <-a.initialListDone:
+			case <-a.stopGC:
+				return
+			case <-time.After(a.initialListTimeout):
+				a.setHealthCondition(healthReasonListStalled, nil)
+				select {
+				case <-a.initialListDone:
+				case <-a.stopGC:
+					return
+				}
+			}
+			a.clearHealthCondition(healthReasonListStalled)
+		}()
+	}
+	if len(a.warmupSnapshot) > 0 {
+		a.backgroundWG.Add(1)
+		go func() {
+			defer a.backgroundWG.Done()
+			select {
+			case <-a.initialListDone:
+			case <-a.stopGC:
+			case <-time.After(time.Duration(float64(a.initialListTimeout) * a.warmupListFraction)):
+				a.activateWarmupSnapshot()
+			}
+		}()
+	}
+	if !a.disableGC || a.initialListTimeoutHandler != nil {
+		a.backgroundWG.Add(1)
 		go func() {
+			defer a.backgroundWG.Done()
 			select {
 			case <-a.initialListDone:
-			case <-time.After(option.Config.AllocatorListTimeout):
-				logging.Fatal(a.logger, "Timeout while waiting for initial allocator state")
+			case <-a.stopGC:
+				return
+			case <-time.After(a.initialListTimeout):
+				switch {
+				case a.initialListTimeoutHandler != nil:
+					a.initialListTimeoutHandler()
+				case len(a.warmupSnapshot) == 0:
+					logging.Fatal(a.getLogger(), "Timeout while waiting for initial allocator state")
+				}
+				// With a timeout handler or a warmup snapshot the
+				// allocator keeps running while the backend list keeps
+				// retrying.
+				select {
+				case <-a.initialListDone:
+				case <-a.stopGC:
+					return
+				}
+			}
+			if !a.disableGC {
+				a.startLocalKeySync()
 			}
-			a.startLocalKeySync()
 		}()
 	}
+	if a.healthMonitorInterval > 0 {
+		a.startHealthMonitor()
+	}
+	if a.watchdogInterval > 0 {
+		a.backgroundWG.Add(1)
+		go a.listWatchWatchdog()
+	}
+}
+
+// listWatchWatchdog restarts the ListAndWatch goroutine of the main cache
+// when no event, including heartbeats and sync markers, has been observed for
+// a full watchdog interval. This recovers from backend streams that stall
+// without being closed.
+func (a *Allocator) listWatchWatchdog() {
+	defer a.backgroundWG.Done()
+
+	// Give the watch a full interval to deliver its first event.
+	lastRestart := time.Now()
+
+	ticker := time.NewTicker(a.watchdogInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.stopGC:
+			return
+		case <-ticker.C:
+			lastEvent := a.mainCache.getLastUpdate()
+			if lastEvent.Before(lastRestart) {
+				lastEvent = lastRestart
+			}
+			if time.Since(lastEvent) < a.watchdogInterval {
+				continue
+			}
+
+			a.getLogger().Warn("No event received from the kvstore watch within the watchdog interval, restarting the watch",
+				logfields.Interval, a.watchdogInterval)
+			a.metrics.IncListWatchRestart()
+			a.mainCache.restartWatch()
+			lastRestart = time.Now()
+		}
+	}
+}
+
+// installWarmupSnapshot installs the given snapshot as the warmup source and
+// indexes it by canonical key representation.
+func (a *Allocator) installWarmupSnapshot(snapshot []AllocatorEntry) {
+	a.warmupSnapshot = snapshot
+	a.warmupKeys = make(map[string]idpool.ID, len(snapshot))
+	for _, entry := range snapshot {
+		a.warmupKeys[entry.Key.GetKey()] = entry.ID
+	}
+	a.warmupActive = make(waitChan)
+}
+
+// activateWarmupSnapshot enters the degraded read-only mode in which
+// allocations are served from the warmup snapshot until the initial key list
+// from the backend completes.
+func (a *Allocator) activateWarmupSnapshot() {
+	a.warmupOnce.Do(func() {
+		a.getLogger().Warn("Initial allocator state not received in time, serving allocations from warmup snapshot in degraded read-only mode")
+		close(a.warmupActive)
+	})
+}
+
+// allocateFromWarmupSnapshot serves an allocation from the warmup snapshot
+// while the allocator is in degraded read-only mode. No backend state is
+// created and no local reference is taken for the key.
+func (a *Allocator) allocateFromWarmupSnapshot(key AllocatorKey) (idpool.ID, bool, bool, error) {
+	id, ok := a.warmupKeys[key.GetKey()]
+	if !ok {
+		return 0, false, false, fmt.Errorf("key %s is not part of the warmup snapshot while the allocator is in degraded mode", key)
+	}
+
+	a.getLogger().Warn("Serving allocation from warmup snapshot in degraded read-only mode",
+		logfields.Key, key,
+		logfields.ID, id,
+	)
+	metrics.IdentityDegradedAllocations.Inc()
+
+	return id, false, false, nil
+}
+
+// snapshotFileEntry is the on-disk representation of a single AllocatorEntry.
+// Keys are stored in their canonical string representation and reconstructed
+// via the allocator key type on import.
+type snapshotFileEntry struct {
+	ID  idpool.ID `json:"id"`
+	Key string    `json:"key"`
+}
+
+// ExportToFile writes the current contents of the main allocator cache to the
+// given path as a JSON snapshot. The file is written to a temporary file first
+// and atomically renamed into place, so that a crash never leaves a partially
+// written snapshot behind.
+func (a *Allocator) ExportToFile(path string) error {
+	entries := []snapshotFileEntry{}
+	a.mainCache.foreach(func(id idpool.ID, key AllocatorKey) {
+		entries = append(entries, snapshotFileEntry{ID: id, Key: key.GetKey()})
+	})
+
+	f, err := renameio.TempFile(filepath.Dir(path), path)
+	if err != nil {
+		return fmt.Errorf("failed to open temporary snapshot file: %w", err)
+	}
+	defer f.Cleanup()
+
+	if err := json.NewEncoder(f).Encode(entries); err != nil {
+		return fmt.Errorf("failed to encode allocator snapshot: %w", err)
+	}
+
+	return f.CloseAtomicallyReplace()
+}
+
+// ImportFromFile reads a snapshot previously written by ExportToFile and
+// installs it as the warmup snapshot, so that allocations for the contained
+// keys can be served in degraded read-only mode while the backend is
+// unreachable. It is expected to be called before the allocator is started.
+func (a *Allocator) ImportFromFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+
+	var entries []snapshotFileEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("failed to decode allocator snapshot: %w", err)
+	}
+
+	snapshot := make([]AllocatorEntry, 0, len(entries))
+	for _, entry := range entries {
+		snapshot = append(snapshot, AllocatorEntry{
+			ID:  entry.ID,
+			Key: a.keyType.PutKey(entry.Key),
+		})
+	}
+	a.installWarmupSnapshot(snapshot)
+
+	return nil
 }
 
 // WithBackend sets this allocator to use backend. It is expected to be used at
@@ -381,14 +930,64 @@ func WithEvents(events AllocatorEventSendChan) AllocatorOption {
 	return func(a *Allocator) { a.events = events }
 }
 
+// WithEventsNonBlocking causes events to be delivered to the channel provided
+// via WithEvents() with a non-blocking send. When the channel is full, the
+// event is dropped and the cilium_identity_events_dropped_total counter is
+// incremented, rather than blocking the allocator (potentially while holding
+// its lock) until the consumer catches up. It is shorthand for
+// WithEventBuffering(EventBufferingDrop, 0).
+//
+// CAUTION: Consumers using this option may miss events and must therefore
+// periodically reconcile their state against the allocator cache.
+func WithEventsNonBlocking() AllocatorOption {
+	return WithEventBuffering(EventBufferingDrop, 0)
+}
+
+// WithEventBuffering selects what happens when the channel provided via
+// WithEvents() is full. In EventBufferingDrop mode the event is dropped. In
+// EventBufferingRing mode the event is spilled into a ring buffer holding up
+// to size events (defaulting when non-positive), which a helper goroutine
+// flushes into the channel, and only once the ring buffer is also full is the
+// oldest buffered event dropped. Either way the allocator itself never blocks,
+// dropped events increment the cilium_identity_events_dropped_total counter,
+// and the drop count is reported in a periodic warning. By default events are
+// delivered with a blocking send.
+//
+// CAUTION: Consumers using this option may miss events and must therefore
+// periodically reconcile their state against the allocator cache.
+func WithEventBuffering(mode EventBufferingMode, size int) AllocatorOption {
+	return func(a *Allocator) {
+		a.eventBufferingMode = mode
+		if mode == EventBufferingRing {
+			a.eventBuffer = newEventRingBuffer(size)
+		}
+	}
+}
+
 // WithMin sets the minimum identifier to be allocated
 func WithMin(id idpool.ID) AllocatorOption {
-	return func(a *Allocator) { a.min = id }
+	return func(a *Allocator) {
+		a.min = id
+		a.manualRange = true
+	}
 }
 
 // WithMax sets the maximum identifier to be allocated
 func WithMax(id idpool.ID) AllocatorOption {
-	return func(a *Allocator) { a.max = id }
+	return func(a *Allocator) {
+		a.max = id
+		a.manualRange = true
+	}
+}
+
+// WithIDRange sets the range of identifiers handed out by the allocator to
+// [minID, maxID]. It is equivalent to combining WithMin and WithMax.
+func WithIDRange(minID, maxID idpool.ID) AllocatorOption {
+	return func(a *Allocator) {
+		a.min = minID
+		a.max = maxID
+		a.manualRange = true
+	}
 }
 
 // WithPrefixMask sets the prefix used for all ID allocations. If set, the mask
@@ -396,7 +995,47 @@ func WithMax(id idpool.ID) AllocatorOption {
 // responsibility of the caller to ensure that the mask is not conflicting with
 // min..max.
 func WithPrefixMask(mask idpool.ID) AllocatorOption {
-	return func(a *Allocator) { a.prefixMask = mask }
+	return func(a *Allocator) {
+		a.prefixMask = mask
+		a.manualPrefixMask = true
+	}
+}
+
+// idSpaceBitlength is the number of bits of the ID space partitioned by
+// WithClusterID, matching the wire length of a numeric identity.
+const idSpaceBitlength = 24
+
+// WithClusterID partitions the ID space among the clusters of a clustermesh
+// of up to maxClusters clusters, and configures the allocator with the ID
+// range and prefix mask of the block belonging to the cluster with the given
+// ID. maxClusters must be a power of two; the block of cluster 0 starts at
+// ID 1 and carries no prefix mask. The derived configuration conflicts with
+// WithMin, WithMax, WithIDRange and WithPrefixMask; combining them makes
+// NewAllocator fail rather than risk handing out out-of-range IDs.
+func WithClusterID(clusterID, maxClusters uint32) AllocatorOption {
+	return func(a *Allocator) {
+		a.clusterID = clusterID
+		a.maxClusters = maxClusters
+	}
+}
+
+// clusterIDRange derives the ID range and prefix mask of the ID space block
+// belonging to the given cluster, with the space partitioned into maxClusters
+// equally sized blocks.
+func clusterIDRange(clusterID, maxClusters uint32) (minID, maxID, prefixMask idpool.ID, err error) {
+	if maxClusters < 2 || bits.OnesCount32(maxClusters) != 1 {
+		return 0, 0, 0, fmt.Errorf("maximum number of clusters must be a power of two >= 2, got %d", maxClusters)
+	}
+	if clusterID >= maxClusters {
+		return 0, 0, 0, fmt.Errorf("cluster ID %d out of range, must be below %d", clusterID, maxClusters)
+	}
+
+	shift := idSpaceBitlength - bits.Len32(maxClusters-1)
+	if clusterID == 0 {
+		return 1, idpool.ID(1)<<shift - 1, 0, nil
+	}
+	minID = idpool.ID(clusterID) << shift
+	return minID, idpool.ID(clusterID+1)<<shift - 1, minID, nil
 }
 
 // WithMasterKeyProtection will watch for delete events on master keys and
@@ -405,18 +1044,137 @@ func WithMasterKeyProtection() AllocatorOption {
 	return func(a *Allocator) { a.enableMasterKeyProtection = true }
 }
 
+// WithReleaseGracePeriod makes Release defer the backend release of the last
+// local reference to a key by the given duration. A new allocation of the
+// same key within the grace period cancels the deferred release and keeps
+// the existing ID, so that a replacement user showing up shortly after the
+// previous one does not churn through a release and re-allocation. Pending
+// releases are flushed when the allocator is deleted.
+func WithReleaseGracePeriod(d time.Duration) AllocatorOption {
+	return func(a *Allocator) { a.releaseGracePeriod = d }
+}
+
+// WithKeyVersion prefixes all kvstore keys written by this allocator with
+// the given key serialization version, and registers a cache validator that
+// ignores keys carrying a different version during ListAndWatch. This allows
+// the canonical key format to change between Cilium versions without old and
+// new agents misinterpreting each other's keys during a rolling upgrade; use
+// Backend.MigrateKeys to rewrite existing keys to the new version. Version 0
+// is the unversioned format and leaves keys untouched.
+func WithKeyVersion(version uint8) AllocatorOption {
+	return func(a *Allocator) {
+		a.keyVersion = version
+		prefix := KeyVersionPrefix(version)
+		a.cacheValidators = append(a.cacheValidators, func(kind AllocatorChangeKind, id idpool.ID, key AllocatorKey) error {
+			if kind != AllocatorChangeUpsert || key == nil {
+				return nil
+			}
+			if !strings.HasPrefix(key.GetKey(), prefix) {
+				return fmt.Errorf("key version mismatch, expected prefix %q", prefix)
+			}
+			return nil
+		})
+	}
+}
+
+// WithKeyAliases configures a function returning the canonical
+// representations under which the given key may have been written by agents
+// using an older encoding. Lookups that miss under the current encoding are
+// retried under each alias, both in the caches and in the backend, so that
+// identities allocated before an encoding change keep their ID. Master keys
+// found under an alias are rewritten to the current encoding on first use.
+func WithKeyAliases(aliases func(key AllocatorKey) []string) AllocatorOption {
+	return func(a *Allocator) { a.keyAliases = aliases }
+}
+
+// WithLookupCachePopulation makes Get and GetNoCache insert associations
+// resolved from the backend into the main cache, so that repeated forward
+// lookups for keys allocated by other nodes do not hit the kvstore until the
+// watcher catches up. Without this option only reverse (ID to key) lookups
+// populate the cache, preserving strictly watcher-driven forward lookup
+// semantics. Cache validators apply to the inserted associations either way.
+func WithLookupCachePopulation() AllocatorOption {
+	return func(a *Allocator) { a.cacheLookupResults = true }
+}
+
 // WithOperatorIDManagement enables the mode with cilium-operator managing
 // Cilium Identities.
 func WithOperatorIDManagement() AllocatorOption {
 	return func(a *Allocator) { a.operatorIDManagement = true }
 }
 
+// WithRemoteCachePriority configures the priority of the remote cache named
+// 'clusterName' during ID resolution. Remote caches are consulted in
+// ascending priority order (lower number = higher priority); caches without
+// a configured priority are consulted last, ordered by name. This makes the
+// resolution of keys present in several clusters deterministic when identity
+// ranges overlap in a clustermesh.
+func WithRemoteCachePriority(clusterName string, priority int) AllocatorOption {
+	return func(a *Allocator) {
+		if a.remoteCachePriorities == nil {
+			a.remoteCachePriorities = map[string]int{}
+		}
+		a.remoteCachePriorities[clusterName] = priority
+	}
+}
+
+// WithWaitForIDIncludeRemoteCaches makes WaitForID return as soon as the key
+// appears in one of the watched remote caches, in addition to the main cache.
+func WithWaitForIDIncludeRemoteCaches() AllocatorOption {
+	return func(a *Allocator) { a.waitIncludeRemoteCaches = true }
+}
+
+// WithIDSelectionStrategy configures how candidate IDs are picked from the
+// local pool during allocation. The default idpool.SelectionSequential
+// proposes IDs in the same deterministic order on every node, so simultaneous
+// allocations on many nodes contend for the same candidate IDs and burn
+// through the allocation attempts; idpool.SelectionRandom spreads the
+// candidates over the free set, reducing such conflicts at the cost of a
+// non-contiguous ID space.
+func WithIDSelectionStrategy(strategy idpool.SelectionStrategy) AllocatorOption {
+	return func(a *Allocator) { a.idSelectionStrategy = strategy }
+}
+
 // WithMaxAllocAttempts sets the maxAllocAttempts. If not set, new Allocator
 // will use defaultMaxAllocAttempts.
 func WithMaxAllocAttempts(maxAttempts int) AllocatorOption {
 	return func(a *Allocator) { a.maxAllocAttempts = maxAttempts }
 }
 
+// WithBackendOpTimeout bounds each individual backend operation performed
+// during allocation and local key synchronization, so that a single slow
+// backend call cannot consume the entire allocation budget of the caller
+// context and starve subsequent retries.
+func WithBackendOpTimeout(d time.Duration) AllocatorOption {
+	return func(a *Allocator) { a.backendOpTimeout = d }
+}
+
+// opTimeoutContext derives the context for an individual backend operation
+// during allocation. Without WithBackendOpTimeout the caller context is
+// returned unchanged.
+func (a *Allocator) opTimeoutContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if a.backendOpTimeout == 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, a.backendOpTimeout)
+}
+
+// syncOpTimeout returns the timeout for backend operations performed during
+// local key synchronization, preferring the value configured with
+// WithBackendOpTimeout over the backendOpTimeout default.
+func (a *Allocator) syncOpTimeout() time.Duration {
+	if a.backendOpTimeout != 0 {
+		return a.backendOpTimeout
+	}
+	return backendOpTimeout
+}
+
+// isOpTimeout reports whether err stems from the expiry of the
+// per-operation backend timeout rather than of the caller context.
+func (a *Allocator) isOpTimeout(ctx context.Context, err error) bool {
+	return a.backendOpTimeout != 0 && errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil
+}
+
 // WithoutGC disables the use of the garbage collector
 func WithoutGC() AllocatorOption {
 	return func(a *Allocator) { a.disableGC = true }
@@ -427,6 +1185,14 @@ func WithoutAutostart() AllocatorOption {
 	return func(a *Allocator) { a.disableAutostart = true }
 }
 
+// WithBackoffStrategy sets a custom backoff strategy for the allocation retry
+// loops, replacing the default exponential backoff. The strategy is shared
+// between all keys being allocated, so implementations must be safe for
+// concurrent use.
+func WithBackoffStrategy(b backoff.BackoffInterface) AllocatorOption {
+	return func(a *Allocator) { a.backoffStrategy = b }
+}
+
 // WithSyncInterval configures the interval for local keys refresh.
 func WithSyncInterval(interval time.Duration) AllocatorOption {
 	return func(a *Allocator) { a.syncInterval = interval }
@@ -438,100 +1204,754 @@ func WithCacheValidator(validator CacheValidator) AllocatorOption {
 	return func(a *Allocator) { a.cacheValidators = append(a.cacheValidators, validator) }
 }
 
-// GetEvents returns the events channel given to the allocator when
-// constructed.
-// Note: This channel is not owned by the allocator!
-func (a *Allocator) GetEvents() AllocatorEventSendChan {
-	return a.events
+// WithRejectedEventSink forwards the events rejected by the cache validators
+// to sink for diagnostics. Events are delivered with a non-blocking send and
+// dropped if the sink is full, so that a slow consumer cannot stall the
+// processing of the notifications.
+func WithRejectedEventSink(sink AllocatorEventSendChan) AllocatorOption {
+	return func(a *Allocator) { a.rejectedEvents = sink }
 }
 
-// Delete deletes an allocator and stops the garbage collector
-func (a *Allocator) Delete() {
-	close(a.stopGC)
-	a.mainCache.stop()
+// AllocatorEntry pairs an allocated ID with its key. It represents a single
+// entry of a previously observed allocator state, as handed to
+// WithWarmupSnapshot.
+type AllocatorEntry struct {
+	ID  idpool.ID
+	Key AllocatorKey
 }
 
-// WaitForInitialSync waits until the initial sync is complete
-func (a *Allocator) WaitForInitialSync(ctx context.Context) error {
-	select {
-	case <-a.initialListDone:
-	case <-ctx.Done():
-		return fmt.Errorf("identity sync was cancelled: %w", ctx.Err())
-	}
+// WithWarmupSnapshot provides a snapshot of a previously observed allocator
+// state, for example restored from CRDs. When the initial key list from the
+// backend does not complete within a fraction of the initial list timeout
+// (see WithWarmupListFraction), allocations for keys present in the snapshot
+// are served in a degraded read-only mode instead of fatally exiting, while
+// the backend synchronization keeps retrying in the background.
+func WithWarmupSnapshot(snapshot []AllocatorEntry) AllocatorOption {
+	return func(a *Allocator) { a.warmupSnapshot = snapshot }
+}
 
-	return nil
+// WithWarmupListFraction overrides the fraction of the initial list timeout
+// to wait for the initial key list before the snapshot provided via
+// WithWarmupSnapshot is activated.
+func WithWarmupListFraction(fraction float64) AllocatorOption {
+	return func(a *Allocator) { a.warmupListFraction = fraction }
 }
 
-// RangeFunc is the function called by RangeCache
-type RangeFunc func(idpool.ID, AllocatorKey)
+// WithInitialListTimeout overrides how long the allocator waits for the
+// initial key list from the backend before the timeout handling kicks in. It
+// defaults to the daemon's AllocatorListTimeout configuration.
+func WithInitialListTimeout(timeout time.Duration) AllocatorOption {
+	return func(a *Allocator) { a.initialListTimeout = timeout }
+}
 
-// ForeachCache iterates over the allocator cache and calls RangeFunc on each
-// cached entry
-func (a *Allocator) ForeachCache(cb RangeFunc) {
-	a.mainCache.foreach(cb)
+// WithInitialListTimeoutHandler sets the function invoked when the initial
+// key list does not complete within the initial list timeout. Without a
+// handler the allocator fatally exits on timeout, unless a warmup snapshot
+// is available; secondary allocators such as the clustermesh remote caches
+// instead record the stalled listing and keep retrying.
+func WithInitialListTimeoutHandler(handler func()) AllocatorOption {
+	return func(a *Allocator) { a.initialListTimeoutHandler = handler }
+}
 
-	a.remoteCachesMutex.RLock()
-	for _, rc := range a.remoteCaches {
-		rc.cache.foreach(cb)
-	}
-	a.remoteCachesMutex.RUnlock()
+// WithSnapshotPath configures a file to which the allocator cache is exported
+// after each successful local key synchronization pass. At initialization, if
+// no snapshot was provided via WithWarmupSnapshot, the file is imported as the
+// warmup snapshot so that identity state survives a kvstore wipe or outage
+// across agent restarts.
+func WithSnapshotPath(path string) AllocatorOption {
+	return func(a *Allocator) { a.snapshotPath = path }
 }
 
-// selectAvailableID selects an available ID.
-// Returns a triple of the selected ID ORed with prefixMask, the ID string and
-// the originally selected ID.
-func (a *Allocator) selectAvailableID() (idpool.ID, string, idpool.ID) {
-	if id := a.idPool.LeaseAvailableID(); id != idpool.NoID {
-		unmaskedID := id
-		id |= a.prefixMask
-		return id, id.String(), unmaskedID
-	}
+// WithHealthMonitor starts a background goroutine that probes backend
+// connectivity at the given interval. When backend operations keep failing
+// over a 30 second window, the allocator enters a degraded mode in which
+// allocations for keys present in the main cache are served locally with the
+// corresponding backend writes queued for replay, and allocations for unknown
+// keys block until the backend recovers or the context expires.
+func WithHealthMonitor(interval time.Duration) AllocatorOption {
+	return func(a *Allocator) { a.healthMonitorInterval = interval }
+}
 
-	return 0, "", 0
+// WithListWatchWatchdog starts a background goroutine that restarts the main
+// cache's ListAndWatch stream when it has not delivered any event, including
+// heartbeats and sync markers, for a full interval. This recovers from
+// backend streams that stall without being closed. A zero interval disables
+// the watchdog.
+func WithListWatchWatchdog(interval time.Duration) AllocatorOption {
+	return func(a *Allocator) { a.watchdogInterval = interval }
 }
 
-// AllocatorKey is the interface to implement in order for a type to be used as
-// key for the allocator. The key's data is assumed to be a collection of
-// pkg/label.Label, and the functions reflect this somewhat.
-type AllocatorKey interface {
-	fmt.Stringer
+// HealthReporter receives updates about the health of the allocator's watch
+// and sync loops. It is satisfied by cell.Health.
+type HealthReporter interface {
+	// OK reports that the allocator is healthy.
+	OK(status string)
 
-	// GetKey returns the canonical string representation of the key
-	GetKey() string
+	// Degraded reports that the allocator is degraded, with the reason and
+	// the error that caused the degradation.
+	Degraded(reason string, err error)
+}
 
-	// PutKey stores the information in v into the key. This is the inverse
-	// operation to GetKey
-	PutKey(v string) AllocatorKey
+// WithHealthReporter sets the reporter through which the allocator reports
+// the health of its watch and sync loops: degraded when the initial key list
+// does not complete within the list timeout, when local key synchronization
+// keeps failing, or when the kvstore watch restarts repeatedly, and OK
+// otherwise.
+func WithHealthReporter(reporter HealthReporter) AllocatorOption {
+	return func(a *Allocator) { a.healthReporter = reporter }
+}
 
-	// GetAsMap returns the key as a collection of "labels" with a key and value.
-	// This is the inverse operation to PutKeyFromMap.
-	GetAsMap() map[string]string
+// Reasons of the degraded health conditions reported through the reporter
+// set via WithHealthReporter.
+const (
+	healthReasonListStalled   = "initial key listing has not completed within timeout"
+	healthReasonSyncFailing   = "local key synchronization keeps failing"
+	healthReasonWatchFlapping = "kvstore watch is restarting repeatedly"
+)
 
-	// PutKeyFromMap stores the labels in v into the key to be used later. This
-	// is the inverse operation to GetAsMap.
-	PutKeyFromMap(v map[string]string) AllocatorKey
+// syncFailureHealthThreshold is the number of consecutive failed local key
+// synchronization rounds after which the allocator reports itself degraded.
+const syncFailureHealthThreshold = 3
 
-	// PutValue puts metadata inside the global identity for the given 'key' with
-	// the given 'value'.
-	PutValue(key any, value any) AllocatorKey
+// watchRestartHealthThreshold is the number of unexpected watch stream
+// terminations within watchRestartResetAfter after which the allocator
+// reports itself degraded.
+const watchRestartHealthThreshold = 3
 
-	// Value returns the value stored in the metadata map.
-	Value(key any) any
-}
+// watchRestartResetAfter is how long the watch must stay up for the restart
+// streak to be forgotten. Exposed as a variable for testing.
+var watchRestartResetAfter = time.Minute
 
-// Return values:
-//  1. allocated ID
-//  2. whether the ID is newly allocated from kvstore
-//  3. whether this is the first owner that holds a reference to the key in
-//     localkeys store
-//  4. error in case of failure
-func (a *Allocator) lockedAllocate(ctx context.Context, key AllocatorKey) (idpool.ID, bool, bool, error) {
-	var firstUse bool
+// setHealthCondition marks the given health condition as degraded and
+// reports the combined degraded state. It is a no-op without a health
+// reporter.
+func (a *Allocator) setHealthCondition(reason string, err error) {
+	if a.healthReporter == nil {
+		return
+	}
 
-	kvstore.Trace(a.logger, "Allocating key in kvstore", fieldKey, key)
+	a.healthConditionsMutex.Lock()
+	if a.healthConditions == nil {
+		a.healthConditions = map[string]error{}
+	}
+	a.healthConditions[reason] = err
+	reasons, errs := a.degradedStateLocked()
+	a.healthConditionsMutex.Unlock()
+
+	a.healthReporter.Degraded(reasons, errs)
+}
+
+// clearHealthCondition clears the given health condition, reporting the
+// allocator as healthy if no other condition remains degraded. It is a no-op
+// without a health reporter.
+func (a *Allocator) clearHealthCondition(reason string) {
+	if a.healthReporter == nil {
+		return
+	}
+
+	a.healthConditionsMutex.Lock()
+	delete(a.healthConditions, reason)
+	reasons, errs := a.degradedStateLocked()
+	a.healthConditionsMutex.Unlock()
+
+	if reasons == "" {
+		a.healthReporter.OK("allocator watch and sync loops operational")
+	} else {
+		a.healthReporter.Degraded(reasons, errs)
+	}
+}
+
+// degradedStateLocked combines the currently degraded health conditions into
+// a single reason and error. The caller must hold healthConditionsMutex.
+func (a *Allocator) degradedStateLocked() (string, error) {
+	reasons := slices.Sorted(maps.Keys(a.healthConditions))
+	var errs error
+	for _, reason := range reasons {
+		errs = errors.Join(errs, a.healthConditions[reason])
+	}
+	return strings.Join(reasons, "; "), errs
+}
+
+// observeWatchRestart records an unexpected termination of the watch stream,
+// reporting the allocator as degraded when terminations keep recurring. The
+// streak is forgotten once the watch stays up for watchRestartResetAfter.
+func (a *Allocator) observeWatchRestart() {
+	if a.healthReporter == nil {
+		return
+	}
+
+	a.healthConditionsMutex.Lock()
+	a.watchRestarts++
+	restarts := a.watchRestarts
+	if a.watchHealthyTimer != nil {
+		a.watchHealthyTimer.Stop()
+	}
+	a.watchHealthyTimer = time.AfterFunc(watchRestartResetAfter, func() {
+		a.healthConditionsMutex.Lock()
+		a.watchRestarts = 0
+		a.healthConditionsMutex.Unlock()
+		a.clearHealthCondition(healthReasonWatchFlapping)
+	})
+	a.healthConditionsMutex.Unlock()
+
+	if restarts >= watchRestartHealthThreshold {
+		a.setHealthCondition(healthReasonWatchFlapping, fmt.Errorf("watch stream terminated unexpectedly %d times", restarts))
+	}
+}
+
+// WithMetrics sets the sink the allocator reports its internal state through.
+// By default all observations are discarded.
+func WithMetrics(m Metrics) AllocatorOption {
+	return func(a *Allocator) { a.metrics = m }
+}
 
+// WithPreAllocateHook sets a hook invoked synchronously with the key about to
+// be allocated, before the corresponding kvstore operations are performed,
+// e.g. to record the allocation in an audit log. The hook is called without
+// any allocator lock held and must not call back into the allocator.
+func WithPreAllocateHook(f func(key AllocatorKey)) AllocatorOption {
+	return func(a *Allocator) { a.preAllocateHook = f }
+}
+
+// WithPostAllocateHook sets a hook invoked synchronously with the result of
+// every allocation attempt, once the corresponding kvstore operations have
+// completed. The hook is called without any allocator lock held and must not
+// call back into the allocator.
+func WithPostAllocateHook(f func(key AllocatorKey, id idpool.ID, isNew bool, err error)) AllocatorOption {
+	return func(a *Allocator) { a.postAllocateHook = f }
+}
+
+// GetEvents returns the events channel given to the allocator when
+// constructed.
+// Note: This channel is not owned by the allocator!
+func (a *Allocator) GetEvents() AllocatorEventSendChan {
+	return a.events
+}
+
+// CheckHealth verifies connectivity to the backend by performing a
+// lightweight read for a sentinel key. It returns nil if the backend responds
+// within a short timeout and a descriptive error otherwise.
+func (a *Allocator) CheckHealth(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	if _, err := a.backend.Get(ctx, a.keyType); err != nil {
+		return fmt.Errorf("allocator backend is not reachable: %w", err)
+	}
+	return nil
+}
+
+// startHealthMonitor runs the background goroutine started via
+// WithHealthMonitor. It feeds the outcome of periodic health checks into the
+// backend health tracking until the allocator is deleted.
+func (a *Allocator) startHealthMonitor() {
+	a.backgroundWG.Add(1)
+	go func() {
+		defer a.backgroundWG.Done()
+		for {
+			select {
+			case <-a.stopGC:
+				a.getLogger().Debug("Stopped backend health monitor")
+				return
+			case <-time.After(a.healthMonitorInterval):
+			}
+
+			a.observeBackendResult(a.CheckHealth(context.Background()))
+		}
+	}()
+}
+
+// observeBackendResult feeds the outcome of a backend operation into the
+// backend health tracking. It is a no-op when the health monitor is not
+// enabled. Context cancellations are caused by the caller rather than the
+// backend and are ignored.
+func (a *Allocator) observeBackendResult(err error) {
+	if a.healthMonitorInterval == 0 {
+		return
+	}
+
+	if err == nil {
+		a.observeBackendSuccess()
+	} else if !errors.Is(err, context.Canceled) {
+		a.observeBackendError()
+	}
+}
+
+// observeBackendError records a failed backend operation and enters degraded
+// mode once errors have persisted over the full backendErrorWindow.
+func (a *Allocator) observeBackendError() {
+	a.backendHealthMutex.Lock()
+	defer a.backendHealthMutex.Unlock()
+
+	now := time.Now()
+	if a.firstBackendError.IsZero() {
+		a.firstBackendError = now
+		return
+	}
+
+	if now.Sub(a.firstBackendError) >= a.backendErrorWindow {
+		a.enterDegradedModeLocked()
+	}
+}
+
+// observeBackendSuccess records a successful backend operation, resetting the
+// error streak and leaving degraded mode if it was active.
+func (a *Allocator) observeBackendSuccess() {
+	a.backendHealthMutex.Lock()
+	a.firstBackendError = time.Time{}
+	recovered := a.exitDegradedModeLocked()
+	a.backendHealthMutex.Unlock()
+
+	if recovered {
+		a.replayDeferredWrites()
+	}
+}
+
+// enterDegradedModeLocked switches the allocator into degraded mode. The
+// caller must hold backendHealthMutex.
+func (a *Allocator) enterDegradedModeLocked() {
+	if !a.backendHealthy.CompareAndSwap(true, false) {
+		return
+	}
+
+	a.backendRecovered = make(waitChan)
+	a.getLogger().Warn("Backend errors persisted over the error window, serving allocations from the local cache in degraded mode",
+		logfields.Duration, a.backendErrorWindow,
+	)
+}
+
+// exitDegradedModeLocked switches the allocator back into healthy mode and
+// unblocks allocations waiting for recovery. It returns true if degraded mode
+// was active. The caller must hold backendHealthMutex.
+func (a *Allocator) exitDegradedModeLocked() bool {
+	if !a.backendHealthy.CompareAndSwap(false, true) {
+		return false
+	}
+
+	close(a.backendRecovered)
+	a.getLogger().Info("Backend recovered, leaving degraded mode")
+	return true
+}
+
+// waitForBackendRecovery blocks until the allocator leaves degraded mode or
+// the context expires.
+func (a *Allocator) waitForBackendRecovery(ctx context.Context) error {
+	a.backendHealthMutex.Lock()
+	if a.backendHealthy.Load() {
+		a.backendHealthMutex.Unlock()
+		return nil
+	}
+	recovered := a.backendRecovered
+	a.backendHealthMutex.Unlock()
+
+	select {
+	case <-recovered:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("allocation was cancelled while waiting for the allocator backend to recover: %w", ctx.Err())
+	}
+}
+
+// allocateDegraded attempts to serve an allocation from the main cache while
+// the allocator is in degraded mode. On a cache hit a local reference is
+// taken immediately and the backend write is queued for replay once the
+// backend recovers. The last return value indicates whether the allocation
+// could be served; on a cache miss the caller is expected to wait for the
+// backend to recover.
+func (a *Allocator) allocateDegraded(key AllocatorKey) (idpool.ID, bool, bool) {
 	k := key.GetKey()
-	lock, err := a.backend.Lock(ctx, key)
+	id := a.mainCache.get(k)
+	if id == idpool.NoID {
+		return idpool.NoID, false, false
+	}
+
+	a.slaveKeysMutex.Lock()
+	defer a.slaveKeysMutex.Unlock()
+
+	_, firstUse, err := a.localKeys.allocate(k, key, id)
+	if err != nil {
+		return idpool.NoID, false, false
+	}
+
+	a.backendHealthMutex.Lock()
+	if a.deferredWrites == nil {
+		a.deferredWrites = map[string]AllocatorKey{}
+	}
+	a.deferredWrites[k] = key
+	a.backendHealthMutex.Unlock()
+
+	a.getLogger().Warn("Serving allocation from the local cache in degraded mode",
+		logfields.Key, key,
+		logfields.ID, id,
+	)
+	metrics.IdentityDegradedAllocations.Inc()
+
+	return id, firstUse, true
+}
+
+// replayDeferredWrites re-creates the backend state for the keys that were
+// served from the local cache while in degraded mode. Keys that were released
+// in the meantime are skipped; the garbage collector cleans up after any
+// replay that races with a concurrent release.
+func (a *Allocator) replayDeferredWrites() {
+	a.backendHealthMutex.Lock()
+	pending := a.deferredWrites
+	a.deferredWrites = nil
+	a.backendHealthMutex.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	go func() {
+		for k, key := range pending {
+			id := a.localKeys.lookupKey(k)
+			if id == idpool.NoID {
+				continue
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), backendOpTimeout)
+			err := a.backend.UpdateKey(ctx, id, key, true)
+			cancel()
+			if err != nil {
+				a.getLogger().Warn("Unable to replay deferred write after backend recovery",
+					logfields.Error, err,
+					logfields.Key, key,
+					logfields.ID, id,
+				)
+				continue
+			}
+
+			if err := a.localKeys.verify(k); err != nil {
+				a.getLogger().Error("BUG: Unable to verify local key", logfields.Error, err)
+			}
+		}
+	}()
+}
+
+// Delete deletes an allocator and stops the garbage collector. It blocks
+// until the background goroutines of the allocator have terminated; use
+// Shutdown to bound the wait. Delete is idempotent.
+func (a *Allocator) Delete() {
+	a.Shutdown(context.Background())
+}
+
+// Shutdown stops the garbage collector, the local key sync routine, the
+// watchers of the remote caches created through NewRemoteCache, and the main
+// cache, and waits until all of them have terminated. It returns an error
+// when ctx expires before the teardown completes. Shutdown is idempotent;
+// concurrent and repeated calls all wait for the same teardown.
+func (a *Allocator) Shutdown(ctx context.Context) error {
+	a.shutdownOnce.Do(func() {
+		close(a.stopGC)
+		a.mainCache.stop()
+		a.flushPendingReleases()
+
+		a.healthConditionsMutex.Lock()
+		if a.watchHealthyTimer != nil {
+			a.watchHealthyTimer.Stop()
+		}
+		a.healthConditionsMutex.Unlock()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		a.backgroundWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("allocator teardown did not complete: %w", ctx.Err())
+	}
+}
+
+// WaitForInitialSync waits until the initial sync is complete
+func (a *Allocator) WaitForInitialSync(ctx context.Context) error {
+	select {
+	case <-a.initialListDone:
+	case <-ctx.Done():
+		return fmt.Errorf("identity sync was cancelled: %w", ctx.Err())
+	}
+
+	return nil
+}
+
+// RangeFunc is the function called by RangeCache
+type RangeFunc func(idpool.ID, AllocatorKey)
+
+// ForeachCache iterates over the allocator cache and calls RangeFunc on each
+// cached entry
+func (a *Allocator) ForeachCache(cb RangeFunc) {
+	a.mainCache.foreach(cb)
+
+	a.remoteCachesMutex.RLock()
+	for _, rc := range a.remoteCaches {
+		rc.cache.foreach(cb)
+	}
+	a.remoteCachesMutex.RUnlock()
+}
+
+// ForeachCacheByIDRange iterates over the allocator cache and calls RangeFunc
+// on each cached entry with an ID in the range [min, max]. Entries outside
+// the range are skipped without invoking the callback, allowing allocators
+// responsible for only a slice of the ID space to avoid filtering themselves.
+func (a *Allocator) ForeachCacheByIDRange(minID, maxID idpool.ID, cb RangeFunc) {
+	a.mainCache.foreachByIDRange(minID, maxID, cb)
+
+	a.remoteCachesMutex.RLock()
+	for _, rc := range a.remoteCaches {
+		rc.cache.foreachByIDRange(minID, maxID, cb)
+	}
+	a.remoteCachesMutex.RUnlock()
+}
+
+// GetAllLocalKeys returns a snapshot of the key-to-ID mappings in use by this
+// node, keyed by the encoded key string. Unlike ForeachCache, entries cached
+// from remote clusters are not included.
+func (a *Allocator) GetAllLocalKeys() map[string]idpool.ID {
+	return a.localKeys.getAllKeys()
+}
+
+// selectAvailableID selects an available ID.
+// Returns a triple of the selected ID ORed with prefixMask, the ID string and
+// the originally selected ID.
+func (a *Allocator) selectAvailableID() (idpool.ID, string, idpool.ID) {
+	if id := a.idPool.LeaseAvailableID(); id != idpool.NoID {
+		unmaskedID := id
+		id |= a.prefixMask
+
+		a.leasedIDsMutex.Lock()
+		a.leasedIDs[unmaskedID] = time.Now()
+		a.leasedIDsMutex.Unlock()
+
+		return id, id.String(), unmaskedID
+	}
+
+	return 0, "", 0
+}
+
+// useLeasedID marks a leased ID as used in the pool and stops tracking the
+// lease.
+func (a *Allocator) useLeasedID(id idpool.ID) {
+	a.idPool.Use(id)
+	a.forgetLease(id)
+}
+
+// releaseLeasedID returns a leased ID to the pool to be re-used for other
+// keys and stops tracking the lease.
+func (a *Allocator) releaseLeasedID(id idpool.ID) {
+	a.idPool.Release(id)
+	a.forgetLease(id)
+}
+
+func (a *Allocator) forgetLease(id idpool.ID) {
+	a.leasedIDsMutex.Lock()
+	delete(a.leasedIDs, id)
+	a.leasedIDsMutex.Unlock()
+}
+
+// checkLeasedIDLeaks reports leases older than leakedLeaseThreshold. Leases
+// that were resolved through the pool directly, e.g. by a watcher event
+// removing the ID, are dropped from the tracking instead.
+func (a *Allocator) checkLeasedIDLeaks() {
+	leaked := 0
+
+	a.leasedIDsMutex.Lock()
+	for id, since := range a.leasedIDs {
+		if !a.idPool.IsLeased(id) {
+			delete(a.leasedIDs, id)
+			continue
+		}
+		if age := time.Since(since); age > a.leakedLeaseThreshold {
+			leaked++
+			a.getLogger().Warn("Leased ID was neither used nor released, shrinking the usable ID space until restart",
+				logfields.ID, id,
+				logfields.Duration, age,
+			)
+		}
+	}
+	a.leasedIDsMutex.Unlock()
+
+	a.metrics.SetLeakedLeases(leaked)
+}
+
+// IDPoolStats returns the number of IDs in the configured allocation range
+// that are available for allocation, leased but not yet used or released,
+// and in use.
+func (a *Allocator) IDPoolStats() (available, leased, used uint64) {
+	available = uint64(a.idPool.AvailableIDs())
+	leased = uint64(a.idPool.LeasedIDs())
+	used = uint64(a.max-a.min+1) - available - leased
+	return available, leased, used
+}
+
+// AllocatorKey is the interface to implement in order for a type to be used as
+// key for the allocator. The key's data is assumed to be a collection of
+// pkg/label.Label, and the functions reflect this somewhat.
+type AllocatorKey interface {
+	fmt.Stringer
+
+	// GetKey returns the canonical string representation of the key
+	GetKey() string
+
+	// IsEqualTo returns true if other represents the same key. The result is
+	// equivalent to comparing the GetKey representations, but implementations
+	// may compare their internal fields directly without serializing them.
+	IsEqualTo(other AllocatorKey) bool
+
+	// PutKey stores the information in v into the key. This is the inverse
+	// operation to GetKey
+	PutKey(v string) AllocatorKey
+
+	// GetAsMap returns the key as a collection of "labels" with a key and value.
+	// This is the inverse operation to PutKeyFromMap.
+	GetAsMap() map[string]string
+
+	// PutKeyFromMap stores the labels in v into the key to be used later. This
+	// is the inverse operation to GetAsMap.
+	PutKeyFromMap(v map[string]string) AllocatorKey
+
+	// PutValue puts metadata inside the global identity for the given 'key' with
+	// the given 'value'.
+	PutValue(key any, value any) AllocatorKey
+
+	// Value returns the value stored in the metadata map.
+	Value(key any) any
+}
+
+// KeyVersionPrefix returns the prefix carried by the canonical
+// representation of keys serialized with the given version. Version 0 is
+// the unversioned format and carries no prefix.
+func KeyVersionPrefix(version uint8) string {
+	if version == 0 {
+		return ""
+	}
+	return "v" + strconv.FormatUint(uint64(version), 10) + "/"
+}
+
+// anyKeyVersionRegex matches the prefix produced by KeyVersionPrefix for
+// any non-zero version.
+var anyKeyVersionRegex = regexp.MustCompile(`^v[0-9]+/`)
+
+// HasKeyVersionPrefix reports whether s carries a key serialization version
+// prefix, regardless of the version number.
+func HasKeyVersionPrefix(s string) bool {
+	return anyKeyVersionRegex.MatchString(s)
+}
+
+// versionedKey wraps an AllocatorKey so that its canonical representation
+// carries the key serialization version configured via WithKeyVersion.
+type versionedKey struct {
+	AllocatorKey
+	version uint8
+}
+
+func (v versionedKey) GetKey() string {
+	return KeyVersionPrefix(v.version) + v.AllocatorKey.GetKey()
+}
+
+func (v versionedKey) String() string {
+	return v.GetKey()
+}
+
+func (v versionedKey) IsEqualTo(other AllocatorKey) bool {
+	if o, ok := other.(versionedKey); ok {
+		return v.version == o.version && v.AllocatorKey.IsEqualTo(o.AllocatorKey)
+	}
+	return other != nil && v.GetKey() == other.GetKey()
+}
+
+func (v versionedKey) PutKey(s string) AllocatorKey {
+	return versionedKey{
+		AllocatorKey: v.AllocatorKey.PutKey(strings.TrimPrefix(s, KeyVersionPrefix(v.version))),
+		version:      v.version,
+	}
+}
+
+func (v versionedKey) PutKeyFromMap(m map[string]string) AllocatorKey {
+	return versionedKey{AllocatorKey: v.AllocatorKey.PutKeyFromMap(m), version: v.version}
+}
+
+func (v versionedKey) PutValue(key any, value any) AllocatorKey {
+	return versionedKey{AllocatorKey: v.AllocatorKey.PutValue(key, value), version: v.version}
+}
+
+// aliasedKey wraps an AllocatorKey so that its canonical representation is an
+// old encoding reported by the function configured via WithKeyAliases. It is
+// only used to consult the backend under the old encoding; keys are always
+// written back in the current encoding.
+type aliasedKey struct {
+	AllocatorKey
+	alias string
+}
+
+func (ak aliasedKey) GetKey() string {
+	return ak.alias
+}
+
+func (ak aliasedKey) String() string {
+	return ak.alias
+}
+
+func (ak aliasedKey) IsEqualTo(other AllocatorKey) bool {
+	return other != nil && ak.GetKey() == other.GetKey()
+}
+
+// getCachedID looks the key up in the main cache, first under its current
+// canonical representation and then under each alias encoding configured via
+// WithKeyAliases.
+func (a *Allocator) getCachedID(key AllocatorKey) idpool.ID {
+	if id := a.mainCache.get(key.GetKey()); id != idpool.NoID {
+		return id
+	}
+	if a.keyAliases != nil {
+		for _, alias := range a.keyAliases(key) {
+			if id := a.mainCache.get(alias); id != idpool.NoID {
+				return id
+			}
+		}
+	}
+	return idpool.NoID
+}
+
+// versionKey wraps the given key so that its canonical representation
+// carries the configured key serialization version. It is a no-op when key
+// versioning is not in use or the key is already versioned.
+func (a *Allocator) versionKey(key AllocatorKey) AllocatorKey {
+	if a.keyVersion == 0 || key == nil {
+		return key
+	}
+	if _, ok := key.(versionedKey); ok {
+		return key
+	}
+	return versionedKey{AllocatorKey: key, version: a.keyVersion}
+}
+
+// Return values:
+//  1. allocated ID
+//  2. whether the ID is newly allocated from kvstore
+//  3. whether this is the first owner that holds a reference to the key in
+//     localkeys store
+//  4. error in case of failure
+func (a *Allocator) lockedAllocate(ctx context.Context, key AllocatorKey) (id idpool.ID, isNew bool, firstUse bool, err error) {
+	if a.preAllocateHook != nil {
+		a.preAllocateHook(key)
+	}
+	if a.postAllocateHook != nil {
+		// Registered before the slave keys mutex is taken below, so that it
+		// runs after the mutex has been released again.
+		defer func() { a.postAllocateHook(key, id, isNew, err) }()
+	}
+
+	kvstore.Trace(a.getLogger(), "Allocating key in kvstore", fieldKey, key)
+
+	k := key.GetKey()
+	opCtx, cancel := a.opTimeoutContext(ctx)
+	lock, err := a.backend.Lock(opCtx, key)
+	cancel()
 	if err != nil {
 		return 0, false, false, err
 	}
@@ -539,16 +1959,21 @@ func (a *Allocator) lockedAllocate(ctx context.Context, key AllocatorKey) (idpoo
 	defer lock.Unlock(context.Background())
 
 	// fetch first key that matches /value/<key>
-	value, err := a.GetIfLocked(ctx, key, lock)
+	opCtx, cancel = a.opTimeoutContext(ctx)
+	value, err := a.GetIfLocked(opCtx, key, lock)
+	cancel()
 	if err != nil {
 		return 0, false, false, err
 	}
 
-	kvstore.Trace(a.logger, "kvstore state is: ", fieldID, value)
+	kvstore.Trace(a.getLogger(), "kvstore state is: ", fieldID, value)
 
 	a.slaveKeysMutex.Lock()
 	defer a.slaveKeysMutex.Unlock()
 
+	// A new user of the key cancels any deferred release scheduled for it.
+	a.cancelPendingReleaseLocked(k)
+
 	// We shouldn't assume the fact the master key does not exist in the kvstore
 	// that localKeys does not have it. The KVStore might have lost all of its
 	// data but the local agent still holds a reference for the given master key.
@@ -556,7 +1981,10 @@ func (a *Allocator) lockedAllocate(ctx context.Context, key AllocatorKey) (idpoo
 		value = a.localKeys.lookupKey(k)
 		if value != 0 {
 			// re-create master key
-			if err := a.backend.UpdateKeyIfLocked(ctx, value, key, true, lock); err != nil {
+			opCtx, cancel := a.opTimeoutContext(ctx)
+			err := a.backend.UpdateKeyIfLocked(opCtx, value, key, true, lock)
+			cancel()
+			if err != nil {
 				return 0, false, false, fmt.Errorf("unable to re-create missing master key '%s': %s while allocating ID: %w", key, value, err)
 			}
 		}
@@ -567,44 +1995,68 @@ func (a *Allocator) lockedAllocate(ctx context.Context, key AllocatorKey) (idpoo
 		}
 
 		if firstUse {
-			a.logger.Debug("Reserved new local key", logfields.Key, k)
+			a.getLogger().Debug("Reserved new local key", logfields.Key, k)
 		} else {
-			a.logger.Debug("Reusing existing local key", logfields.Key, k)
+			a.getLogger().Debug("Reusing existing local key", logfields.Key, k)
+		}
+
+		if firstUse && a.keyAliases != nil {
+			// The ID may have been resolved through an alias encoding. If
+			// the master key is missing under the current encoding, rewrite
+			// it now so that the migration converges as keys are used.
+			opCtx, cancel := a.opTimeoutContext(ctx)
+			canonical, err := a.backend.GetIfLocked(opCtx, key, lock)
+			cancel()
+			if err == nil && canonical == idpool.NoID {
+				a.getLogger().Debug("Rewriting master key found under an alias encoding", logfields.Key, k)
+				opCtx, cancel = a.opTimeoutContext(ctx)
+				if err := a.backend.UpdateKeyIfLocked(opCtx, value, key, true, lock); err != nil {
+					a.getLogger().Warn("Unable to rewrite master key under the current encoding",
+						logfields.Error, err,
+						logfields.Key, k,
+					)
+				}
+				cancel()
+			}
 		}
 	}
 
 	if value != 0 {
-		a.logger.Debug("Reusing existing global key", logfields.Key, k)
+		a.getLogger().Debug("Reusing existing global key", logfields.Key, k)
 
-		if err = a.backend.AcquireReference(ctx, value, key, lock); err != nil {
+		opCtx, cancel = a.opTimeoutContext(ctx)
+		err = a.backend.AcquireReference(opCtx, value, key, lock)
+		cancel()
+		if err != nil {
 			a.localKeys.release(k)
 			return 0, false, false, fmt.Errorf("unable to create secondary key '%s': %w", k, err)
 		}
 
 		// mark the key as verified in the local cache
 		if err := a.localKeys.verify(k); err != nil {
-			a.logger.Error("BUG: Unable to verify local key", logfields.Error, err)
+			a.getLogger().Error("BUG: Unable to verify local key", logfields.Error, err)
 		}
 
+		a.metrics.SetLocalKeys(a.localKeys.count())
 		return value, false, firstUse, nil
 	}
 
-	a.logger.Debug("Allocating new master ID", logfields.Key, k)
+	a.getLogger().Debug("Allocating new master ID", logfields.Key, k)
 	id, strID, unmaskedID := a.selectAvailableID()
 	if id == 0 {
-		return 0, false, false, fmt.Errorf("no more available IDs in configured space")
+		return 0, false, false, ErrIDSpaceExhausted
 	}
 
-	kvstore.Trace(a.logger, "Selected available key ID", fieldID, id)
+	kvstore.Trace(a.getLogger(), "Selected available key ID", fieldID, id)
 
 	releaseKeyAndID := func() {
 		a.localKeys.release(k)
-		a.idPool.Release(unmaskedID) // This returns this ID to be re-used for other keys
+		a.releaseLeasedID(unmaskedID) // This returns this ID to be re-used for other keys
 	}
 
 	oldID, firstUse, err := a.localKeys.allocate(k, key, id)
 	if err != nil {
-		a.idPool.Release(unmaskedID)
+		a.releaseLeasedID(unmaskedID)
 		return 0, false, false, fmt.Errorf("unable to reserve local key '%s': %w", k, err)
 	}
 
@@ -612,150 +2064,459 @@ func (a *Allocator) lockedAllocate(ctx context.Context, key AllocatorKey) (idpoo
 	// start over
 	if id != oldID {
 		releaseKeyAndID()
-		return 0, false, false, fmt.Errorf("another writer has allocated key %s", k)
+		return 0, false, false, fmt.Errorf("%w: another writer has allocated key %s", ErrKeyConflict, k)
 	}
 
 	// Check that this key has not been allocated in the cluster during our
 	// operation here
-	value, err = a.GetNoCache(ctx, key)
+	opCtx, cancel = a.opTimeoutContext(ctx)
+	value, err = a.GetNoCache(opCtx, key)
+	cancel()
 	if err != nil {
 		releaseKeyAndID()
 		return 0, false, false, err
 	}
 	if value != 0 {
 		releaseKeyAndID()
-		return 0, false, false, fmt.Errorf("Found master key after proceeding with new allocation for %s", k)
+		return 0, false, false, fmt.Errorf("%w: found master key after proceeding with new allocation for %s", ErrKeyConflict, k)
+	}
+
+	// Assigned to 'key' from 'key2' since in case of an error, we don't replace
+	// the original 'key' variable with 'nil'.
+	key2 := key
+	opCtx, cancel = a.opTimeoutContext(ctx)
+	key, err = a.backend.AllocateIDIfLocked(opCtx, id, key2, lock)
+	cancel()
+	a.observeBackendResult(err)
+	if err != nil {
+		// Creation failed. Another agent most likely beat us to allocting this
+		// ID, retry.
+		releaseKeyAndID()
+		return 0, false, false, fmt.Errorf("%w: unable to allocate ID %s for key %s: %w", ErrKeyConflict, strID, key2, err)
+	}
+
+	// Notify pool that leased ID is now in-use.
+	a.useLeasedID(unmaskedID)
+
+	opCtx, cancel = a.opTimeoutContext(ctx)
+	err = a.backend.AcquireReference(opCtx, id, key, lock)
+	cancel()
+	if err != nil {
+		// We will leak the master key here as the key has already been
+		// exposed and may be in use by other nodes. The garbage
+		// collector will release it again.
+		releaseKeyAndID()
+		return 0, false, false, fmt.Errorf("secondary key creation failed '%s': %w", k, err)
+	}
+
+	// mark the key as verified in the local cache
+	if err := a.localKeys.verify(k); err != nil {
+		a.getLogger().Error("BUG: Unable to verify local key", logfields.Error, err)
+	}
+
+	a.getLogger().Debug("Allocated new global key", logfields.Key, k)
+
+	a.metrics.SetLocalKeys(a.localKeys.count())
+	a.metrics.SetAvailableIDs(a.idPool.AvailableIDs())
+	return id, true, firstUse, nil
+}
+
+// Allocate will retrieve the ID for the provided key. If no ID has been
+// allocated for this key yet, a key will be allocated. If allocation fails,
+// most likely due to a parallel allocation of the same ID by another user,
+// allocation is re-attempted for maxAllocAttempts times.
+//
+// Return values:
+//  1. allocated ID
+//  2. whether the ID is newly allocated from kvstore
+//  3. whether this is the first owner that holds a reference to the key in
+//     localkeys store
+//  4. error in case of failure
+func (a *Allocator) Allocate(ctx context.Context, key AllocatorKey) (value idpool.ID, isNew bool, firstUse bool, err error) {
+	key = a.versionKey(key)
+	a.getLogger().Debug("Allocating key", logfields.Key, key)
+
+	attempts := 0
+	defer func(start time.Time) {
+		a.metrics.IncAllocation(allocationOutcome(isNew, firstUse, err))
+		a.metrics.ObserveAllocateDuration(time.Since(start).Seconds())
+		if attempts > 0 {
+			a.metrics.ObserveAllocateAttempts(attempts)
+		}
+	}(time.Now())
+
+	select {
+	case <-a.initialListDone:
+	case <-a.warmupActive:
+		// The initial list may have completed after the warmup snapshot was
+		// activated, in which case the authoritative state is preferred.
+		select {
+		case <-a.initialListDone:
+		default:
+			return a.allocateFromWarmupSnapshot(key)
+		}
+	case <-ctx.Done():
+		return 0, false, false, fmt.Errorf("allocation was cancelled while waiting for initial key list to be received: %w", ctx.Err())
+	}
+
+	if a.operatorIDManagement {
+		// The second and third return values are always false when
+		// operatorIDManagement is enabled because cilium-operator manages security
+		// IDs, and they are never newly allocated or require holding a reference to
+		// a key.
+		if !a.disableAutostart {
+			// The watcher feeds the main cache, so the identity shows up
+			// the moment cilium-operator creates it, without putting
+			// polling load on the backend.
+			id, err := a.WaitForID(ctx, key)
+			return id, false, false, err
+		}
+		id, err := a.GetWithRetry(ctx, key)
+		return id, false, false, err
+	}
+
+	kvstore.Trace(a.getLogger(), "Allocating from kvstore", fieldKey, key)
+
+	boff := a.retryBackoff(key)
+
+	for attempt := range a.maxAllocAttempts {
+		attempts++
+
+		// Check our list of local keys already in use and increment the
+		// refcnt. The returned key must be released afterwards. No kvstore
+		// operation was performed for this allocation.
+		// We also do this on every loop as a different Allocate call might have
+		// allocated the key while we are attempting to allocate in this
+		// execution thread. It does not hurt to check if localKeys contains a
+		// reference for the key that we are attempting to allocate.
+		if val := a.localKeys.use(key.GetKey()); val != idpool.NoID {
+			kvstore.Trace(a.getLogger(), "Reusing local id",
+				fieldID, val,
+				fieldKey, key,
+			)
+			a.mainCache.insert(key, val)
+			a.metrics.SetCacheSize(a.mainCache.numEntries())
+			return val, false, false, nil
+		}
+
+		// While the backend is unhealthy, serve the allocation from the
+		// main cache and defer the backend write until recovery. For keys
+		// not present in the cache no ID can be selected safely, so block
+		// until the backend recovers or the context expires.
+		if a.healthMonitorInterval > 0 && !a.backendHealthy.Load() {
+			if id, firstUse, ok := a.allocateDegraded(key); ok {
+				return id, false, firstUse, nil
+			}
+			if err := a.waitForBackendRecovery(ctx); err != nil {
+				return 0, false, false, err
+			}
+		}
+
+		// FIXME: Add non-locking variant
+		value, isNew, firstUse, err = a.lockedAllocate(ctx, key)
+		if err == nil {
+			a.mainCache.insert(key, value)
+			a.metrics.SetCacheSize(a.mainCache.numEntries())
+			a.getLogger().Debug("Allocated key",
+				logfields.Key, key,
+				logfields.ID, value,
+			)
+			return value, isNew, firstUse, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			a.getLogger().Warn("Ongoing key allocation has been cancelled",
+				logfields.Error, ctx.Err(),
+				logfields.Key, key,
+				logfields.Attempt, attempt,
+			)
+			return 0, false, false, fmt.Errorf("key allocation cancelled: %w", ctx.Err())
+		default:
+			a.getLogger().Warn("Key allocation attempt failed",
+				logfields.Error, err,
+				logfields.Key, key,
+				logfields.Attempt, attempt,
+			)
+		}
+
+		kvstore.Trace(a.getLogger(), "Allocation attempt failed",
+			fieldKey, key,
+			logfields.Attempt, attempt,
+		)
+
+		// Retrying a non-retryable error only delays surfacing the failure.
+		// An expired per-operation timeout is the exception: the caller
+		// context still has budget for further attempts.
+		if !IsRetryable(err) && !a.isOpTimeout(ctx, err) {
+			return 0, false, false, err
+		}
+
+		if waitErr := boff.Wait(ctx); waitErr != nil {
+			return 0, false, false, fmt.Errorf("%w: %w", ErrBackendTimeout, waitErr)
+		}
+	}
+
+	return 0, false, false, err
+}
+
+var (
+	// ErrWouldBlock is returned by TryAllocate when the allocation cannot
+	// proceed without blocking, e.g. while the initial key list is still
+	// being received.
+	ErrWouldBlock = errors.New("allocation would block")
+
+	// ErrBackendUnavailable is returned by TryAllocate, wrapping the
+	// underlying error, when the single backend attempt fails.
+	ErrBackendUnavailable = errors.New("allocator backend unavailable")
+
+	// ErrIDSpaceExhausted is returned when all IDs in the configured
+	// allocation range are in use and no new ID can be selected.
+	ErrIDSpaceExhausted = errors.New("no more available IDs in configured space")
+
+	// ErrKeyConflict is returned when a concurrent writer claimed the key
+	// or the selected ID first. The allocation may succeed when retried.
+	ErrKeyConflict = errors.New("key allocation conflict")
+
+	// ErrBackendTimeout is returned when the allocation gave up waiting for
+	// the backend, or for cilium-operator in operator-managed mode.
+	ErrBackendTimeout = errors.New("timed out waiting for allocator backend")
+
+	// ErrNotAllocatedLocally is returned by Release when the key holds no
+	// local references, typically indicating a double release. Callers
+	// performing intentionally idempotent cleanup may ignore it.
+	ErrNotAllocatedLocally = errors.New("key has no local references")
+)
+
+// TryAllocate is a non-blocking variant of Allocate: it performs at most one
+// local cache lookup and one backend attempt, without retries, backoff or
+// sleeping. It returns ErrWouldBlock when the initial key list has not been
+// received yet, and ErrBackendUnavailable when the backend attempt fails,
+// allowing latency-sensitive callers to fall back to a deferred allocation
+// instead of waiting out backoff cycles. The return values are the same as
+// those of Allocate.
+func (a *Allocator) TryAllocate(ctx context.Context, key AllocatorKey) (value idpool.ID, isNew bool, firstUse bool, err error) {
+	key = a.versionKey(key)
+	a.getLogger().Debug("Trying non-blocking key allocation", logfields.Key, key)
+
+	defer func(start time.Time) {
+		a.metrics.IncAllocation(allocationOutcome(isNew, firstUse, err))
+		a.metrics.ObserveAllocateDuration(time.Since(start).Seconds())
+	}(time.Now())
+
+	select {
+	case <-a.initialListDone:
+	default:
+		return 0, false, false, ErrWouldBlock
+	}
+
+	if a.operatorIDManagement {
+		id, err := a.Get(ctx, key)
+		switch {
+		case err != nil:
+			return 0, false, false, fmt.Errorf("%w: %w", ErrBackendUnavailable, err)
+		case id == idpool.NoID:
+			// cilium-operator has not created the identity yet.
+			return 0, false, false, ErrWouldBlock
+		}
+		return id, false, false, nil
+	}
+
+	if val := a.localKeys.use(key.GetKey()); val != idpool.NoID {
+		a.mainCache.insert(key, val)
+		a.metrics.SetCacheSize(a.mainCache.numEntries())
+		return val, false, false, nil
+	}
+
+	// While the backend is unhealthy, the degraded path can still serve keys
+	// present in the main cache; anything else fails fast.
+	if a.healthMonitorInterval > 0 && !a.backendHealthy.Load() {
+		if id, firstUse, ok := a.allocateDegraded(key); ok {
+			return id, false, firstUse, nil
+		}
+		return 0, false, false, ErrBackendUnavailable
+	}
+
+	value, isNew, firstUse, err = a.lockedAllocate(ctx, key)
+	if err != nil {
+		return 0, false, false, fmt.Errorf("%w: %w", ErrBackendUnavailable, err)
+	}
+
+	a.mainCache.insert(key, value)
+	a.metrics.SetCacheSize(a.mainCache.numEntries())
+	return value, isNew, firstUse, nil
+}
+
+// IDConflictError is returned by AllocateWithID when the requested ID cannot
+// be reclaimed because it is already allocated to a different key, or because
+// the key is already associated with a different ID.
+type IDConflictError struct {
+	// ID is the identity requested for reclamation.
+	ID idpool.ID
+
+	// Key is the key for which the reclamation was requested.
+	Key AllocatorKey
+
+	// ExistingKey is the key currently holding ID, if that is the conflict.
+	ExistingKey AllocatorKey
+
+	// ExistingID is the ID Key is currently associated with, if that is the
+	// conflict.
+	ExistingID idpool.ID
+}
+
+func (e IDConflictError) Error() string {
+	if e.ExistingKey != nil {
+		return fmt.Sprintf("ID %s is already allocated to key %s", e.ID, e.ExistingKey)
+	}
+	return fmt.Sprintf("key %s is already associated with ID %s", e.Key, e.ExistingID)
+}
+
+// AllocateWithID (re)creates the master key for 'key' at exactly the
+// requested ID. It is intended for the endpoint restore path, where restored
+// endpoints already know the numeric identity they were using before the
+// agent restarted, and falling back to an arbitrary available ID would break
+// identity stability in the policy maps until convergence. The returned
+// isNew value reports whether the master key had to be recreated. When the
+// ID is held by a different key, or the key is already associated with a
+// different ID, an IDConflictError is returned and the caller should fall
+// back to generic allocation via Allocate.
+func (a *Allocator) AllocateWithID(ctx context.Context, key AllocatorKey, id idpool.ID) (isNew bool, err error) {
+	if a.operatorIDManagement {
+		return false, errors.New("reclaiming a specific ID is not supported when cilium-operator manages identities")
+	}
+
+	key = a.versionKey(key)
+	a.getLogger().Debug("Allocating key with pre-reserved ID",
+		logfields.Key, key,
+		logfields.ID, id,
+	)
+
+	select {
+	case <-a.initialListDone:
+	case <-ctx.Done():
+		return false, fmt.Errorf("allocation was cancelled while waiting for initial key list to be received: %w", ctx.Err())
+	}
+
+	k := key.GetKey()
+
+	lock, err := a.backend.Lock(ctx, key)
+	if err != nil {
+		return false, err
+	}
+
+	defer lock.Unlock(context.Background())
+
+	value, err := a.GetIfLocked(ctx, key, lock)
+	if err != nil {
+		return false, err
+	}
+
+	a.slaveKeysMutex.Lock()
+	defer a.slaveKeysMutex.Unlock()
+
+	if value != 0 && value != id {
+		return false, IDConflictError{ID: id, Key: key, ExistingID: value}
+	}
+
+	if value == 0 {
+		// Ensure the requested ID has not been given out to a different key
+		// in the meantime.
+		existingKey, err := a.backend.GetByID(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		if existingKey != nil && !existingKey.IsEqualTo(key) {
+			return false, IDConflictError{ID: id, Key: key, ExistingKey: existingKey}
+		}
+	}
+
+	oldID, _, err := a.localKeys.allocate(k, key, id)
+	if err != nil {
+		return false, fmt.Errorf("unable to reserve local key '%s': %w", k, err)
+	}
+
+	// The local agent may still hold a reference for the key at a different
+	// ID, e.g. when another subsystem allocated it before the restore path
+	// got here. Recreating the master key at the requested ID would then
+	// leave the two out of sync.
+	if oldID != id {
+		a.localKeys.release(k)
+		return false, IDConflictError{ID: id, Key: key, ExistingID: oldID}
 	}
 
-	// Assigned to 'key' from 'key2' since in case of an error, we don't replace
-	// the original 'key' variable with 'nil'.
-	key2 := key
-	key, err = a.backend.AllocateIDIfLocked(ctx, id, key2, lock)
-	if err != nil {
-		// Creation failed. Another agent most likely beat us to allocting this
-		// ID, retry.
-		releaseKeyAndID()
-		return 0, false, false, fmt.Errorf("unable to allocate ID %s for key %s: %w", strID, key2, err)
+	if value == 0 {
+		// Conditionally re-create the master key at exactly the requested ID.
+		key2 := key
+		key, err = a.backend.AllocateIDIfLocked(ctx, id, key2, lock)
+		a.observeBackendResult(err)
+		if err != nil {
+			// Another agent most likely beat us to allocating this ID.
+			a.localKeys.release(k)
+			return false, fmt.Errorf("unable to reclaim ID %s for key %s: %w", id, key2, err)
+		}
+		isNew = true
 	}
 
-	// Notify pool that leased ID is now in-use.
-	a.idPool.Use(unmaskedID)
+	// The ID was not leased from the pool, mark it as in use directly.
+	a.idPool.Remove(id)
 
 	if err = a.backend.AcquireReference(ctx, id, key, lock); err != nil {
-		// We will leak the master key here as the key has already been
-		// exposed and may be in use by other nodes. The garbage
-		// collector will release it again.
-		releaseKeyAndID()
-		return 0, false, false, fmt.Errorf("secondary key creation failed '%s': %w", k, err)
+		a.localKeys.release(k)
+		return false, fmt.Errorf("unable to create secondary key '%s': %w", k, err)
 	}
 
 	// mark the key as verified in the local cache
 	if err := a.localKeys.verify(k); err != nil {
-		a.logger.Error("BUG: Unable to verify local key", logfields.Error, err)
+		a.getLogger().Error("BUG: Unable to verify local key", logfields.Error, err)
 	}
 
-	a.logger.Debug("Allocated new global key", logfields.Key, k)
+	a.mainCache.insert(key, id)
+	a.metrics.SetCacheSize(a.mainCache.numEntries())
+	a.metrics.SetLocalKeys(a.localKeys.count())
 
-	return id, true, firstUse, nil
+	return isNew, nil
 }
 
-// Allocate will retrieve the ID for the provided key. If no ID has been
-// allocated for this key yet, a key will be allocated. If allocation fails,
-// most likely due to a parallel allocation of the same ID by another user,
-// allocation is re-attempted for maxAllocAttempts times.
-//
-// Return values:
-//  1. allocated ID
-//  2. whether the ID is newly allocated from kvstore
-//  3. whether this is the first owner that holds a reference to the key in
-//     localkeys store
-//  4. error in case of failure
-func (a *Allocator) Allocate(ctx context.Context, key AllocatorKey) (idpool.ID, bool, bool, error) {
-	var (
-		err      error
-		value    idpool.ID
-		isNew    bool
-		firstUse bool
-	)
+// RetryableError explicitly marks the wrapped error as retryable for the
+// allocation retry loops, overriding the classification done by IsRetryable.
+type RetryableError struct {
+	Err error
+}
 
-	a.logger.Debug("Allocating key", logfields.Key, key)
+func (e RetryableError) Error() string { return e.Err.Error() }
 
-	select {
-	case <-a.initialListDone:
-	case <-ctx.Done():
-		return 0, false, false, fmt.Errorf("allocation was cancelled while waiting for initial key list to be received: %w", ctx.Err())
-	}
+func (e RetryableError) Unwrap() error { return e.Err }
 
-	if a.operatorIDManagement {
-		id, err := a.GetWithRetry(ctx, key)
-		// The second and third return values are always false when
-		// operatorIDManagement is enabled because cilium-operator manages security
-		// IDs, and they are never newly allocated or require holding a reference to
-		// a key.
-		return id, false, false, err
+// IsRetryable returns true if an allocation attempt that failed with the
+// given error may succeed when retried. Context cancellation and expiry are
+// not retryable, as further attempts with the same context fail the same
+// way. Any other error is considered transient, matching the historical
+// behavior of the retry loops, unless wrapped in a RetryableError the
+// classification can be overridden with.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var retryable RetryableError
+	if errors.As(err, &retryable) {
+		return true
 	}
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
 
-	kvstore.Trace(a.logger, "Allocating from kvstore", fieldKey, key)
+// retryBackoff returns the backoff to use in the allocation retry loop for
+// 'key': the custom strategy if one was configured via WithBackoffStrategy,
+// or a copy of the default template customized with the key name otherwise.
+func (a *Allocator) retryBackoff(key AllocatorKey) backoff.BackoffInterface {
+	if a.backoffStrategy != nil {
+		return a.backoffStrategy
+	}
 
 	// make a copy of the template and customize it
 	boff := a.backoffTemplate
 	boff.Name = key.String()
-
-	for attempt := range a.maxAllocAttempts {
-		// Check our list of local keys already in use and increment the
-		// refcnt. The returned key must be released afterwards. No kvstore
-		// operation was performed for this allocation.
-		// We also do this on every loop as a different Allocate call might have
-		// allocated the key while we are attempting to allocate in this
-		// execution thread. It does not hurt to check if localKeys contains a
-		// reference for the key that we are attempting to allocate.
-		if val := a.localKeys.use(key.GetKey()); val != idpool.NoID {
-			kvstore.Trace(a.logger, "Reusing local id",
-				fieldID, val,
-				fieldKey, key,
-			)
-			a.mainCache.insert(key, val)
-			return val, false, false, nil
-		}
-
-		// FIXME: Add non-locking variant
-		value, isNew, firstUse, err = a.lockedAllocate(ctx, key)
-		if err == nil {
-			a.mainCache.insert(key, value)
-			a.logger.Debug("Allocated key",
-				logfields.Key, key,
-				logfields.ID, value,
-			)
-			return value, isNew, firstUse, nil
-		}
-
-		select {
-		case <-ctx.Done():
-			a.logger.Warn("Ongoing key allocation has been cancelled",
-				logfields.Error, ctx.Err(),
-				logfields.Key, key,
-				logfields.Attempt, attempt,
-			)
-			return 0, false, false, fmt.Errorf("key allocation cancelled: %w", ctx.Err())
-		default:
-			a.logger.Warn("Key allocation attempt failed",
-				logfields.Error, err,
-				logfields.Key, key,
-				logfields.Attempt, attempt,
-			)
-		}
-
-		kvstore.Trace(a.logger, "Allocation attempt failed",
-			fieldKey, key,
-			logfields.Attempt, attempt,
-		)
-
-		if waitErr := boff.Wait(ctx); waitErr != nil {
-			return 0, false, false, waitErr
-		}
-	}
-
-	return 0, false, false, err
+	return &boff
 }
 
 func (a *Allocator) GetWithRetry(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
@@ -772,9 +2533,7 @@ func (a *Allocator) GetWithRetry(ctx context.Context, key AllocatorKey) (idpool.
 		return id, nil
 	}
 
-	// Make a copy of the template and customize it.
-	boff := a.backoffTemplate
-	boff.Name = key.String()
+	boff := a.retryBackoff(key)
 
 	var id idpool.ID
 	var err error
@@ -787,47 +2546,99 @@ func (a *Allocator) GetWithRetry(ctx context.Context, key AllocatorKey) (idpool.
 
 		select {
 		case <-ctx.Done():
-			a.logger.Warn("Ongoing key allocation has been cancelled",
+			a.getLogger().Warn("Ongoing key allocation has been cancelled",
 				logfields.Error, ctx.Err(),
 				logfields.Key, key,
 				logfields.Attempt, attempt,
 			)
 			return idpool.NoID, fmt.Errorf("key allocation cancelled: %w", ctx.Err())
 		default:
-			a.logger.Debug("CiliumIdentity not yet created by cilium-operator, retrying...",
+			a.getLogger().Debug("CiliumIdentity not yet created by cilium-operator, retrying...",
 				logfields.Error, err,
 				logfields.Key, key,
 				logfields.Attempt, attempt,
 			)
 		}
 
+		if !IsRetryable(err) {
+			return idpool.NoID, err
+		}
+
 		if waitErr := boff.Wait(ctx); waitErr != nil {
-			a.logger.Warn("timed out waiting for cilium-operator to allocate CiliumIdentity",
+			a.getLogger().Warn("timed out waiting for cilium-operator to allocate CiliumIdentity",
 				logfields.Key, key,
 				logfields.Attempt, attempt,
 			)
-			return idpool.NoID, fmt.Errorf("timed out waiting for cilium-operator to allocate CiliumIdentity for key %v, error: %w", key.GetKey(), waitErr)
+			return idpool.NoID, fmt.Errorf("%w: cilium-operator did not allocate CiliumIdentity for key %v: %w", ErrBackendTimeout, key.GetKey(), waitErr)
 		}
 	}
 
 	return idpool.NoID, err
 }
 
+// WaitForID returns the ID allocated to a key, blocking until the association
+// is delivered through the ListAndWatch stream instead of polling the backend.
+// It returns immediately when the key is already present in the main cache,
+// or in one of the watched remote caches when the allocator was created with
+// WithWaitForIDIncludeRemoteCaches. The wait is aborted when ctx is cancelled.
+func (a *Allocator) WaitForID(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
+	key = a.versionKey(key)
+	k := key.GetKey()
+
+	ch := make(chan idpool.ID, 1)
+	id, unsubscribe := a.mainCache.subscribeKeyWait(k, ch)
+	if unsubscribe == nil {
+		return id, nil
+	}
+	defer unsubscribe()
+
+	if a.waitIncludeRemoteCaches {
+		a.remoteCachesMutex.RLock()
+		for _, rc := range a.remoteCaches {
+			id, unsubscribe := rc.cache.subscribeKeyWait(k, ch)
+			if unsubscribe == nil {
+				a.remoteCachesMutex.RUnlock()
+				return id, nil
+			}
+			defer unsubscribe()
+		}
+		a.remoteCachesMutex.RUnlock()
+	}
+
+	select {
+	case id := <-ch:
+		return id, nil
+	case <-ctx.Done():
+		return idpool.NoID, fmt.Errorf("cancelled while waiting for key %s to appear: %w", key.String(), ctx.Err())
+	}
+}
+
 // GetIfLocked returns the ID which is allocated to a key. Returns an ID of NoID if no ID
 // has been allocated to this key yet if the client is still holding the given
 // lock.
 func (a *Allocator) GetIfLocked(ctx context.Context, key AllocatorKey, lock kvstore.KVLocker) (idpool.ID, error) {
-	if id := a.mainCache.get(key.GetKey()); id != idpool.NoID {
+	key = a.versionKey(key)
+	if id := a.getCachedID(key); id != idpool.NoID {
 		return id, nil
 	}
 
-	return a.backend.GetIfLocked(ctx, key, lock)
+	id, err := a.backend.GetIfLocked(ctx, key, lock)
+	if err == nil && id == idpool.NoID && a.keyAliases != nil {
+		for _, alias := range a.keyAliases(key) {
+			id, err = a.backend.GetIfLocked(ctx, aliasedKey{AllocatorKey: key, alias: alias}, lock)
+			if err != nil || id != idpool.NoID {
+				break
+			}
+		}
+	}
+	return id, err
 }
 
 // Get returns the ID which is allocated to a key. Returns an ID of NoID if no ID
 // has been allocated to this key yet.
 func (a *Allocator) Get(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
-	if id := a.mainCache.get(key.GetKey()); id != idpool.NoID {
+	key = a.versionKey(key)
+	if id := a.getCachedID(key); id != idpool.NoID {
 		return id, nil
 	}
 
@@ -837,122 +2648,473 @@ func (a *Allocator) Get(ctx context.Context, key AllocatorKey) (idpool.ID, error
 // GetNoCache returns the ID which is allocated to a key in the kvstore,
 // bypassing the local copy of allocated keys.
 func (a *Allocator) GetNoCache(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
-	return a.backend.Get(ctx, key)
+	key = a.versionKey(key)
+	id, err := a.backend.Get(ctx, key)
+	a.observeBackendResult(err)
+	if err == nil && id == idpool.NoID && a.keyAliases != nil {
+		for _, alias := range a.keyAliases(key) {
+			id, err = a.backend.Get(ctx, aliasedKey{AllocatorKey: key, alias: alias})
+			if err != nil || id != idpool.NoID {
+				break
+			}
+		}
+	}
+	if err == nil && id != idpool.NoID && a.cacheLookupResults {
+		a.mainCache.insertLookupResult(id, key)
+	}
+	return id, err
 }
 
 // GetByID returns the key associated with an ID. Returns nil if no key is
-// associated with the ID.
+// associated with the ID. Associations resolved from the backend are inserted
+// into the main cache, so that repeated lookups for IDs allocated by other
+// nodes do not hit the kvstore until the watcher catches up.
 func (a *Allocator) GetByID(ctx context.Context, id idpool.ID) (AllocatorKey, error) {
 	if key := a.mainCache.getByID(id); key != nil {
 		return key, nil
 	}
 
-	return a.backend.GetByID(ctx, id)
+	key, err := a.backend.GetByID(ctx, id)
+	if err == nil && key != nil {
+		a.mainCache.insertLookupResult(id, key)
+	}
+	return key, err
+}
+
+// GetByIDBatch returns the keys associated with a set of IDs. The local cache
+// is consulted first in a single pass, and only the IDs missing from it are
+// looked up in the backend. IDs with no associated key are omitted from the
+// returned map.
+func (a *Allocator) GetByIDBatch(ctx context.Context, ids []idpool.ID) (map[idpool.ID]AllocatorKey, error) {
+	keys := make(map[idpool.ID]AllocatorKey, len(ids))
+	missing := a.mainCache.getByIDs(ids, keys)
+	if len(missing) == 0 {
+		return keys, nil
+	}
+
+	backendKeys, err := a.backend.GetByIDBatch(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for id, key := range backendKeys {
+		keys[id] = key
+	}
+	return keys, nil
+}
+
+// remoteCachesByPriority returns a snapshot of the watched remote caches in
+// the order they are to be consulted during ID resolution: ascending by the
+// priority configured via WithRemoteCachePriority, with unprioritized caches
+// last, and ties broken by name. This keeps the resolution of keys present
+// in several clusters deterministic.
+func (a *Allocator) remoteCachesByPriority() []*remoteCache {
+	a.remoteCachesMutex.RLock()
+	caches := make([]*remoteCache, 0, len(a.remoteCaches))
+	for _, rc := range a.remoteCaches {
+		caches = append(caches, rc)
+	}
+	a.remoteCachesMutex.RUnlock()
+
+	slices.SortFunc(caches, func(x, y *remoteCache) int {
+		px, okx := a.remoteCachePriorities[x.name]
+		py, oky := a.remoteCachePriorities[y.name]
+		switch {
+		case okx && !oky:
+			return -1
+		case !okx && oky:
+			return 1
+		case px != py:
+			return cmp.Compare(px, py)
+		}
+		return strings.Compare(x.name, y.name)
+	})
+
+	return caches
 }
 
 // GetIncludeRemoteCaches returns the ID which is allocated to a key. Includes the
 // caches of watched remote kvstores in the query. Returns an ID of NoID if no
 // ID has been allocated in any remote kvstore to this key yet.
 func (a *Allocator) GetIncludeRemoteCaches(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
+	key = a.versionKey(key)
+
 	// check main cache first
 	if id := a.mainCache.get(key.GetKey()); id != idpool.NoID {
 		return id, nil
 	}
 
-	// check remote caches
-	a.remoteCachesMutex.RLock()
-	for _, rc := range a.remoteCaches {
+	// check remote caches in priority order
+	for _, rc := range a.remoteCachesByPriority() {
 		if id := rc.cache.get(key.GetKey()); id != idpool.NoID {
-			a.remoteCachesMutex.RUnlock()
 			return id, nil
 		}
 	}
-	a.remoteCachesMutex.RUnlock()
 
-	// check main backend
-	if id, err := a.backend.Get(ctx, key); id != idpool.NoID || err != nil {
-		return id, err
+	// check main backend
+	if id, err := a.backend.Get(ctx, key); id != idpool.NoID || err != nil {
+		return id, err
+	}
+
+	// we skip checking remote backends explicitly here, to avoid
+	// accidentally overloading them in case of lookups for invalid identities
+
+	return idpool.NoID, nil
+}
+
+// GetByIDIncludeRemoteCaches returns the key associated with an ID. Includes
+// the caches of watched remote kvstores in the query.
+// Returns nil if no key is associated with the ID.
+func (a *Allocator) GetByIDIncludeRemoteCaches(ctx context.Context, id idpool.ID) (AllocatorKey, error) {
+	// check main cache first
+	if key := a.mainCache.getByID(id); key != nil {
+		return key, nil
+	}
+
+	// check remote caches in priority order
+	for _, rc := range a.remoteCachesByPriority() {
+		if key := rc.cache.getByID(id); key != nil {
+			return key, nil
+		}
+	}
+
+	// check main backend
+	if key, err := a.backend.GetByID(ctx, id); key != nil || err != nil {
+		return key, err
+	}
+
+	// we skip checking remote backends explicitly here, to avoid
+	// accidentally overloading them in case of lookups for invalid identities
+
+	return nil, nil
+}
+
+// Release releases the use of an ID associated with the provided key. After
+// the last user has released the ID, the key is removed in the KVstore and
+// the returned lastUse value is true. Releasing a key that holds no local
+// references returns ErrNotAllocatedLocally, typically indicating a double
+// release; callers performing intentionally idempotent cleanup may ignore it.
+func (a *Allocator) Release(ctx context.Context, key AllocatorKey) (lastUse bool, err error) {
+	if a.operatorIDManagement {
+		a.getLogger().Debug("Skipping key release when cilium-operator ID management is enabled", logfields.Key, key)
+		return false, nil
+	}
+
+	key = a.versionKey(key)
+	a.getLogger().Debug("Releasing key", logfields.Key, key)
+
+	select {
+	case <-a.initialListDone:
+	case <-ctx.Done():
+		return false, fmt.Errorf("release was cancelled while waiting for initial key list to be received: %w", ctx.Err())
+	}
+
+	k := key.GetKey()
+
+	a.slaveKeysMutex.Lock()
+	defer a.slaveKeysMutex.Unlock()
+
+	// release the key locally, if it was the last use, remove the node
+	// specific value key to remove the global reference mark
+	var id idpool.ID
+	lastUse, id, err = a.localKeys.release(k)
+	if err != nil {
+		if errors.Is(err, ErrNotAllocatedLocally) {
+			a.metrics.IncInvalidRelease()
+		}
+		return lastUse, err
+	}
+	a.metrics.SetLocalKeys(a.localKeys.count())
+	if lastUse {
+		if a.releaseGracePeriod > 0 {
+			a.schedulePendingReleaseLocked(id, key)
+			return lastUse, err
+		}
+
+		// Since in CRD mode we don't have a way to map which identity is being
+		// used by a node, we need to also pass the ID to the release function.
+		// This allows the CRD store to find the right identity by its ID and
+		// remove the node reference on that identity.
+		a.backend.Release(ctx, id, key)
+	}
+
+	return lastUse, err
+}
+
+// pendingRelease is a backend release deferred by the release grace period.
+type pendingRelease struct {
+	id    idpool.ID
+	key   AllocatorKey
+	timer *time.Timer
+}
+
+// schedulePendingReleaseLocked schedules the backend release of the given
+// key after the release grace period has passed. The caller must hold
+// slaveKeysMutex.
+func (a *Allocator) schedulePendingReleaseLocked(id idpool.ID, key AllocatorKey) {
+	k := key.GetKey()
+
+	if prev, ok := a.pendingReleases[k]; ok {
+		prev.timer.Stop()
+	}
+
+	a.getLogger().Debug("Deferring backend release of last key reference",
+		logfields.Key, k,
+		logfields.Duration, a.releaseGracePeriod,
+	)
+
+	p := &pendingRelease{id: id, key: key}
+	p.timer = time.AfterFunc(a.releaseGracePeriod, func() {
+		a.slaveKeysMutex.Lock()
+		defer a.slaveKeysMutex.Unlock()
+
+		if a.pendingReleases[k] != p {
+			// Canceled by a new allocation, or already flushed.
+			return
+		}
+		delete(a.pendingReleases, k)
+		a.backend.Release(context.Background(), p.id, p.key)
+	})
+	a.pendingReleases[k] = p
+}
+
+// cancelPendingReleaseLocked cancels the deferred backend release of the
+// given key, if one is scheduled. The caller must hold slaveKeysMutex.
+func (a *Allocator) cancelPendingReleaseLocked(k string) {
+	if p, ok := a.pendingReleases[k]; ok {
+		p.timer.Stop()
+		delete(a.pendingReleases, k)
+		a.getLogger().Debug("Canceled deferred release of key, it is in use again", logfields.Key, k)
+	}
+}
+
+// flushPendingReleases performs all backend releases still deferred by the
+// release grace period. It is called on allocator deletion so that slave
+// keys of keys no longer in use do not outlive the allocator.
+func (a *Allocator) flushPendingReleases() {
+	a.slaveKeysMutex.Lock()
+	defer a.slaveKeysMutex.Unlock()
+
+	for k, p := range a.pendingReleases {
+		p.timer.Stop()
+		delete(a.pendingReleases, k)
+		a.backend.Release(context.Background(), p.id, p.key)
+	}
+}
+
+// ReleaseBatch releases one reference for each of the given keys, as if
+// Release had been called for each of them, but takes the slave keys mutex
+// only once and issues the backend deletions for last-use keys with bounded
+// concurrency. It returns the errors encountered, indexed by key, or nil if
+// all keys were released successfully.
+func (a *Allocator) ReleaseBatch(ctx context.Context, keys []AllocatorKey) map[string]error {
+	if a.operatorIDManagement {
+		a.getLogger().Debug("Skipping batch key release when cilium-operator ID management is enabled")
+		return nil
+	}
+
+	a.getLogger().Debug("Releasing keys in batch", logfields.Count, len(keys))
+
+	errs := map[string]error{}
+
+	select {
+	case <-a.initialListDone:
+	case <-ctx.Done():
+		err := fmt.Errorf("release was cancelled while waiting for initial key list to be received: %w", ctx.Err())
+		for _, key := range keys {
+			errs[key.GetKey()] = err
+		}
+		return errs
+	}
+
+	a.slaveKeysMutex.Lock()
+	defer a.slaveKeysMutex.Unlock()
+
+	// release the keys locally in a single pass, collecting those whose last
+	// use was released and whose node specific value key thus needs to be
+	// removed to drop the global reference mark
+	lastUses := map[idpool.ID]AllocatorKey{}
+	for _, key := range keys {
+		key = a.versionKey(key)
+		k := key.GetKey()
+		lastUse, id, err := a.localKeys.release(k)
+		if err != nil {
+			if errors.Is(err, ErrNotAllocatedLocally) {
+				a.metrics.IncInvalidRelease()
+			}
+			errs[k] = err
+			continue
+		}
+		if lastUse {
+			lastUses[id] = key
+		}
 	}
+	a.metrics.SetLocalKeys(a.localKeys.count())
 
-	// we skip checking remote backends explicitly here, to avoid
-	// accidentally overloading them in case of lookups for invalid identities
+	a.releaseBackendKeys(ctx, lastUses, errs)
 
-	return idpool.NoID, nil
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
 }
 
-// GetByIDIncludeRemoteCaches returns the key associated with an ID. Includes
-// the caches of watched remote kvstores in the query.
-// Returns nil if no key is associated with the ID.
-func (a *Allocator) GetByIDIncludeRemoteCaches(ctx context.Context, id idpool.ID) (AllocatorKey, error) {
-	// check main cache first
-	if key := a.mainCache.getByID(id); key != nil {
-		return key, nil
+// BulkRelease releases one reference for each of the given keys, like
+// ReleaseBatch, but reports for each successfully released key whether its
+// last use was released. Errors encountered for individual keys are joined
+// into the returned error; the map covers the remaining keys in any case.
+func (a *Allocator) BulkRelease(ctx context.Context, keys []AllocatorKey) (map[AllocatorKey]bool, error) {
+	if a.operatorIDManagement {
+		a.getLogger().Debug("Skipping bulk key release when cilium-operator ID management is enabled")
+		return nil, nil
 	}
 
-	// check remote caches
-	a.remoteCachesMutex.RLock()
-	for _, rc := range a.remoteCaches {
-		if key := rc.cache.getByID(id); key != nil {
-			a.remoteCachesMutex.RUnlock()
-			return key, nil
-		}
+	a.getLogger().Debug("Releasing keys in bulk", logfields.Count, len(keys))
+
+	select {
+	case <-a.initialListDone:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("release was cancelled while waiting for initial key list to be received: %w", ctx.Err())
 	}
-	a.remoteCachesMutex.RUnlock()
 
-	// check main backend
-	if key, err := a.backend.GetByID(ctx, id); key != nil || err != nil {
-		return key, err
+	result := make(map[AllocatorKey]bool, len(keys))
+	errs := map[string]error{}
+
+	a.slaveKeysMutex.Lock()
+	defer a.slaveKeysMutex.Unlock()
+
+	// release the keys locally in a single pass, collecting those whose last
+	// use was released and whose node specific value key thus needs to be
+	// removed to drop the global reference mark
+	lastUses := map[idpool.ID]AllocatorKey{}
+	for _, key := range keys {
+		versioned := a.versionKey(key)
+		k := versioned.GetKey()
+		lastUse, id, err := a.localKeys.release(k)
+		if err != nil {
+			if errors.Is(err, ErrNotAllocatedLocally) {
+				a.metrics.IncInvalidRelease()
+			}
+			errs[k] = err
+			continue
+		}
+		result[key] = lastUse
+		if lastUse {
+			lastUses[id] = versioned
+		}
 	}
+	a.metrics.SetLocalKeys(a.localKeys.count())
 
-	// we skip checking remote backends explicitly here, to avoid
-	// accidentally overloading them in case of lookups for invalid identities
+	a.releaseBackendKeys(ctx, lastUses, errs)
 
-	return nil, nil
+	var joined error
+	for k, err := range errs {
+		joined = errors.Join(joined, fmt.Errorf("%s: %w", k, err))
+	}
+	return result, joined
 }
 
-// Release releases the use of an ID associated with the provided key. After
-// the last user has released the ID, the key is removed in the KVstore and
-// the returned lastUse value is true.
-func (a *Allocator) Release(ctx context.Context, key AllocatorKey) (lastUse bool, err error) {
+// ReleaseAllLocal drops all local references to all keys, regardless of their
+// refcount, and removes the corresponding node specific value keys from the
+// backend. It is intended for agent shutdown, where all keys in use by the
+// node go away at once. It returns the errors encountered, indexed by key, or
+// nil if all keys were released successfully.
+func (a *Allocator) ReleaseAllLocal(ctx context.Context) map[string]error {
 	if a.operatorIDManagement {
-		a.logger.Debug("Skipping key release when cilium-operator ID management is enabled", logfields.Key, key)
-		return false, nil
+		a.getLogger().Debug("Skipping release of all local keys when cilium-operator ID management is enabled")
+		return nil
 	}
 
-	a.logger.Debug("Releasing key", logfields.Key, key)
-
 	select {
 	case <-a.initialListDone:
 	case <-ctx.Done():
-		return false, fmt.Errorf("release was cancelled while waiting for initial key list to be received: %w", ctx.Err())
+		return map[string]error{"": fmt.Errorf("release was cancelled while waiting for initial key list to be received: %w", ctx.Err())}
 	}
 
-	k := key.GetKey()
-
 	a.slaveKeysMutex.Lock()
 	defer a.slaveKeysMutex.Unlock()
 
-	// release the key locally, if it was the last use, remove the node
-	// specific value key to remove the global reference mark
-	var id idpool.ID
-	lastUse, id, err = a.localKeys.release(k)
-	if err != nil {
-		return lastUse, err
-	}
-	if lastUse {
-		// Since in CRD mode we don't have a way to map which identity is being
-		// used by a node, we need to also pass the ID to the release function.
-		// This allows the CRD store to find the right identity by its ID and
-		// remove the node reference on that identity.
-		a.backend.Release(ctx, id, key)
+	released := a.localKeys.releaseAll()
+	a.getLogger().Debug("Releasing all local keys", logfields.Count, len(released))
+	a.metrics.SetLocalKeys(0)
+
+	errs := map[string]error{}
+	a.releaseBackendKeys(ctx, released, errs)
+
+	if len(errs) == 0 {
+		return nil
 	}
+	return errs
+}
 
-	return lastUse, err
+// releaseBatchConcurrency bounds the number of concurrent backend deletions
+// issued when releasing keys in bulk.
+const releaseBatchConcurrency = 16
+
+// releaseBackendKeys removes the node specific value keys for the given keys
+// from the backend, recording failures in errs. The slave keys mutex must be
+// held.
+func (a *Allocator) releaseBackendKeys(ctx context.Context, keys map[idpool.ID]AllocatorKey, errs map[string]error) {
+	var (
+		g         errgroup.Group
+		errsMutex lock.Mutex
+	)
+	g.SetLimit(releaseBatchConcurrency)
+	for id, key := range keys {
+		g.Go(func() error {
+			if err := a.backend.Release(ctx, id, key); err != nil {
+				errsMutex.Lock()
+				errs[key.GetKey()] = err
+				errsMutex.Unlock()
+			}
+			return nil
+		})
+	}
+	g.Wait()
 }
 
-// RunGC scans the kvstore for unused master keys and removes them
-func (a *Allocator) RunGC(ctx context.Context, rateLimit *rate.Limiter, staleKeysPrevRound map[string]uint64) (map[string]uint64, *GCStats, error) {
-	return a.backend.RunGC(ctx, rateLimit, staleKeysPrevRound, a.min, a.max)
+// RunGC scans the kvstore for unused master keys and removes them. Keys
+// younger than minKeyAge are left alone this round, even if unused.
+// The scan is driven page by page, waiting on the rate limiter between
+// pages so that a large identity space does not translate into a burst of
+// oversized backend list requests.
+// When dryRun is true no keys are deleted; the keys that a real run would
+// have deleted are returned as candidates instead, while the stale key
+// bookkeeping proceeds as usual.
+func (a *Allocator) RunGC(ctx context.Context, rateLimit *rate.Limiter, staleKeysPrevRound map[string]uint64, minKeyAge time.Duration, dryRun bool) (map[string]uint64, []GCCandidate, *GCStats, error) {
+	scanStart := time.Now()
+
+	staleKeys := map[string]uint64{}
+	var candidates []GCCandidate
+	stats := &GCStats{}
+
+	continuation := ""
+	for {
+		pageKeys, next, pageCandidates, pageStats, err := a.backend.RunGC(ctx, rateLimit, staleKeysPrevRound, minKeyAge, dryRun, a.min, a.max, continuation)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		maps.Copy(staleKeys, pageKeys)
+		candidates = append(candidates, pageCandidates...)
+		if pageStats != nil {
+			stats.Alive += pageStats.Alive
+			stats.Deleted += pageStats.Deleted
+			stats.SkippedYoung += pageStats.SkippedYoung
+		}
+		stats.Pages++
+
+		if next == "" {
+			break
+		}
+		continuation = next
+
+		if err := rateLimit.Wait(ctx); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	stats.ScanDuration = time.Since(scanStart)
+	return staleKeys, candidates, stats, nil
 }
 
 // RunLocksGC scans the kvstore for stale locks and removes them
@@ -960,6 +3122,89 @@ func (a *Allocator) RunLocksGC(ctx context.Context, staleLocksPrevRound map[stri
 	return a.backend.RunLocksGC(ctx, staleLocksPrevRound)
 }
 
+const (
+	// ConsistencyErrorMissingMaster indicates that the backend no longer
+	// knows an ID the local agent still holds a verified reference for.
+	ConsistencyErrorMissingMaster = "missing_master"
+
+	// ConsistencyErrorKeyMismatch indicates that the backend associates an
+	// ID with a different key than the local agent.
+	ConsistencyErrorKeyMismatch = "key_mismatch"
+
+	// ConsistencyErrorReverseMismatch indicates that the key no longer
+	// resolves back to the ID the local agent holds it under.
+	ConsistencyErrorReverseMismatch = "reverse_mismatch"
+)
+
+// ConsistencyError describes a divergence between a verified local key and
+// the authoritative backend state, as found by VerifyConsistency.
+type ConsistencyError struct {
+	// ID is the identity the local agent holds a reference for.
+	ID idpool.ID
+
+	// LocalKey is the key associated with ID in the local key store.
+	LocalKey AllocatorKey
+
+	// BackendKey is the key the backend currently associates with ID, if
+	// any.
+	BackendKey AllocatorKey
+
+	// ErrorType classifies the divergence as one of the ConsistencyError*
+	// constants.
+	ErrorType string
+}
+
+// VerifyConsistency cross-checks all verified local keys against the
+// authoritative backend state: for each locally held ID it verifies that the
+// master key still exists in the backend, and that the key still resolves
+// back to the same ID. It is intended to be invoked on a slow periodic
+// schedule, e.g. by the operator's health check, as every ID incurs backend
+// round-trips. A non-nil error indicates that the verification itself could
+// not be completed.
+func (a *Allocator) VerifyConsistency(ctx context.Context) ([]ConsistencyError, error) {
+	var inconsistencies []ConsistencyError
+
+	for id, key := range a.localKeys.getVerifiedIDs() {
+		backendKey, err := a.backend.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("unable to look up ID %s: %w", id, err)
+		}
+
+		switch {
+		case backendKey == nil || backendKey.GetKey() == "":
+			inconsistencies = append(inconsistencies, ConsistencyError{
+				ID:        id,
+				LocalKey:  key,
+				ErrorType: ConsistencyErrorMissingMaster,
+			})
+			continue
+		case !backendKey.IsEqualTo(key):
+			inconsistencies = append(inconsistencies, ConsistencyError{
+				ID:         id,
+				LocalKey:   key,
+				BackendKey: backendKey,
+				ErrorType:  ConsistencyErrorKeyMismatch,
+			})
+			continue
+		}
+
+		backendID, err := a.backend.Get(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to look up key %s: %w", key, err)
+		}
+		if backendID != id {
+			inconsistencies = append(inconsistencies, ConsistencyError{
+				ID:         id,
+				LocalKey:   key,
+				BackendKey: backendKey,
+				ErrorType:  ConsistencyErrorReverseMismatch,
+			})
+		}
+	}
+
+	return inconsistencies, nil
+}
+
 // DeleteAllKeys will delete all keys. It is expected to be used in tests.
 func (a *Allocator) DeleteAllKeys() {
 	a.backend.DeleteAllKeys(context.TODO())
@@ -980,19 +3225,53 @@ func (a *Allocator) syncLocalKeys() {
 	ids := a.localKeys.getVerifiedIDs()
 	ctx := context.TODO()
 
+	success := true
+	var lastErr error
 	for id, key := range ids {
-		a.syncLocalKey(ctx, id, key)
+		if err := a.syncLocalKey(ctx, id, key); err != nil {
+			success = false
+			lastErr = err
+		}
+	}
+
+	// Report the allocator as degraded when synchronization rounds keep
+	// failing: local keys no longer protected by master key protection are
+	// at risk of being garbage collected.
+	if success {
+		if a.failedSyncRounds >= syncFailureHealthThreshold {
+			a.clearHealthCondition(healthReasonSyncFailing)
+		}
+		a.failedSyncRounds = 0
+	} else {
+		a.failedSyncRounds++
+		if a.failedSyncRounds >= syncFailureHealthThreshold {
+			a.setHealthCondition(healthReasonSyncFailing, lastErr)
+		}
+	}
+
+	// Checkpoint the allocator cache to disk after each successful pass, so
+	// that a warmup snapshot is available on the next agent start if the
+	// kvstore is wiped or unreachable.
+	if success && a.snapshotPath != "" {
+		if err := a.ExportToFile(a.snapshotPath); err != nil {
+			a.getLogger().Warn("Unable to export allocator snapshot file",
+				logfields.Error, err,
+				logfields.Path, a.snapshotPath,
+			)
+		}
 	}
 }
 
-func (a *Allocator) syncLocalKey(ctx context.Context, id idpool.ID, key AllocatorKey) {
+func (a *Allocator) syncLocalKey(ctx context.Context, id idpool.ID, key AllocatorKey) error {
 	encodedKey := key.GetKey()
 	if newId := a.localKeys.lookupKey(encodedKey); newId != id {
-		return
+		return nil
 	}
-	err := a.backend.UpdateKey(ctx, id, key, false)
+	opCtx, cancel := context.WithTimeout(ctx, a.syncOpTimeout())
+	err := a.backend.UpdateKey(opCtx, id, key, false)
+	cancel()
 	if err != nil {
-		a.logger.Warn(
+		a.getLogger().Warn(
 			"Error updating key",
 			logfields.Key, key,
 			logfields.ID, id,
@@ -1003,7 +3282,7 @@ func (a *Allocator) syncLocalKey(ctx context.Context, id idpool.ID, key Allocato
 	// in use in most cases, we avoid grabbing the slaveKeysMutex here to reduce lock contention.
 	// If it is in use here, we know the slave key is not leaked, and we don't need to do any cleanup.
 	if newId := a.localKeys.lookupKey(encodedKey); newId != idpool.NoID {
-		return
+		return err
 	}
 
 	a.slaveKeysMutex.Lock()
@@ -1014,16 +3293,16 @@ func (a *Allocator) syncLocalKey(ctx context.Context, id idpool.ID, key Allocato
 	// If the key is still unused, it could mean that the slave key was upserted into the kvstore during "UpdateKey"
 	// after it was previously released. If that is the case, we release it while holding the slaveKeysMutex.
 	if newId := a.localKeys.lookupKey(encodedKey); newId == idpool.NoID {
-		ctx, cancel := context.WithTimeout(ctx, backendOpTimeout)
+		ctx, cancel := context.WithTimeout(ctx, a.syncOpTimeout())
 		defer cancel()
-		a.logger.Warn(
+		a.getLogger().Warn(
 			"Releasing now unused key that was re-recreated",
 			logfields.Key, key,
 			logfields.ID, id,
 		)
 		err = a.backend.Release(ctx, id, key)
 		if err != nil {
-			a.logger.Warn(
+			a.getLogger().Warn(
 				"Error releasing unused key",
 				logfields.Error, err,
 				logfields.Key, key,
@@ -1031,16 +3310,20 @@ func (a *Allocator) syncLocalKey(ctx context.Context, id idpool.ID, key Allocato
 			)
 		}
 	}
+	return err
 }
 
 func (a *Allocator) startLocalKeySync() {
+	a.backgroundWG.Add(1)
 	go func(a *Allocator) {
+		defer a.backgroundWG.Done()
 		for {
 			a.syncLocalKeys()
+			a.checkLeasedIDLeaks()
 
 			select {
 			case <-a.stopGC:
-				a.logger.Debug("Stopped master key sync routine")
+				a.getLogger().Debug("Stopped master key sync routine")
 				return
 			case <-time.After(a.syncInterval):
 			}
@@ -1067,6 +3350,23 @@ type AllocatorEvent struct {
 	Key AllocatorKey
 }
 
+// Backoff bounds applied when restarting a remote kvstore watch after the
+// initial list fails or the watch terminates unexpectedly. Exposed as
+// variables for testing.
+var (
+	remoteCacheRetryMinBackoff = time.Second
+	remoteCacheRetryMaxBackoff = time.Minute
+)
+
+// errRemoteWatchInterrupted is recorded in the remote cache status when the
+// watch terminates before the context is canceled.
+var errRemoteWatchInterrupted = errors.New("remote kvstore watch terminated unexpectedly")
+
+// errRemoteListStalled is recorded in the remote cache status when the initial
+// listing from the remote kvstore does not complete within the initial list
+// timeout.
+var errRemoteListStalled = errors.New("initial listing from remote kvstore has not completed within timeout")
+
 // remoteCache represents the cache content of an additional kvstore managing
 // identities. The contents are not directly accessible but will be merged into
 // the ForeachCache() function.
@@ -1077,22 +3377,94 @@ type remoteCache struct {
 	cache     *cache
 
 	watchFunc func(ctx context.Context, remote *remoteCache, onSync func(context.Context))
+
+	// statusMutex protects the status fields below.
+	statusMutex lock.RWMutex
+
+	// lastSynced is the time the initial listing from the remote kvstore
+	// last completed successfully. Zero if it never completed.
+	lastSynced time.Time
+
+	// drainedEntries is the number of stale entries drained because they
+	// were no longer present after a reconnection, or because the watcher
+	// was stopped before completing the initial listing.
+	drainedEntries int
+
+	// lastError is the error that terminated the watch, if any.
+	lastError error
+
+	// retryCount is the number of consecutive times the watch has been
+	// restarted with backoff. It is not cleared on a successful sync, as
+	// the backoff itself only resets after a sufficiently long healthy
+	// period.
+	retryCount int
+}
+
+// RemoteCacheStatus describes the synchronization state of the identity cache
+// of one remote cluster.
+type RemoteCacheStatus struct {
+	// NumEntries is the number of identities currently in the cache.
+	NumEntries int `json:"num-entries"`
+
+	// Synced is true once the initial listing from the remote kvstore has
+	// completed and new events are being watched.
+	Synced bool `json:"synced"`
+
+	// LastSynced is the time the initial listing from the remote kvstore
+	// last completed successfully. Zero if it never completed.
+	LastSynced time.Time `json:"last-synced"`
+
+	// DrainedEntries is the number of stale entries drained because they
+	// were no longer present after a reconnection, or because the watcher
+	// was stopped before completing the initial listing.
+	DrainedEntries int `json:"drained-entries"`
+
+	// LastError is the error that terminated the watch, if any.
+	LastError error `json:"last-error,omitempty"`
+
+	// RetryCount is the number of consecutive times the watch has been
+	// restarted with backoff after the initial list failed or the watch
+	// terminated unexpectedly. It resets together with the backoff, once
+	// the watch has stayed healthy for long enough.
+	RetryCount int `json:"retry-count,omitempty"`
 }
 
 type RemoteIDCache interface {
 	NumEntries() int
 	Synced() bool
+	Status() RemoteCacheStatus
 	Watch(ctx context.Context, onSync func(context.Context))
 }
 
 func (a *Allocator) NewRemoteCache(remoteName string, remoteAlloc *Allocator) RemoteIDCache {
-	return &remoteCache{
+	rc := &remoteCache{
 		name:      remoteName,
 		allocator: remoteAlloc,
 		cache:     &remoteAlloc.mainCache,
 
 		watchFunc: a.watchRemoteKVStore,
 	}
+
+	// A fatal exit on a stalled initial list is appropriate for the primary
+	// allocator only; a secondary allocator merely records the stalled
+	// listing in the cache status while the watch keeps retrying.
+	if remoteAlloc.initialListTimeoutHandler == nil {
+		remoteAlloc.initialListTimeoutHandler = rc.markListStalled
+	}
+
+	return rc
+}
+
+// markListStalled records that the initial listing from the remote kvstore
+// has not completed within the initial list timeout. The recorded error is
+// cleared once the listing eventually completes.
+func (rc *remoteCache) markListStalled() {
+	rc.allocator.getLogger().Warn("Initial listing from remote kvstore has not completed within timeout, continuing to retry",
+		logfields.ClusterName, rc.name)
+
+	rc.statusMutex.Lock()
+	rc.lastError = errRemoteListStalled
+	rc.statusMutex.Unlock()
 }
 
 // watchRemoteKVStore starts watching an allocator base prefix the kvstore
@@ -1101,13 +3473,39 @@ func (a *Allocator) NewRemoteCache(remoteName string, remoteAlloc *Allocator) Re
 // start being reported in the identities returned by the ForeachCache()
 // function. RemoteName should be unique per logical "remote".
 func (a *Allocator) watchRemoteKVStore(ctx context.Context, rc *remoteCache, onSync func(context.Context)) {
-	scopedLog := a.logger.With(logfields.ClusterName, rc.name)
+	a.backgroundWG.Add(1)
+	defer a.backgroundWG.Done()
+
+	// Stop the watcher when the owning allocator is shut down, in addition
+	// to the cancellation of the caller context.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-a.stopGC:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	scopedLog := a.getLogger().With(logfields.ClusterName, rc.name)
 	scopedLog.Info("Starting remote kvstore watcher")
 
-	rc.allocator.start()
+	// Restart the watch with exponential backoff and jitter when the initial
+	// list fails or the watch terminates before the context is canceled, so
+	// that a flapping remote kvstore is not hammered with immediate
+	// reconnections. The backoff resets once the watch has stayed healthy
+	// for long enough.
+	retry := backoff.Exponential{
+		Logger:     scopedLog,
+		Min:        remoteCacheRetryMinBackoff,
+		Max:        remoteCacheRetryMaxBackoff,
+		Jitter:     true,
+		ResetAfter: 2 * remoteCacheRetryMaxBackoff,
+		Name:       "remote-kvstore-watch-" + rc.name,
+	}
 
-	select {
-	case <-ctx.Done():
+	drainUnsynced := func() {
 		scopedLog.Debug("Context canceled before remote kvstore watcher synchronization completed: stale identities will now be drained")
 		rc.close()
 
@@ -1124,7 +3522,7 @@ func (a *Allocator) watchRemoteKVStore(ctx context.Context, rc *remoteCache, onS
 		// are not present in the current cache (if any). This ensures we do not
 		// leak any stale identity, and at the same time we do not invalidate the
 		// current state.
-		rc.cache.drainIf(func(id idpool.ID) bool {
+		drained := rc.cache.drainIf(func(id idpool.ID) bool {
 			if old == nil {
 				return true
 			}
@@ -1132,10 +3530,54 @@ func (a *Allocator) watchRemoteKVStore(ctx context.Context, rc *remoteCache, onS
 			_, ok := old.cache.nextCache[id]
 			return !ok
 		})
-		return
 
-	case <-rc.cache.listDone:
-		scopedLog.Info("Remote kvstore watcher successfully synchronized and registered")
+		rc.statusMutex.Lock()
+		rc.drainedEntries += drained
+		rc.lastError = context.Cause(ctx)
+		rc.statusMutex.Unlock()
+
+		if old == nil {
+			a.metrics.DeleteRemoteCacheSize(rc.name)
+		}
+	}
+
+	rc.allocator.start()
+
+	for synced := false; !synced; {
+		select {
+		case <-ctx.Done():
+			drainUnsynced()
+			return
+
+		case <-rc.cache.listDone:
+			scopedLog.Info("Remote kvstore watcher successfully synchronized and registered")
+
+			rc.statusMutex.Lock()
+			rc.lastSynced = time.Now()
+			rc.lastError = nil
+			rc.statusMutex.Unlock()
+
+			synced = true
+
+		case <-rc.cache.watchDone:
+			scopedLog.Warn("Remote kvstore watch terminated before completing the initial list, restarting",
+				logfields.Attempt, retry.Attempt()+1)
+
+			rc.statusMutex.Lock()
+			rc.lastError = errRemoteWatchInterrupted
+			rc.statusMutex.Unlock()
+
+			if err := retry.Wait(ctx); err != nil {
+				drainUnsynced()
+				return
+			}
+
+			rc.statusMutex.Lock()
+			rc.retryCount = retry.Attempt()
+			rc.statusMutex.Unlock()
+
+			rc.cache.start()
+		}
 	}
 
 	a.remoteCachesMutex.Lock()
@@ -1143,6 +3585,8 @@ func (a *Allocator) watchRemoteKVStore(ctx context.Context, rc *remoteCache, onS
 	a.remoteCaches[rc.name] = rc
 	a.remoteCachesMutex.Unlock()
 
+	a.metrics.SetRemoteCacheSize(rc.name, rc.NumEntries())
+
 	if old != nil {
 		// In case of reconnection, let's emit a deletion event for all stale identities
 		// that are no longer present in the kvstore. We take the lock of the new cache
@@ -1150,21 +3594,218 @@ func (a *Allocator) watchRemoteKVStore(ctx context.Context, rc *remoteCache, onS
 		// are added/removed in the meanwhile).
 		scopedLog.Debug("Another kvstore watcher was already registered: deleting stale identities")
 		rc.cache.mutex.RLock()
-		old.cache.drainIf(func(id idpool.ID) bool {
+		drained := old.cache.drainIf(func(id idpool.ID) bool {
 			_, ok := rc.cache.nextCache[id]
 			return !ok
 		})
 		rc.cache.mutex.RUnlock()
+
+		rc.statusMutex.Lock()
+		rc.drainedEntries += drained
+		rc.statusMutex.Unlock()
 	}
 
 	// Execute the on-sync callback handler.
 	onSync(ctx)
 
-	<-ctx.Done()
+	// relistDone is non-nil while a restarted watch is re-listing the
+	// remote kvstore, and nil (blocking forever) otherwise.
+	var relistDone waitChan
+
+watch:
+	for {
+		select {
+		case <-ctx.Done():
+			break watch
+
+		case <-relistDone:
+			scopedLog.Info("Remote kvstore watcher successfully resynchronized")
+
+			rc.statusMutex.Lock()
+			rc.lastSynced = time.Now()
+			rc.lastError = nil
+			rc.statusMutex.Unlock()
+
+			a.metrics.SetRemoteCacheSize(rc.name, rc.NumEntries())
+			relistDone = nil
+
+		case <-rc.cache.watchDone:
+			scopedLog.Warn("Remote kvstore watch terminated unexpectedly, restarting",
+				logfields.Attempt, retry.Attempt()+1)
+
+			rc.statusMutex.Lock()
+			rc.lastError = errRemoteWatchInterrupted
+			rc.statusMutex.Unlock()
+
+			if err := retry.Wait(ctx); err != nil {
+				break watch
+			}
+
+			rc.statusMutex.Lock()
+			rc.retryCount = retry.Attempt()
+			rc.statusMutex.Unlock()
+
+			relistDone = rc.cache.start()
+		}
+	}
+
 	rc.close()
+
+	rc.statusMutex.Lock()
+	rc.lastError = context.Cause(ctx)
+	rc.statusMutex.Unlock()
+
+	a.metrics.DeleteRemoteCacheSize(rc.name)
 	scopedLog.Info("Stopped remote kvstore watcher")
 }
 
+// RemoteCacheSummary is a point-in-time summary of the state of the identity
+// cache of one remote cluster.
+type RemoteCacheSummary struct {
+	// Name is the logical name of the remote, typically the cluster name.
+	Name string `json:"name"`
+
+	// NumEntries is the number of identities currently in the cache.
+	NumEntries int `json:"num-entries"`
+
+	// Synced is true once the initial listing from the remote kvstore has
+	// completed and new events are being watched.
+	Synced bool `json:"synced"`
+
+	// LastUpdated is the time the cache last processed a list, upsert or
+	// delete event. Zero if no event has been processed yet.
+	LastUpdated time.Time `json:"last-updated"`
+}
+
+// DumpRemoteCaches returns a summary of all registered remote caches, sorted
+// by name.
+func (a *Allocator) DumpRemoteCaches() []RemoteCacheSummary {
+	a.remoteCachesMutex.RLock()
+	summaries := make([]RemoteCacheSummary, 0, len(a.remoteCaches))
+	for name, rc := range a.remoteCaches {
+		summaries = append(summaries, RemoteCacheSummary{
+			Name:        name,
+			NumEntries:  rc.NumEntries(),
+			Synced:      rc.Synced(),
+			LastUpdated: rc.cache.getLastUpdate(),
+		})
+	}
+	a.remoteCachesMutex.RUnlock()
+
+	slices.SortFunc(summaries, func(a, b RemoteCacheSummary) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return summaries
+}
+
+// SnapshotSourceLocal is the Source of snapshot entries from the main cache.
+// Entries from a remote cache carry the remote cluster name instead.
+const SnapshotSourceLocal = "local-cache"
+
+// AllocatorSnapshotEntry is one key↔ID association in an allocator cache.
+type AllocatorSnapshotEntry struct {
+	// ID is the allocated numeric identifier.
+	ID idpool.ID `json:"id"`
+
+	// Key is the string representation of the key the ID is allocated to.
+	Key string `json:"key"`
+
+	// Source is SnapshotSourceLocal for entries of the main cache and the
+	// remote cluster name for entries of a remote cache.
+	Source string `json:"source"`
+
+	// Verified is true when the key is in use locally and its master key
+	// has been verified to exist in the backend.
+	Verified bool `json:"verified"`
+
+	// RefCount is the number of local users of the key. Only filled in for
+	// verbose snapshots, and only for keys in use locally.
+	RefCount uint64 `json:"ref-count,omitempty"`
+
+	// PendingRelease is true when the last local reference to the key has
+	// been dropped but its backend release is still deferred by the
+	// release grace period.
+	PendingRelease bool `json:"pending-release,omitempty"`
+}
+
+// AllocatorSnapshot is a point-in-time, read-only copy of the key↔ID
+// associations known to the allocator.
+type AllocatorSnapshot struct {
+	Entries []AllocatorSnapshotEntry `json:"entries"`
+}
+
+// Snapshot returns a read-only snapshot of the key↔ID associations known to
+// the allocator, including the remote cluster caches. Entries are sorted by
+// source, with the main cache first and the remote caches in name order, and
+// by ID within each source. The cache contents are copied under the
+// respective locks, so the snapshot can be serialized without holding any.
+func (a *Allocator) Snapshot(verbose bool) AllocatorSnapshot {
+	keyInfo := a.localKeys.getKeyInfo()
+
+	a.slaveKeysMutex.Lock()
+	pendingReleases := make(map[string]struct{}, len(a.pendingReleases))
+	for k := range a.pendingReleases {
+		pendingReleases[k] = struct{}{}
+	}
+	a.slaveKeysMutex.Unlock()
+
+	var entries []AllocatorSnapshotEntry
+
+	a.mainCache.mutex.RLock()
+	for id, key := range a.mainCache.cache {
+		entry := AllocatorSnapshotEntry{ID: id, Source: SnapshotSourceLocal}
+		if key != nil {
+			entry.Key = key.GetKey()
+		}
+		if info, ok := keyInfo[entry.Key]; ok {
+			entry.Verified = info.verified
+			if verbose {
+				entry.RefCount = info.refcnt
+			}
+		}
+		if _, ok := pendingReleases[entry.Key]; ok {
+			entry.PendingRelease = true
+		}
+		entries = append(entries, entry)
+	}
+	a.mainCache.mutex.RUnlock()
+
+	a.remoteCachesMutex.RLock()
+	remotes := make([]*remoteCache, 0, len(a.remoteCaches))
+	for _, rc := range a.remoteCaches {
+		remotes = append(remotes, rc)
+	}
+	a.remoteCachesMutex.RUnlock()
+
+	for _, rc := range remotes {
+		rc.cache.mutex.RLock()
+		for id, key := range rc.cache.cache {
+			entry := AllocatorSnapshotEntry{ID: id, Source: rc.name}
+			if key != nil {
+				entry.Key = key.GetKey()
+			}
+			entries = append(entries, entry)
+		}
+		rc.cache.mutex.RUnlock()
+	}
+
+	slices.SortFunc(entries, func(x, y AllocatorSnapshotEntry) int {
+		if x.Source != y.Source {
+			switch {
+			case x.Source == SnapshotSourceLocal:
+				return -1
+			case y.Source == SnapshotSourceLocal:
+				return 1
+			default:
+				return strings.Compare(x.Source, y.Source)
+			}
+		}
+		return cmp.Compare(x.ID, y.ID)
+	})
+
+	return AllocatorSnapshot{Entries: entries}
+}
+
 // RemoveRemoteKVStore removes any reference to a remote allocator / kvstore, emitting
 // a deletion event for all previously known identities.
 func (a *Allocator) RemoveRemoteKVStore(remoteName string) {
@@ -1175,7 +3816,7 @@ func (a *Allocator) RemoveRemoteKVStore(remoteName string) {
 
 	if old != nil {
 		old.cache.drain()
-		a.logger.Info("Remote kvstore watcher unregistered", logfields.ClusterName, remoteName)
+		a.getLogger().Info("Remote kvstore watcher unregistered", logfields.ClusterName, remoteName)
 	}
 }
 
@@ -1215,6 +3856,25 @@ func (rc *remoteCache) Synced() bool {
 	}
 }
 
+// Status returns the synchronization status of the remote cache.
+func (rc *remoteCache) Status() RemoteCacheStatus {
+	if rc == nil {
+		return RemoteCacheStatus{}
+	}
+
+	rc.statusMutex.RLock()
+	defer rc.statusMutex.RUnlock()
+
+	return RemoteCacheStatus{
+		NumEntries:     rc.cache.numEntries(),
+		Synced:         rc.Synced(),
+		LastSynced:     rc.lastSynced,
+		DrainedEntries: rc.drainedEntries,
+		LastError:      rc.lastError,
+		RetryCount:     rc.retryCount,
+	}
+}
+
 // close stops watching for identities in the kvstore associated with the
 // remote cache.
 func (rc *remoteCache) close() {
@@ -1226,3 +3886,41 @@ func (rc *remoteCache) close() {
 func (a *Allocator) Observe(ctx context.Context, next func(AllocatorChange), complete func(error)) {
 	a.mainCache.Observe(ctx, next, complete)
 }
+
+// ReplayCurrentState calls cb with an upsert event for each entry currently
+// in the main cache, followed by a sync event. The cache lock is held for the
+// whole replay, so a consumer that starts listening for events before calling
+// this can bootstrap its view of the state without missing concurrent
+// updates.
+func (a *Allocator) ReplayCurrentState(cb func(AllocatorChange)) {
+	a.mainCache.replayCurrentState(cb)
+}
+
+// eventSubscriptionBufferSize is the capacity of the channel returned by
+// SubscribeEvents.
+const eventSubscriptionBufferSize = 1024
+
+// SubscribeEvents returns a channel which first replays an upsert event for
+// every entry currently in the main cache and then streams the live changes.
+// The replay and the subscription to the live changes happen under a
+// consistent read of the cache, so no event is lost or duplicated across the
+// boundary between them. The channel must be drained continuously, as the
+// allocator blocks emitting changes once its buffer is full; calling the
+// returned function unsubscribes and closes it.
+func (a *Allocator) SubscribeEvents(ctx context.Context) (AllocatorEventRecvChan, func()) {
+	subCtx, cancel := context.WithCancel(ctx)
+
+	events := stream.ToChannel(subCtx,
+		stream.Map(
+			stream.Filter(stream.Observable[AllocatorChange](a), func(change AllocatorChange) bool {
+				return change.Kind != AllocatorChangeSync
+			}),
+			func(change AllocatorChange) AllocatorEvent {
+				return AllocatorEvent{Typ: change.Kind, ID: change.ID, Key: change.Key}
+			},
+		),
+		stream.WithBufferSize(eventSubscriptionBufferSize),
+	)
+
+	return events, cancel
+}
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"os"
+	"path/filepath"
 	"slices"
 	"sort"
 	"strings"
@@ -20,7 +22,9 @@ import (
 	"github.com/cilium/stream"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
 
+	"github.com/cilium/cilium/pkg/backoff"
 	"github.com/cilium/cilium/pkg/idpool"
 	"github.com/cilium/cilium/pkg/kvstore"
 	"github.com/cilium/cilium/pkg/lock"
@@ -35,8 +39,18 @@ type dummyBackend struct {
 
 	updateMasterKeyHandler func(ctx context.Context, id idpool.ID, key AllocatorKey) error
 	updateSlaveKeyHandler  func(ctx context.Context, id idpool.ID, key AllocatorKey) error
+	getHandler             func(ctx context.Context, key AllocatorKey) (idpool.ID, error)
 
 	disableListDone bool
+
+	// getCalls and getByIDCalls count the lookups served by the backend,
+	// allowing tests to assert which lookups were answered from the cache.
+	getCalls     int
+	getByIDCalls int
+
+	// listAndWatchFailures makes the first N ListAndWatch calls return
+	// immediately, without listing anything nor reporting the list as done.
+	listAndWatchFailures int
 }
 
 func newDummyBackend() *dummyBackend {
@@ -156,8 +170,12 @@ func (d *dummyBackend) UpdateKeyIfLocked(ctx context.Context, id idpool.ID, key
 }
 
 func (d *dummyBackend) Get(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
-	d.mutex.RLock()
-	defer d.mutex.RUnlock()
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.getCalls++
+	if d.getHandler != nil {
+		return d.getHandler(ctx, key)
+	}
 	// This loops through slaveKeys to mimic the kvstore implementation
 	for id, k := range d.slaveKeys {
 		if key.GetKey() == k.GetKey() {
@@ -172,14 +190,27 @@ func (d *dummyBackend) GetIfLocked(ctx context.Context, key AllocatorKey, lock k
 }
 
 func (d *dummyBackend) GetByID(ctx context.Context, id idpool.ID) (AllocatorKey, error) {
-	d.mutex.RLock()
-	defer d.mutex.RUnlock()
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.getByIDCalls++
 	if key, ok := d.masterKeys[id]; ok {
 		return key, nil
 	}
 	return nil, nil
 }
 
+func (d *dummyBackend) GetByIDBatch(ctx context.Context, ids []idpool.ID) (map[idpool.ID]AllocatorKey, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	keys := make(map[idpool.ID]AllocatorKey, len(ids))
+	for _, id := range ids {
+		if key, ok := d.masterKeys[id]; ok {
+			keys[id] = key
+		}
+	}
+	return keys, nil
+}
+
 func (d *dummyBackend) Release(ctx context.Context, id idpool.ID, key AllocatorKey) error {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
@@ -205,15 +236,21 @@ func (d *dummyBackend) ListIDs(ctx context.Context) (identityIDs []idpool.ID, er
 
 func (d *dummyBackend) ListAndWatch(ctx context.Context, handler CacheMutations) {
 	d.mutex.Lock()
+	if d.listAndWatchFailures > 0 {
+		d.listAndWatchFailures--
+		d.mutex.Unlock()
+		return
+	}
 	d.handler = handler
 
 	// Sort by ID to ensure consistent ordering
 	for _, id := range slices.Sorted(maps.Keys(d.masterKeys)) {
 		d.handler.OnUpsert(id, d.masterKeys[id])
 	}
+	listDone := !d.disableListDone
 	d.mutex.Unlock()
 
-	if !d.disableListDone {
+	if listDone {
 		d.handler.OnListDone()
 	}
 
@@ -224,13 +261,21 @@ func (d *dummyBackend) RunLocksGC(_ context.Context, _ map[string]kvstore.Value)
 	return nil, nil
 }
 
-func (d *dummyBackend) RunGC(context.Context, *rate.Limiter, map[string]uint64, idpool.ID, idpool.ID) (map[string]uint64, *GCStats, error) {
-	return nil, nil, nil
+func (d *dummyBackend) RunGC(context.Context, *rate.Limiter, map[string]uint64, time.Duration, bool, idpool.ID, idpool.ID, string) (map[string]uint64, string, []GCCandidate, *GCStats, error) {
+	return nil, "", nil, nil, nil
+}
+
+func (d *dummyBackend) MigrateKeys(context.Context, uint8, uint8) error {
+	return nil
 }
 
 type TestAllocatorKey string
 
 func (t TestAllocatorKey) GetKey() string { return string(t) }
+func (t TestAllocatorKey) IsEqualTo(other AllocatorKey) bool {
+	o, ok := other.(TestAllocatorKey)
+	return ok && t == o
+}
 func (t TestAllocatorKey) GetAsMap() map[string]string {
 	return map[string]string{string(t): string(t)}
 }
@@ -298,6 +343,100 @@ func TestPrefixMask(t *testing.T) {
 	a.Delete()
 }
 
+func TestClusterIDRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		clusterID   uint32
+		maxClusters uint32
+
+		minID, maxID, prefixMask idpool.ID
+		wantErr                  bool
+	}{
+		{
+			name: "cluster 0 of 256", clusterID: 0, maxClusters: 256,
+			minID: 1, maxID: 1<<16 - 1, prefixMask: 0,
+		},
+		{
+			name: "cluster 1 of 256", clusterID: 1, maxClusters: 256,
+			minID: 1 << 16, maxID: 2<<16 - 1, prefixMask: 1 << 16,
+		},
+		{
+			name: "cluster 255 of 256", clusterID: 255, maxClusters: 256,
+			minID: 255 << 16, maxID: 256<<16 - 1, prefixMask: 255 << 16,
+		},
+		{
+			name: "cluster 1 of 512", clusterID: 1, maxClusters: 512,
+			minID: 1 << 15, maxID: 2<<15 - 1, prefixMask: 1 << 15,
+		},
+		{
+			name: "cluster 3 of 4", clusterID: 3, maxClusters: 4,
+			minID: 3 << 22, maxID: 1<<24 - 1, prefixMask: 3 << 22,
+		},
+		{name: "zero clusters", clusterID: 0, maxClusters: 0, wantErr: true},
+		{name: "single cluster", clusterID: 0, maxClusters: 1, wantErr: true},
+		{name: "not a power of two", clusterID: 0, maxClusters: 3, wantErr: true},
+		{name: "cluster ID at limit", clusterID: 256, maxClusters: 256, wantErr: true},
+		{name: "cluster ID beyond limit", clusterID: 1000, maxClusters: 256, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minID, maxID, prefixMask, err := clusterIDRange(tt.clusterID, tt.maxClusters)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.minID, minID)
+			require.Equal(t, tt.maxID, maxID)
+			require.Equal(t, tt.prefixMask, prefixMask)
+		})
+	}
+}
+
+func TestWithClusterID(t *testing.T) {
+	backend := newDummyBackend()
+	a, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithClusterID(3, 256), WithoutGC(), WithoutAutostart())
+	require.NoError(t, err)
+	require.Equal(t, idpool.ID(3<<16), a.min)
+	require.Equal(t, idpool.ID(4<<16-1), a.max)
+	require.Equal(t, idpool.ID(3<<16), a.prefixMask)
+	a.Delete()
+
+	// Out-of-range parameters surface at construction time.
+	_, err = NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithClusterID(256, 256), WithoutGC(), WithoutAutostart())
+	require.Error(t, err)
+
+	// Combining the derived configuration with a manual one is rejected,
+	// no matter which option sets the latter.
+	for _, opt := range []AllocatorOption{
+		WithMin(idpool.ID(1)),
+		WithMax(idpool.ID(256)),
+		WithIDRange(idpool.ID(1), idpool.ID(256)),
+		WithPrefixMask(idpool.ID(1 << 16)),
+	} {
+		_, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+			WithClusterID(3, 256), opt, WithoutGC(), WithoutAutostart())
+		require.ErrorContains(t, err, "conflicts")
+	}
+}
+
+func TestWithIDRange(t *testing.T) {
+	backend := newDummyBackend()
+	a, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithIDRange(idpool.ID(10), idpool.ID(20)), WithoutGC(), WithoutAutostart())
+	require.NoError(t, err)
+	require.Equal(t, idpool.ID(10), a.min)
+	require.Equal(t, idpool.ID(20), a.max)
+	a.Delete()
+
+	_, err = NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithIDRange(idpool.ID(20), idpool.ID(10)), WithoutGC(), WithoutAutostart())
+	require.Error(t, err)
+}
+
 func testAllocator(t *testing.T, maxID idpool.ID) {
 	backend := newDummyBackend()
 	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMax(maxID), WithoutGC())
@@ -381,7 +520,7 @@ func testAllocator(t *testing.T, maxID idpool.ID) {
 	rateLimiter := rate.NewLimiter(10*time.Second, 100)
 
 	// running the GC should not evict any entries
-	allocator.RunGC(context.Background(), rateLimiter, nil)
+	allocator.RunGC(context.Background(), rateLimiter, nil, 0, false)
 
 	// release final reference of all IDs
 	for i := idpool.ID(1); i <= maxID; i++ {
@@ -394,13 +533,186 @@ func testAllocator(t *testing.T, maxID idpool.ID) {
 	}
 
 	// running the GC should evict all entries
-	allocator.RunGC(context.Background(), rateLimiter, nil)
+	allocator.RunGC(context.Background(), rateLimiter, nil, 0, false)
 
 	allocator.DeleteAllKeys()
 	allocator.Delete()
 	allocator2.Delete()
 }
 
+// pagedGCBackend is a dummyBackend whose RunGC serves a fixed, sorted set of
+// master keys in pages, recording how often each key was considered.
+type pagedGCBackend struct {
+	*dummyBackend
+	pageSize int
+	keys     []string
+	seen     map[string]int
+}
+
+func (p *pagedGCBackend) RunGC(ctx context.Context, rateLimit *rate.Limiter, staleKeysPrevRound map[string]uint64, minKeyAge time.Duration, dryRun bool, minID, maxID idpool.ID, continuation string) (map[string]uint64, string, []GCCandidate, *GCStats, error) {
+	start := 0
+	if continuation != "" {
+		start = sort.SearchStrings(p.keys, continuation) + 1
+	}
+	end := min(start+p.pageSize, len(p.keys))
+
+	staleKeys := map[string]uint64{}
+	for _, key := range p.keys[start:end] {
+		p.seen[key]++
+		staleKeys[key] = 1
+	}
+
+	next := ""
+	if end < len(p.keys) {
+		next = p.keys[end-1]
+	}
+	return staleKeys, next, nil, &GCStats{Alive: end - start}, nil
+}
+
+func TestRunGCPagination(t *testing.T) {
+	backend := &pagedGCBackend{
+		dummyBackend: newDummyBackend(),
+		pageSize:     1000,
+		seen:         map[string]int{},
+	}
+	for i := range 10000 {
+		backend.keys = append(backend.keys, fmt.Sprintf("key-%05d", i))
+	}
+
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithoutGC(), WithoutAutostart())
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+
+	rateLimiter := rate.NewLimiter(10*time.Second, 100)
+	staleKeys, _, stats, err := allocator.RunGC(context.Background(), rateLimiter, nil, 0, false)
+	require.NoError(t, err)
+
+	// Every key must have been considered exactly once, across all pages.
+	require.Len(t, staleKeys, len(backend.keys))
+	for _, key := range backend.keys {
+		require.Equal(t, 1, backend.seen[key], "key %s", key)
+	}
+
+	require.Equal(t, 10, stats.Pages)
+	require.Equal(t, len(backend.keys), stats.Alive)
+	require.Positive(t, stats.ScanDuration)
+}
+
+func TestReleaseGracePeriod(t *testing.T) {
+	backend := newDummyBackend()
+	gracePeriod := 100 * time.Millisecond
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithReleaseGracePeriod(gracePeriod))
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+
+	hasSlaveKey := func(id idpool.ID) bool {
+		backend.mutex.RLock()
+		defer backend.mutex.RUnlock()
+		_, ok := backend.slaveKeys[id]
+		return ok
+	}
+
+	key := TestAllocatorKey("foo")
+	id, _, _, err := allocator.Allocate(context.Background(), key)
+	require.NoError(t, err)
+
+	// Releasing the last reference defers the backend release.
+	lastUse, err := allocator.Release(context.Background(), key)
+	require.NoError(t, err)
+	require.True(t, lastUse)
+	require.True(t, hasSlaveKey(id))
+
+	// The pending release is visible in the debug snapshot.
+	snapshot := allocator.Snapshot(false)
+	require.Len(t, snapshot.Entries, 1)
+	require.True(t, snapshot.Entries[0].PendingRelease)
+
+	// Re-allocating the key within the grace period cancels the pending
+	// release and keeps the ID.
+	id2, isNew, _, err := allocator.Allocate(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, id, id2)
+	require.False(t, isNew)
+
+	snapshot = allocator.Snapshot(false)
+	require.Len(t, snapshot.Entries, 1)
+	require.False(t, snapshot.Entries[0].PendingRelease)
+
+	// The canceled release must not fire after the grace period.
+	time.Sleep(3 * gracePeriod)
+	require.True(t, hasSlaveKey(id))
+
+	// Releasing again and letting the grace period expire performs the
+	// backend release.
+	lastUse, err = allocator.Release(context.Background(), key)
+	require.NoError(t, err)
+	require.True(t, lastUse)
+	require.Eventually(t, func() bool { return !hasSlaveKey(id) }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReleaseGracePeriodShutdownFlush(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithReleaseGracePeriod(time.Hour))
+	require.NoError(t, err)
+
+	key := TestAllocatorKey("foo")
+	id, _, _, err := allocator.Allocate(context.Background(), key)
+	require.NoError(t, err)
+
+	lastUse, err := allocator.Release(context.Background(), key)
+	require.NoError(t, err)
+	require.True(t, lastUse)
+
+	// Deleting the allocator flushes the pending release immediately, long
+	// before the grace period would have expired.
+	allocator.Delete()
+
+	backend.mutex.RLock()
+	_, ok := backend.slaveKeys[id]
+	backend.mutex.RUnlock()
+	require.False(t, ok)
+}
+
+func TestShutdown(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithSyncInterval(time.Millisecond))
+	require.NoError(t, err)
+
+	// Race allocations and releases against the shutdown; they either
+	// complete or fail, but must not block the teardown.
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			key := TestAllocatorKey(fmt.Sprintf("key%04d", i))
+			for range 10 {
+				if _, _, _, err := allocator.Allocate(context.Background(), key); err != nil {
+					return
+				}
+				allocator.Release(context.Background(), key)
+			}
+		}()
+	}
+	close(start)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, allocator.Shutdown(ctx))
+
+	// Shutdown is idempotent.
+	require.NoError(t, allocator.Shutdown(ctx))
+
+	wg.Wait()
+}
+
 func TestAllocateCached(t *testing.T) {
 	testAllocator(t, idpool.ID(256)) // enable use of local cache
 }
@@ -463,6 +775,38 @@ func TestObserveAllocatorChanges(t *testing.T) {
 	require.False(t, notClosed)
 }
 
+func TestReplayCurrentState(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMin(idpool.ID(1)), WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	require.NotNil(t, allocator)
+
+	numAllocations := 10
+
+	for i := range numAllocations {
+		key := TestAllocatorKey(fmt.Sprintf("key%04d", i))
+		_, _, _, err := allocator.Allocate(context.Background(), key)
+		require.NoError(t, err)
+	}
+
+	var changes []AllocatorChange
+	allocator.ReplayCurrentState(func(change AllocatorChange) {
+		changes = append(changes, change)
+	})
+
+	require.Len(t, changes, numAllocations+1)
+	for _, change := range changes[:numAllocations] {
+		// The entries are replayed in hash map traversal order, just validate
+		// that the fields are set.
+		require.Equal(t, AllocatorChangeUpsert, change.Kind)
+		require.True(t, strings.HasPrefix(change.Key.String(), "key0"))
+		require.NotEqual(t, 0, change.ID)
+	}
+
+	// The replay ends with a sync event marking the snapshot as complete.
+	require.Equal(t, AllocatorChangeSync, changes[numAllocations].Kind)
+}
+
 // TestHandleK8sDelete tests the behavior of the allocator of handling OnDelete events
 // when master key protection is enabled vs disabled.
 func TestHandleK8sDelete(t *testing.T) {
@@ -545,7 +889,8 @@ func TestWatchRemoteKVStore(t *testing.T) {
 		synced.Store(false)
 	}
 
-	global := Allocator{logger: hivetest.Logger(t), remoteCaches: make(map[string]*remoteCache)}
+	global := Allocator{metrics: noopMetrics{}, remoteCaches: make(map[string]*remoteCache)}
+	global.logger.Store(hivetest.Logger(t))
 	events := make(AllocatorEventChan, 10)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -583,8 +928,17 @@ func TestWatchRemoteKVStore(t *testing.T) {
 
 	require.True(t, rc.Synced(), "The cache should now be synchronized")
 	require.True(t, synced.Load(), "The on-sync callback should have been executed")
+
+	status := rc.Status()
+	require.Equal(t, 2, status.NumEntries)
+	require.True(t, status.Synced)
+	require.False(t, status.LastSynced.IsZero(), "The last synchronization time should have been recorded")
+	require.Zero(t, status.DrainedEntries)
+	require.NoError(t, status.LastError)
+
 	stop(cancel)
 	require.False(t, rc.Synced(), "The cache should no longer be synchronized when stopped")
+	require.ErrorIs(t, rc.Status().LastError, context.Canceled)
 
 	// Add a new remote cache with the same name, and assert that it overrides
 	// the previous one, and the proper events are emitted (including deletions
@@ -608,6 +962,13 @@ func TestWatchRemoteKVStore(t *testing.T) {
 		return global.remoteCaches["remote"] == rc
 	}, 1*time.Second, 10*time.Millisecond)
 
+	// The stale identity no longer present after the reconnection has been
+	// drained, and accounted for in the status.
+	require.Eventually(t, func() bool {
+		status := rc.Status()
+		return status.NumEntries == 2 && status.DrainedEntries == 1
+	}, 1*time.Second, 10*time.Millisecond)
+
 	stop(cancel)
 
 	// Add a new remote cache with the same name, but cancel the context before
@@ -634,6 +995,12 @@ func TestWatchRemoteKVStore(t *testing.T) {
 	require.Equal(t, AllocatorEvent{ID: idpool.ID(7), Key: TestAllocatorKey("foo"), Typ: AllocatorChangeDelete}, <-events)
 	require.Equal(t, rc, global.remoteCaches["remote"])
 
+	status = oc.Status()
+	require.False(t, status.Synced, "The cache stopped before synchronizing should not be synced")
+	require.True(t, status.LastSynced.IsZero(), "No successful synchronization should have been recorded")
+	require.Equal(t, 1, status.DrainedEntries, "The entry not present in the previous cache should have been drained")
+	require.ErrorIs(t, status.LastError, context.Canceled)
+
 	require.Empty(t, events)
 
 	// Remove the remote caches and assert that a deletion event is triggered
@@ -653,6 +1020,54 @@ func TestWatchRemoteKVStore(t *testing.T) {
 	require.Equal(t, AllocatorEvent{ID: idpool.ID(5), Key: TestAllocatorKey("bar"), Typ: AllocatorChangeDelete}, drained[1])
 }
 
+func TestWatchRemoteKVStoreRetry(t *testing.T) {
+	var wg sync.WaitGroup
+
+	prevMin, prevMax := remoteCacheRetryMinBackoff, remoteCacheRetryMaxBackoff
+	remoteCacheRetryMinBackoff, remoteCacheRetryMaxBackoff = 5*time.Millisecond, time.Second
+	t.Cleanup(func() { remoteCacheRetryMinBackoff, remoteCacheRetryMaxBackoff = prevMin, prevMax })
+
+	global := Allocator{metrics: noopMetrics{}, remoteCaches: make(map[string]*remoteCache)}
+	global.logger.Store(hivetest.Logger(t))
+	events := make(AllocatorEventChan, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	// The first two lists fail; the watch is restarted with backoff until
+	// the third succeeds.
+	backend := newDummyBackend()
+	backend.listAndWatchFailures = 2
+	remote, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithEvents(events), WithoutAutostart(), WithoutGC())
+	require.NoError(t, err)
+
+	backend.AllocateID(ctx, idpool.ID(1), TestAllocatorKey("foo"))
+
+	rc := global.NewRemoteCache("remote", remote)
+	wg.Add(1)
+	go func() {
+		rc.Watch(ctx, func(context.Context) {})
+		wg.Done()
+	}()
+
+	require.Eventually(t, func() bool {
+		global.remoteCachesMutex.RLock()
+		defer global.remoteCachesMutex.RUnlock()
+		return global.remoteCaches["remote"] == rc
+	}, 1*time.Second, 10*time.Millisecond)
+
+	require.Equal(t, AllocatorEvent{ID: idpool.ID(1), Key: TestAllocatorKey("foo"), Typ: AllocatorChangeUpsert}, <-events)
+
+	status := rc.Status()
+	require.True(t, status.Synced, "The cache should be synchronized after the list succeeds")
+	require.Equal(t, 2, status.RetryCount, "Both failed lists should have been retried with backoff")
+	require.NoError(t, status.LastError)
+	require.False(t, status.LastSynced.IsZero())
+}
+
 func TestCacheValidators(t *testing.T) {
 	const (
 		validID   = 10
@@ -706,75 +1121,1801 @@ func TestCacheValidators(t *testing.T) {
 	require.Equal(t, AllocatorChangeDelete, kind)
 }
 
-func TestSyncLocalKeys(t *testing.T) {
-	numIDs := idpool.ID(3)
+func TestCacheValidatorRejections(t *testing.T) {
+	const (
+		minInvalidID = 100
+		key          = TestAllocatorKey("key")
+	)
+
+	var (
+		backend  = &dummyBackend{disableListDone: true}
+		events   = make(chan AllocatorEvent, 16)
+		rejected = make(chan AllocatorEvent, 16)
+		metrics  = newMockMetrics()
+	)
+
+	allocator, err := NewAllocator(
+		hivetest.Logger(t),
+		TestAllocatorKey(""), backend,
+		WithEvents(events), WithoutGC(), WithMetrics(metrics),
+		WithRejectedEventSink(rejected),
+		WithCacheValidator(func(_ AllocatorChangeKind, id idpool.ID, _ AllocatorKey) error {
+			if id >= minInvalidID {
+				return errors.New("out of range")
+			}
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+	allocator.mainCache.OnListDone()
+
+	t.Cleanup(func() { allocator.Delete() })
+
+	allocator.mainCache.OnUpsert(10, key)
+	allocator.mainCache.OnUpsert(100, key)
+	allocator.mainCache.OnUpsert(101, key)
+	allocator.mainCache.OnDelete(10, key)
+	allocator.mainCache.OnDelete(100, key)
+
+	require.Equal(t, 1, metrics.cacheValidationCount(outcomeAcceptedValue, AllocatorChangeUpsert))
+	require.Equal(t, 1, metrics.cacheValidationCount(outcomeAcceptedValue, AllocatorChangeDelete))
+	require.Equal(t, 2, metrics.cacheValidationCount(outcomeRejectedValue, AllocatorChangeUpsert))
+	require.Equal(t, 1, metrics.cacheValidationCount(outcomeRejectedValue, AllocatorChangeDelete))
+
+	require.Len(t, rejected, 3, "Rejected events should be forwarded to the sink")
+	require.Equal(t, AllocatorEvent{AllocatorChangeUpsert, idpool.ID(100), key}, <-rejected)
+	require.Equal(t, AllocatorEvent{AllocatorChangeUpsert, idpool.ID(101), key}, <-rejected)
+	require.Equal(t, AllocatorEvent{AllocatorChangeDelete, idpool.ID(100), key}, <-rejected)
+
+	require.Len(t, events, 2, "Only valid events should be propagated")
+}
+
+func TestKeyVersion(t *testing.T) {
 	backend := newDummyBackend()
-	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMax(numIDs))
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithKeyVersion(1))
 	require.NoError(t, err)
 	require.NotNil(t, allocator)
 
-	var ids []idpool.ID
+	t.Cleanup(func() { allocator.Delete() })
 
-	// allocate IDs
-	for i := idpool.ID(1); i <= numIDs; i++ {
-		key := TestAllocatorKey(fmt.Sprintf("key-%04d", i))
-		id, _, _, err := allocator.Allocate(context.Background(), key)
-		require.NoError(t, err)
-		require.NotEqual(t, idpool.NoID, id)
-		ids = append(ids, id)
+	ctx := context.Background()
+	id, isNew, firstUse, err := allocator.Allocate(ctx, TestAllocatorKey("foo"))
+	require.NoError(t, err)
+	require.NotEqual(t, idpool.NoID, id)
+	require.True(t, isNew)
+	require.True(t, firstUse)
+
+	// The backend sees the canonical representation with the version prefix.
+	backend.mutex.RLock()
+	master := backend.masterKeys[id]
+	backend.mutex.RUnlock()
+	require.NotNil(t, master)
+	require.Equal(t, "v1/foo", master.GetKey())
+
+	// Lookups with the unversioned key resolve the versioned entry.
+	got, err := allocator.Get(ctx, TestAllocatorKey("foo"))
+	require.NoError(t, err)
+	require.Equal(t, id, got)
+
+	// Keys carrying a different version, or none at all, are ignored by the
+	// cache, while keys carrying the configured version are accepted. The
+	// IDs are outside the allocation range to not collide with 'id'.
+	allocator.mainCache.OnUpsert(idpool.ID(1077), TestAllocatorKey("v2/bar"))
+	require.Nil(t, allocator.mainCache.getByID(idpool.ID(1077)))
+	allocator.mainCache.OnUpsert(idpool.ID(1078), TestAllocatorKey("bar"))
+	require.Nil(t, allocator.mainCache.getByID(idpool.ID(1078)))
+	allocator.mainCache.OnUpsert(idpool.ID(1079), TestAllocatorKey("v1/bar"))
+	require.NotNil(t, allocator.mainCache.getByID(idpool.ID(1079)))
+
+	// Releases with the unversioned key drop the versioned slave key.
+	lastUse, err := allocator.Release(ctx, TestAllocatorKey("foo"))
+	require.NoError(t, err)
+	require.True(t, lastUse)
 
-		// Ensure id stored in backend is the same
-		backendID, err := backend.Get(context.TODO(), key)
-		require.NoError(t, err)
-		require.Equal(t, backendID, id)
-	}
+	backend.mutex.RLock()
+	_, ok := backend.slaveKeys[id]
+	backend.mutex.RUnlock()
+	require.False(t, ok)
+}
 
-	allocator.syncLocalKeys()
+func TestKeyAliases(t *testing.T) {
+	ctx := context.Background()
+	backend := newDummyBackend()
 
-	// Release the use one id/delete the slave key
-	key, err := backend.GetByID(context.TODO(), ids[0])
+	// The backend holds a master key written by an older version with a
+	// different canonical encoding.
+	oldKey := TestAllocatorKey("old/foo")
+	_, err := backend.AllocateID(ctx, idpool.ID(42), oldKey)
 	require.NoError(t, err)
-	err = backend.Release(context.TODO(), ids[0], key)
+
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(),
+		WithKeyAliases(func(key AllocatorKey) []string {
+			return []string{"old/" + key.GetKey()}
+		}))
 	require.NoError(t, err)
+	t.Cleanup(func() { allocator.Delete() })
+	require.NoError(t, allocator.WaitForInitialSync(ctx))
 
-	// Delete the master key of one ID
-	err = backend.DeleteID(context.TODO(), ids[1])
+	// Lookups under the current encoding resolve through the alias.
+	id, err := allocator.Get(ctx, TestAllocatorKey("foo"))
 	require.NoError(t, err)
+	require.Equal(t, idpool.ID(42), id)
 
-	// Delete both master and slave key for another ID
-	key, err = backend.GetByID(context.TODO(), ids[2])
+	// Allocation reuses the ID found under the alias rather than
+	// allocating a new one.
+	id, isNew, firstUse, err := allocator.Allocate(ctx, TestAllocatorKey("foo"))
 	require.NoError(t, err)
-	err = backend.Release(context.TODO(), ids[2], key)
+	require.Equal(t, idpool.ID(42), id)
+	require.False(t, isNew)
+	require.True(t, firstUse)
+
+	// The first use rewrote the master key under the current encoding.
+	backend.mutex.RLock()
+	master := backend.masterKeys[idpool.ID(42)]
+	backend.mutex.RUnlock()
+	require.NotNil(t, master)
+	require.Equal(t, "foo", master.GetKey())
+}
+
+func TestKeyAliasesBackendLookup(t *testing.T) {
+	ctx := context.Background()
+	backend := newDummyBackend()
+
+	// Another node still references the key under the old encoding: both
+	// the master and the slave key exist in the old format only.
+	oldKey := TestAllocatorKey("old/foo")
+	_, err := backend.AllocateID(ctx, idpool.ID(7), oldKey)
 	require.NoError(t, err)
-	err = backend.DeleteID(context.TODO(), ids[2])
+	require.NoError(t, backend.AcquireReference(ctx, idpool.ID(7), oldKey, nil))
+
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithoutAutostart(),
+		WithKeyAliases(func(key AllocatorKey) []string {
+			return []string{"old/" + key.GetKey()}
+		}))
 	require.NoError(t, err)
 
-	allocator.syncLocalKeys()
+	// With no cache available, the lookup falls through to the backend and
+	// still resolves through the alias.
+	id, err := allocator.GetNoCache(ctx, TestAllocatorKey("foo"))
+	require.NoError(t, err)
+	require.Equal(t, idpool.ID(7), id)
+}
 
-	// Ensure all IDs are present
-	for i := idpool.ID(1); i <= numIDs; i++ {
-		key := TestAllocatorKey(fmt.Sprintf("key-%04d", i))
+func TestLookupCachePopulation(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+	<-allocator.initialListDone
 
-		// Ensure all slave keys are present via Get
-		backendID, err := backend.Get(context.TODO(), key)
-		require.NoError(t, err)
-		require.NotEqual(t, idpool.NoID, backendID)
+	getCalls := func() int {
+		backend.mutex.RLock()
+		defer backend.mutex.RUnlock()
+		return backend.getCalls
+	}
+	getByIDCalls := func() int {
+		backend.mutex.RLock()
+		defer backend.mutex.RUnlock()
+		return backend.getByIDCalls
+	}
 
-		// Ensure all master keys are present via GetById
-		backendKey, err := backend.GetByID(context.TODO(), backendID)
+	// Simulate a key allocated by another node that the watcher has not
+	// delivered yet, by writing to the backend behind the allocator's back.
+	key := TestAllocatorKey("remote")
+	backend.mutex.Lock()
+	backend.masterKeys[idpool.ID(1300)] = key
+	backend.slaveKeys[idpool.ID(1300)] = key
+	backend.mutex.Unlock()
+
+	// The first reverse lookup hits the backend and populates the cache;
+	// the second one is served from the cache.
+	resolved, err := allocator.GetByID(context.Background(), idpool.ID(1300))
+	require.NoError(t, err)
+	require.Equal(t, key, resolved)
+	require.Equal(t, 1, getByIDCalls())
+
+	resolved, err = allocator.GetByID(context.Background(), idpool.ID(1300))
+	require.NoError(t, err)
+	require.Equal(t, key, resolved)
+	require.Equal(t, 1, getByIDCalls())
+
+	// Forward lookups do not populate the cache by default. The reverse
+	// lookups above already cached the association, so use a fresh key.
+	other := TestAllocatorKey("remote2")
+	backend.mutex.Lock()
+	backend.masterKeys[idpool.ID(1301)] = other
+	backend.slaveKeys[idpool.ID(1301)] = other
+	backend.mutex.Unlock()
+
+	for range 2 {
+		id, err := allocator.Get(context.Background(), other)
 		require.NoError(t, err)
-		require.Equal(t, key, backendKey)
+		require.Equal(t, idpool.ID(1301), id)
 	}
-
+	require.Equal(t, 2, getCalls())
 }
 
-func TestSyncLocalKeysWithIdentityAllocations(t *testing.T) {
-	numIDs := idpool.ID(500)
+func TestLookupCachePopulationForward(t *testing.T) {
 	backend := newDummyBackend()
-	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMax(100*numIDs))
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithLookupCachePopulation())
 	require.NoError(t, err)
-	require.NotNil(t, allocator)
-
+	t.Cleanup(allocator.Delete)
+	<-allocator.initialListDone
+
+	key := TestAllocatorKey("remote")
+	backend.mutex.Lock()
+	backend.masterKeys[idpool.ID(1300)] = key
+	backend.slaveKeys[idpool.ID(1300)] = key
+	backend.mutex.Unlock()
+
+	// With WithLookupCachePopulation, only the first forward lookup hits
+	// the backend.
+	for range 2 {
+		id, err := allocator.Get(context.Background(), key)
+		require.NoError(t, err)
+		require.Equal(t, idpool.ID(1300), id)
+	}
+	backend.mutex.RLock()
+	calls := backend.getCalls
+	backend.mutex.RUnlock()
+	require.Equal(t, 1, calls)
+}
+
+func TestLookupCachePopulationValidator(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(),
+		WithCacheValidator(func(kind AllocatorChangeKind, id idpool.ID, key AllocatorKey) error {
+			if key != nil && key.GetKey() == "poison" {
+				return errors.New("rejected")
+			}
+			return nil
+		}))
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+	<-allocator.initialListDone
+
+	// Associations rejected by the cache validators are still returned, but
+	// never cached: every lookup goes to the backend.
+	key := TestAllocatorKey("poison")
+	backend.mutex.Lock()
+	backend.masterKeys[idpool.ID(1300)] = key
+	backend.mutex.Unlock()
+
+	for range 2 {
+		resolved, err := allocator.GetByID(context.Background(), idpool.ID(1300))
+		require.NoError(t, err)
+		require.Equal(t, key, resolved)
+	}
+	backend.mutex.RLock()
+	calls := backend.getByIDCalls
+	backend.mutex.RUnlock()
+	require.Equal(t, 2, calls)
+}
+
+func TestIDSelectionStrategy(t *testing.T) {
+	// allocateWithPeer runs two allocators against a shared backend. The
+	// peer allocator registers its watcher last, so the allocator under
+	// test does not learn about the peer's allocations and has to discover
+	// the conflicts through failed allocation attempts. Returns the total
+	// number of attempts the allocator under test needed for its 16 keys.
+	allocateWithPeer := func(strategy idpool.SelectionStrategy) int {
+		backend := newDummyBackend()
+		metrics := newMockMetrics()
+		allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+			WithMax(idpool.ID(256)), WithoutGC(), WithMetrics(metrics),
+			WithIDSelectionStrategy(strategy),
+			WithBackoffStrategy(&backoff.Constant{Logger: hivetest.Logger(t), Interval: time.Microsecond}))
+		require.NoError(t, err)
+		t.Cleanup(allocator.Delete)
+		<-allocator.initialListDone
+
+		peer, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+			WithMax(idpool.ID(256)), WithoutGC())
+		require.NoError(t, err)
+		t.Cleanup(peer.Delete)
+		<-peer.initialListDone
+
+		for i := range 8 {
+			_, _, _, err := peer.Allocate(context.Background(), TestAllocatorKey(fmt.Sprintf("peer%04d", i)))
+			require.NoError(t, err)
+		}
+
+		for i := range 16 {
+			_, _, _, err := allocator.Allocate(context.Background(), TestAllocatorKey(fmt.Sprintf("own%04d", i)))
+			require.NoError(t, err)
+		}
+		return metrics.totalAttempts()
+	}
+
+	// The sequential strategy proposes the same candidate IDs the peer
+	// already took and has to retry past all of them; the random strategy
+	// rarely collides within the mostly free ID space.
+	sequential := allocateWithPeer(idpool.SelectionSequential)
+	random := allocateWithPeer(idpool.SelectionRandom)
+	require.Greater(t, sequential, random)
+	require.GreaterOrEqual(t, sequential, 16+8)
+}
+
+func TestEventsNonBlocking(t *testing.T) {
+	backend := &dummyBackend{disableListDone: true}
+	events := make(chan AllocatorEvent, 1)
+
+	allocator, err := NewAllocator(
+		hivetest.Logger(t),
+		TestAllocatorKey(""), backend,
+		WithEvents(events), WithEventsNonBlocking(), WithoutGC(),
+	)
+	require.NoError(t, err)
+	allocator.mainCache.OnListDone()
+
+	t.Cleanup(func() { allocator.Delete() })
+
+	// The first event fills the channel, the second one must be dropped
+	// without blocking.
+	allocator.mainCache.OnUpsert(idpool.ID(10), TestAllocatorKey("key10"))
+	allocator.mainCache.OnUpsert(idpool.ID(11), TestAllocatorKey("key11"))
+	require.Len(t, events, 1, "Event should have been dropped, not queued")
+	require.Equal(t, uint64(1), allocator.eventsDropped)
+	require.Equal(t, AllocatorEvent{AllocatorChangeUpsert, idpool.ID(10), TestAllocatorKey("key10")}, <-events)
+
+	// Both IDs are cached even though only one event was delivered.
+	require.Equal(t, TestAllocatorKey("key10"), allocator.mainCache.getByID(idpool.ID(10)))
+	require.Equal(t, TestAllocatorKey("key11"), allocator.mainCache.getByID(idpool.ID(11)))
+
+	// Delivery resumes once the channel has been drained.
+	allocator.mainCache.OnDelete(idpool.ID(10), TestAllocatorKey("key10"))
+	require.Len(t, events, 1, "Delete event should be propagated")
+	require.Equal(t, AllocatorEvent{AllocatorChangeDelete, idpool.ID(10), TestAllocatorKey("key10")}, <-events)
+}
+
+func TestEventBufferingRing(t *testing.T) {
+	backend := &dummyBackend{disableListDone: true}
+	events := make(chan AllocatorEvent, 1)
+
+	allocator, err := NewAllocator(
+		hivetest.Logger(t),
+		TestAllocatorKey(""), backend,
+		WithEvents(events), WithEventBuffering(EventBufferingRing, 2), WithoutGC(),
+	)
+	require.NoError(t, err)
+	allocator.mainCache.OnListDone()
+
+	t.Cleanup(func() { allocator.Delete() })
+
+	ringLen := func() int {
+		allocator.eventBuffer.mutex.Lock()
+		defer allocator.eventBuffer.mutex.Unlock()
+		return allocator.eventBuffer.length
+	}
+	upsert := func(id int) {
+		allocator.mainCache.OnUpsert(idpool.ID(id), TestAllocatorKey(fmt.Sprintf("key%d", id)))
+	}
+
+	// The first event is flushed into the channel, filling it.
+	upsert(10)
+	require.Eventually(t, func() bool { return len(events) == 1 }, time.Second, 10*time.Millisecond)
+
+	// The second event is popped by the flusher which then blocks sending
+	// it, the third one stays buffered.
+	upsert(11)
+	upsert(12)
+	require.Eventually(t, func() bool { return ringLen() == 1 }, time.Second, 10*time.Millisecond)
+
+	// The fourth event fills the ring buffer, the fifth one overflows it,
+	// dropping the oldest buffered event (key12).
+	upsert(13)
+	require.Equal(t, uint64(0), allocator.eventsDropped)
+	upsert(14)
+	require.Equal(t, uint64(1), allocator.eventsDropped)
+
+	// All events but the dropped one are delivered in order.
+	for _, id := range []int{10, 11, 13, 14} {
+		require.Equal(t, AllocatorEvent{AllocatorChangeUpsert, idpool.ID(id), TestAllocatorKey(fmt.Sprintf("key%d", id))}, <-events)
+	}
+	require.Eventually(t, func() bool { return ringLen() == 0 }, time.Second, 10*time.Millisecond)
+	require.Empty(t, events)
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	require.NotNil(t, allocator)
+	t.Cleanup(allocator.Delete)
+	<-allocator.initialListDone
+
+	ctx := context.Background()
+	for _, k := range []string{"key1", "key2", "key3"} {
+		_, _, _, err := allocator.Allocate(ctx, TestAllocatorKey(k))
+		require.NoError(t, err)
+	}
+
+	events, unsubscribe := allocator.SubscribeEvents(ctx)
+
+	// Upsert further entries concurrently with the replay to exercise the
+	// boundary between the replayed and the live events.
+	go func() {
+		for id := 1000; id < 1010; id++ {
+			allocator.mainCache.OnUpsert(idpool.ID(id), TestAllocatorKey(fmt.Sprintf("key%d", id)))
+		}
+	}()
+
+	// The three allocated keys are replayed and the ten upserted ones are
+	// delivered exactly once each, either as part of the replay or live.
+	const expected = 13
+	received := make(map[idpool.ID]int, expected)
+	for total := 0; total < expected; total++ {
+		select {
+		case ev, ok := <-events:
+			require.True(t, ok)
+			require.Equal(t, AllocatorChangeUpsert, ev.Typ)
+			received[ev.ID]++
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for events, received %d of %d", len(received), expected)
+		}
+	}
+	require.Len(t, received, expected, "No event should be duplicated")
+
+	// Deletions keep streaming after the replay.
+	allocator.mainCache.OnDelete(idpool.ID(1000), TestAllocatorKey("key1000"))
+	require.Equal(t, AllocatorEvent{AllocatorChangeDelete, idpool.ID(1000), TestAllocatorKey("key1000")}, <-events)
+
+	// Unsubscribing closes the channel.
+	unsubscribe()
+	_, ok := <-events
+	require.False(t, ok)
+}
+
+func TestWarmupSnapshot(t *testing.T) {
+	backend := newDummyBackend()
+	backend.disableListDone = true
+
+	snapshot := []AllocatorEntry{
+		{ID: idpool.ID(10), Key: TestAllocatorKey("key10")},
+		{ID: idpool.ID(11), Key: TestAllocatorKey("key11")},
+	}
+
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMin(idpool.ID(1)), WithMax(idpool.ID(256)), WithoutGC(),
+		WithWarmupSnapshot(snapshot))
+	require.NoError(t, err)
+	t.Cleanup(func() { allocator.Delete() })
+
+	ctx := context.Background()
+
+	// Before the snapshot is activated, allocations block waiting for the
+	// initial key list.
+	shortCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, _, _, err = allocator.Allocate(shortCtx, TestAllocatorKey("key10"))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	allocator.activateWarmupSnapshot()
+
+	// Snapshot keys are served read-only: the ID comes straight from the
+	// snapshot and no local reference is taken.
+	id, isNew, firstUse, err := allocator.Allocate(ctx, TestAllocatorKey("key10"))
+	require.NoError(t, err)
+	require.Equal(t, idpool.ID(10), id)
+	require.False(t, isNew)
+	require.False(t, firstUse)
+	require.Equal(t, idpool.NoID, allocator.localKeys.lookupKey("key10"))
+
+	// Keys outside the snapshot cannot be allocated while degraded.
+	_, _, _, err = allocator.Allocate(ctx, TestAllocatorKey("other"))
+	require.ErrorContains(t, err, "warmup snapshot")
+
+	// Once the initial list completes, allocations go through the backend
+	// again.
+	allocator.mainCache.OnListDone()
+	id, isNew, firstUse, err = allocator.Allocate(ctx, TestAllocatorKey("other"))
+	require.NoError(t, err)
+	require.NotEqual(t, idpool.NoID, id)
+	require.True(t, isNew)
+	require.True(t, firstUse)
+}
+
+func TestInitialListTimeoutHandler(t *testing.T) {
+	backend := newDummyBackend()
+	backend.disableListDone = true
+
+	invoked := make(chan struct{})
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(),
+		WithInitialListTimeout(10*time.Millisecond),
+		WithInitialListTimeoutHandler(func() { close(invoked) }))
+	require.NoError(t, err)
+	t.Cleanup(func() { allocator.Delete() })
+
+	// The handler is invoked instead of fatally exiting when the initial
+	// key list does not complete in time.
+	select {
+	case <-invoked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the initial list timeout handler")
+	}
+
+	// The allocator keeps running and recovers once the list completes.
+	allocator.mainCache.OnListDone()
+	id, _, _, err := allocator.Allocate(context.Background(), TestAllocatorKey("foo"))
+	require.NoError(t, err)
+	require.NotEqual(t, idpool.NoID, id)
+}
+
+func TestRemoteCacheListStalled(t *testing.T) {
+	backend := newDummyBackend()
+	backend.disableListDone = true
+
+	global := Allocator{metrics: noopMetrics{}, remoteCaches: make(map[string]*remoteCache)}
+	global.logger.Store(hivetest.Logger(t))
+
+	remote, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithoutGC(), WithoutAutostart(), WithInitialListTimeout(10*time.Millisecond))
+	require.NoError(t, err)
+
+	rc := global.NewRemoteCache("remote", remote)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		rc.Watch(ctx, func(context.Context) {})
+	}()
+	t.Cleanup(func() { cancel(); wg.Wait() })
+
+	// A stalled initial listing is recorded in the cache status instead of
+	// fatally exiting.
+	require.Eventually(t, func() bool {
+		return errors.Is(rc.Status().LastError, errRemoteListStalled)
+	}, 5*time.Second, 10*time.Millisecond)
+	require.False(t, rc.Synced())
+
+	// The status recovers once the listing eventually completes.
+	remote.mainCache.OnListDone()
+	require.Eventually(t, func() bool {
+		status := rc.Status()
+		return status.Synced && status.LastError == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestListWatchWatchdog(t *testing.T) {
+	backend := newDummyBackend()
+	backend.disableListDone = true
+	metrics := newMockMetrics()
+
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithMetrics(metrics),
+		WithListWatchWatchdog(10*time.Millisecond))
+	require.NoError(t, err)
+	t.Cleanup(func() { allocator.Delete() })
+
+	// The watch never delivers any event, so the watchdog keeps restarting
+	// it and accounts for each restart.
+	require.Eventually(t, func() bool {
+		return metrics.listWatchRestartCount() >= 2
+	}, 5*time.Second, 5*time.Millisecond)
+
+	// Once the backend delivers the initial list again, the allocator
+	// becomes operational despite the watch having been restarted before
+	// the first list completed.
+	backend.mutex.Lock()
+	backend.disableListDone = false
+	backend.mutex.Unlock()
+
+	id, _, _, err := allocator.Allocate(context.Background(), TestAllocatorKey("foo"))
+	require.NoError(t, err)
+	require.NotEqual(t, idpool.NoID, id)
+}
+
+func TestSnapshotExportImport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "allocator.snapshot")
+	ctx := context.Background()
+
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithSnapshotPath(path))
+	require.NoError(t, err)
+
+	// A missing snapshot file does not install a warmup snapshot.
+	require.Nil(t, allocator.warmupKeys)
+
+	id, _, _, err := allocator.Allocate(ctx, TestAllocatorKey("key10"))
+	require.NoError(t, err)
+	require.NotEqual(t, idpool.NoID, id)
+
+	// A successful local key synchronization pass checkpoints the cache.
+	_, err = os.Stat(path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	allocator.syncLocalKeys()
+	_, err = os.Stat(path)
+	require.NoError(t, err)
+	allocator.Delete()
+
+	// A new allocator whose backend never completes the initial key list
+	// imports the snapshot file as its warmup source.
+	restoredBackend := newDummyBackend()
+	restoredBackend.disableListDone = true
+	restored, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), restoredBackend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithSnapshotPath(path))
+	require.NoError(t, err)
+	t.Cleanup(func() { restored.Delete() })
+
+	restored.activateWarmupSnapshot()
+
+	restoredID, isNew, firstUse, err := restored.Allocate(ctx, TestAllocatorKey("key10"))
+	require.NoError(t, err)
+	require.Equal(t, id, restoredID)
+	require.False(t, isNew)
+	require.False(t, firstUse)
+
+	// A corrupt snapshot file is rejected with an error.
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o644))
+	require.ErrorContains(t, restored.ImportFromFile(path), "failed to decode")
+}
+
+func TestCheckHealth(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithoutGC(), WithoutAutostart())
+	require.NoError(t, err)
+
+	require.NoError(t, allocator.CheckHealth(context.Background()))
+
+	backend.getHandler = func(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
+		return idpool.NoID, errors.New("connection refused")
+	}
+	require.ErrorContains(t, allocator.CheckHealth(context.Background()), "connection refused")
+}
+
+func TestHealthMonitorDegradedMode(t *testing.T) {
+	ctx := context.Background()
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithoutAutostart(),
+		WithHealthMonitor(5*time.Millisecond))
+	require.NoError(t, err)
+	t.Cleanup(func() { allocator.Delete() })
+
+	// Shorten the error window before starting so that the monitor enters
+	// degraded mode quickly.
+	allocator.backendErrorWindow = 20 * time.Millisecond
+	allocator.start()
+	require.NoError(t, allocator.WaitForInitialSync(ctx))
+	require.True(t, allocator.backendHealthy.Load())
+
+	// Simulate a key allocated by another node: present in the main cache,
+	// but without a local reference.
+	allocator.mainCache.OnUpsert(idpool.ID(10), TestAllocatorKey("key10"))
+
+	// Make all backend reads fail and wait for the monitor to notice.
+	backend.mutex.Lock()
+	backend.getHandler = func(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
+		return idpool.NoID, errors.New("connection refused")
+	}
+	backend.mutex.Unlock()
+	require.Eventually(t, func() bool {
+		return !allocator.backendHealthy.Load()
+	}, 5*time.Second, 5*time.Millisecond)
+
+	// Cache hits are served locally with a reference taken, and the backend
+	// write is deferred.
+	id, isNew, firstUse, err := allocator.Allocate(ctx, TestAllocatorKey("key10"))
+	require.NoError(t, err)
+	require.Equal(t, idpool.ID(10), id)
+	require.False(t, isNew)
+	require.True(t, firstUse)
+	require.Equal(t, idpool.ID(10), allocator.localKeys.lookupKey("key10"))
+
+	// Cache misses block until the context expires.
+	shortCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, _, _, err = allocator.Allocate(shortCtx, TestAllocatorKey("other"))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// A cache miss issued before recovery completes once the backend is back.
+	var (
+		blockedID  idpool.ID
+		blockedErr error
+		done       = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		blockedID, _, _, blockedErr = allocator.Allocate(ctx, TestAllocatorKey("other"))
+	}()
+
+	backend.mutex.Lock()
+	backend.getHandler = nil
+	backend.mutex.Unlock()
+	require.Eventually(t, func() bool {
+		return allocator.backendHealthy.Load()
+	}, 5*time.Second, 5*time.Millisecond)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("allocation did not unblock after backend recovery")
+	}
+	require.NoError(t, blockedErr)
+	require.NotEqual(t, idpool.NoID, blockedID)
+
+	// The deferred write is replayed against the backend.
+	require.Eventually(t, func() bool {
+		backend.mutex.RLock()
+		defer backend.mutex.RUnlock()
+		_, master := backend.masterKeys[idpool.ID(10)]
+		_, slave := backend.slaveKeys[idpool.ID(10)]
+		return master && slave
+	}, 5*time.Second, 5*time.Millisecond)
+}
+
+// fakeHealthReporter records the latest status reported through the
+// HealthReporter interface.
+type fakeHealthReporter struct {
+	mutex    lock.Mutex
+	reported bool
+	degraded bool
+	reason   string
+	err      error
+}
+
+func (f *fakeHealthReporter) OK(status string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.reported = true
+	f.degraded = false
+	f.reason = status
+	f.err = nil
+}
+
+func (f *fakeHealthReporter) Degraded(reason string, err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.reported = true
+	f.degraded = true
+	f.reason = reason
+	f.err = err
+}
+
+func (f *fakeHealthReporter) isOK() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.reported && !f.degraded
+}
+
+func (f *fakeHealthReporter) isDegraded() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.degraded
+}
+
+func (f *fakeHealthReporter) lastReason() string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.reason
+}
+
+func (f *fakeHealthReporter) lastError() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.err
+}
+
+func TestHealthReporterInitialList(t *testing.T) {
+	backend := newDummyBackend()
+	backend.disableListDone = true
+	reporter := &fakeHealthReporter{}
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithHealthReporter(reporter),
+		WithInitialListTimeout(10*time.Millisecond))
+	require.NoError(t, err)
+	t.Cleanup(func() { allocator.Delete() })
+
+	// The initial key list never completes, so the allocator eventually
+	// reports itself degraded.
+	require.Eventually(t, func() bool {
+		return reporter.isDegraded() && reporter.lastReason() == healthReasonListStalled
+	}, 5*time.Second, 5*time.Millisecond)
+
+	// Once the list completes, health recovers.
+	allocator.mainCache.OnListDone()
+	require.Eventually(t, reporter.isOK, 5*time.Second, 5*time.Millisecond)
+}
+
+func TestHealthReporterSyncFailures(t *testing.T) {
+	ctx := context.Background()
+	backend := newDummyBackend()
+	reporter := &fakeHealthReporter{}
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithHealthReporter(reporter))
+	require.NoError(t, err)
+	t.Cleanup(func() { allocator.Delete() })
+
+	_, _, _, err = allocator.Allocate(ctx, TestAllocatorKey("foo"))
+	require.NoError(t, err)
+
+	backend.mutex.Lock()
+	backend.updateMasterKeyHandler = func(ctx context.Context, id idpool.ID, key AllocatorKey) error {
+		return errors.New("write failed")
+	}
+	backend.mutex.Unlock()
+
+	// Synchronization rounds below the threshold do not degrade health.
+	for range syncFailureHealthThreshold - 1 {
+		allocator.syncLocalKeys()
+	}
+	require.False(t, reporter.isDegraded())
+
+	// Once errors have persisted over enough rounds, the allocator reports
+	// itself degraded with the error that caused it.
+	allocator.syncLocalKeys()
+	require.True(t, reporter.isDegraded())
+	require.Equal(t, healthReasonSyncFailing, reporter.lastReason())
+	require.ErrorContains(t, reporter.lastError(), "write failed")
+
+	// The first successful round recovers health.
+	backend.mutex.Lock()
+	backend.updateMasterKeyHandler = nil
+	backend.mutex.Unlock()
+	allocator.syncLocalKeys()
+	require.True(t, reporter.isOK())
+}
+
+func TestHealthReporterWatchRestarts(t *testing.T) {
+	prevReset := watchRestartResetAfter
+	watchRestartResetAfter = 100 * time.Millisecond
+	t.Cleanup(func() { watchRestartResetAfter = prevReset })
+
+	backend := newDummyBackend()
+	backend.listAndWatchFailures = watchRestartHealthThreshold
+	reporter := &fakeHealthReporter{}
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithoutAutostart(), WithHealthReporter(reporter))
+	require.NoError(t, err)
+	t.Cleanup(func() { allocator.Delete() })
+
+	// Each failed ListAndWatch terminates the watch unexpectedly. Restart
+	// it as the remote cache watcher would.
+	for range watchRestartHealthThreshold - 1 {
+		allocator.mainCache.start()
+		<-allocator.mainCache.watchDone
+	}
+	require.False(t, reporter.isDegraded())
+
+	allocator.mainCache.start()
+	<-allocator.mainCache.watchDone
+	require.True(t, reporter.isDegraded())
+	require.Equal(t, healthReasonWatchFlapping, reporter.lastReason())
+
+	// The next watch succeeds; once it has stayed up long enough, the
+	// restart streak is forgotten and health recovers.
+	listDone := allocator.mainCache.start()
+	<-listDone
+	require.Eventually(t, reporter.isOK, 5*time.Second, 5*time.Millisecond)
+}
+
+func TestIsRetryable(t *testing.T) {
+	require.False(t, IsRetryable(nil))
+	require.False(t, IsRetryable(context.Canceled))
+	require.False(t, IsRetryable(fmt.Errorf("lookup failed: %w", context.DeadlineExceeded)))
+	require.True(t, IsRetryable(errors.New("connection refused")))
+	require.True(t, IsRetryable(RetryableError{Err: context.Canceled}))
+	require.True(t, IsRetryable(fmt.Errorf("lookup failed: %w", RetryableError{Err: context.Canceled})))
+}
+
+func TestAllocateNonRetryableError(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithMaxAllocAttempts(3))
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+
+	// A backend error wrapping context cancellation fails the allocation on
+	// the first attempt, without going through the backoff.
+	var attempts atomic.Int32
+	backend.mutex.Lock()
+	backend.getHandler = func(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
+		attempts.Add(1)
+		return idpool.NoID, fmt.Errorf("lookup failed: %w", context.Canceled)
+	}
+	backend.mutex.Unlock()
+
+	_, _, _, err = allocator.Allocate(context.Background(), TestAllocatorKey("key1"))
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, int32(1), attempts.Load())
+
+	// Wrapping the same error in a RetryableError keeps the retry loop going
+	// until the attempts are exhausted.
+	attempts.Store(0)
+	backend.mutex.Lock()
+	backend.getHandler = func(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
+		attempts.Add(1)
+		return idpool.NoID, RetryableError{Err: fmt.Errorf("lookup failed: %w", context.Canceled)}
+	}
+	backend.mutex.Unlock()
+
+	_, _, _, err = allocator.Allocate(context.Background(), TestAllocatorKey("key2"))
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, int32(3), attempts.Load())
+}
+
+func TestBackendOpTimeout(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithMaxAllocAttempts(3),
+		WithBackendOpTimeout(20*time.Millisecond))
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+	<-allocator.initialListDone
+
+	// A backend that stops answering consumes only the per-operation timeout
+	// of each attempt, leaving the remaining budget of the caller context to
+	// the retries.
+	var attempts atomic.Int32
+	backend.mutex.Lock()
+	backend.getHandler = func(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
+		attempts.Add(1)
+		<-ctx.Done()
+		return idpool.NoID, ctx.Err()
+	}
+	backend.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, _, _, err = allocator.Allocate(ctx, TestAllocatorKey("slow"))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, int32(3), attempts.Load())
+	require.NoError(t, ctx.Err())
+
+	// Once the backend answers again, allocation succeeds within the same
+	// caller context.
+	backend.mutex.Lock()
+	backend.getHandler = nil
+	backend.mutex.Unlock()
+
+	_, isNew, _, err := allocator.Allocate(ctx, TestAllocatorKey("slow"))
+	require.NoError(t, err)
+	require.True(t, isNew)
+}
+
+func TestSetLogger(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+
+	old := allocator.getLogger()
+	logger := hivetest.Logger(t)
+	allocator.SetLogger(logger)
+	require.NotSame(t, old, allocator.getLogger())
+	require.Same(t, logger, allocator.localKeys.getLogger())
+
+	// Operations after the swap log through the new logger.
+	_, _, _, err = allocator.Allocate(context.Background(), TestAllocatorKey("key1"))
+	require.NoError(t, err)
+}
+
+func TestAllocatorMetrics(t *testing.T) {
+	backend := newDummyBackend()
+	m := newMockMetrics()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithMaxAllocAttempts(2), WithMetrics(m))
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+
+	key := TestAllocatorKey("foo")
+
+	// allocating a new global key
+	_, isNew, firstUse, err := allocator.Allocate(context.Background(), key)
+	require.NoError(t, err)
+	require.True(t, isNew)
+	require.True(t, firstUse)
+	require.Equal(t, 1, m.allocationCount(outcomeNewValue))
+	require.Equal(t, 1, m.durations)
+	require.Equal(t, []int{1}, m.attempts)
+	require.Equal(t, 1, m.cacheSize)
+	require.Equal(t, 1, m.localKeys)
+	require.Equal(t, 255, m.availableIDs)
+
+	// taking a second reference is a local reuse
+	_, isNew, firstUse, err = allocator.Allocate(context.Background(), key)
+	require.NoError(t, err)
+	require.False(t, isNew)
+	require.False(t, firstUse)
+	require.Equal(t, 1, m.allocationCount(outcomeReusedLocalValue))
+
+	// taking the first reference to a key already present in the backend is
+	// a global reuse
+	m2 := newMockMetrics()
+	allocator2, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithMetrics(m2))
+	require.NoError(t, err)
+	t.Cleanup(allocator2.Delete)
+
+	_, isNew, firstUse, err = allocator2.Allocate(context.Background(), key)
+	require.NoError(t, err)
+	require.False(t, isNew)
+	require.True(t, firstUse)
+	require.Equal(t, 1, m2.allocationCount(outcomeReusedGlobalValue))
+	require.Equal(t, 1, m2.localKeys)
+
+	// a backend failure is counted once, with the attempts used
+	backend.mutex.Lock()
+	backend.getHandler = func(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
+		return idpool.NoID, fmt.Errorf("lookup failed: %w", context.Canceled)
+	}
+	backend.mutex.Unlock()
+
+	_, _, _, err = allocator.Allocate(context.Background(), TestAllocatorKey("bar"))
+	require.Error(t, err)
+	require.Equal(t, 1, m.allocationCount(outcomeFailedValue))
+	require.Equal(t, []int{1, 1, 1}, m.attempts)
+
+	backend.mutex.Lock()
+	backend.getHandler = nil
+	backend.mutex.Unlock()
+
+	// releasing references keeps the local keys gauge in sync
+	_, err = allocator.Release(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, 1, m.localKeys)
+	_, err = allocator.Release(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, 0, m.localKeys)
+}
+
+func TestAllocateHooks(t *testing.T) {
+	backend := newDummyBackend()
+
+	type postCall struct {
+		key   AllocatorKey
+		id    idpool.ID
+		isNew bool
+		err   error
+	}
+	var (
+		allocator *Allocator
+		preCalls  []AllocatorKey
+		postCalls []postCall
+	)
+	pre := func(key AllocatorKey) {
+		preCalls = append(preCalls, key)
+	}
+	post := func(key AllocatorKey, id idpool.ID, isNew bool, err error) {
+		// The hook must be called without the slave keys mutex held.
+		allocator.slaveKeysMutex.Lock()
+		allocator.slaveKeysMutex.Unlock()
+		postCalls = append(postCalls, postCall{key: key, id: id, isNew: isNew, err: err})
+	}
+
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithMaxAllocAttempts(2),
+		WithPreAllocateHook(pre), WithPostAllocateHook(post))
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+
+	key := TestAllocatorKey("foo")
+	id, isNew, _, err := allocator.Allocate(context.Background(), key)
+	require.NoError(t, err)
+	require.True(t, isNew)
+	require.Equal(t, []AllocatorKey{key}, preCalls)
+	require.Equal(t, []postCall{{key: key, id: id, isNew: true}}, postCalls)
+
+	// Reusing the local key does not hit the kvstore, so the hooks are not
+	// invoked.
+	_, _, _, err = allocator.Allocate(context.Background(), key)
+	require.NoError(t, err)
+	require.Len(t, preCalls, 1)
+	require.Len(t, postCalls, 1)
+
+	// A failed attempt is reported to the post-allocation hook.
+	backend.mutex.Lock()
+	backend.getHandler = func(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
+		return idpool.NoID, fmt.Errorf("lookup failed: %w", context.Canceled)
+	}
+	backend.mutex.Unlock()
+
+	other := TestAllocatorKey("bar")
+	_, _, _, err = allocator.Allocate(context.Background(), other)
+	require.Error(t, err)
+	require.Equal(t, []AllocatorKey{key, other}, preCalls)
+	require.Len(t, postCalls, 2)
+	require.Equal(t, other, postCalls[1].key)
+	require.ErrorIs(t, postCalls[1].err, context.Canceled)
+}
+
+func TestTryAllocate(t *testing.T) {
+	// While the initial key list has not been received, TryAllocate fails
+	// fast instead of waiting for it.
+	blockedBackend := newDummyBackend()
+	blockedBackend.disableListDone = true
+	blocked, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), blockedBackend, WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	t.Cleanup(blocked.Delete)
+
+	_, _, _, err = blocked.TryAllocate(context.Background(), TestAllocatorKey("key1"))
+	require.ErrorIs(t, err, ErrWouldBlock)
+
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+	<-allocator.initialListDone
+
+	key := TestAllocatorKey("key1")
+	id, isNew, firstUse, err := allocator.TryAllocate(context.Background(), key)
+	require.NoError(t, err)
+	require.NotEqual(t, idpool.NoID, id)
+	require.True(t, isNew)
+	require.True(t, firstUse)
+
+	// With the backend failing, a single attempt is made for unknown keys,
+	// while keys in local use are still served from the warm cache.
+	var attempts atomic.Int32
+	backend.mutex.Lock()
+	backend.getHandler = func(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
+		attempts.Add(1)
+		return idpool.NoID, errors.New("connection refused")
+	}
+	backend.mutex.Unlock()
+
+	cachedID, isNew, firstUse, err := allocator.TryAllocate(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, id, cachedID)
+	require.False(t, isNew)
+	require.False(t, firstUse)
+	require.Equal(t, int32(0), attempts.Load())
+
+	_, _, _, err = allocator.TryAllocate(context.Background(), TestAllocatorKey("key2"))
+	require.ErrorIs(t, err, ErrBackendUnavailable)
+	require.Equal(t, int32(1), attempts.Load())
+}
+
+type waitForIDResult struct {
+	id  idpool.ID
+	err error
+}
+
+func TestWaitForID(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+	<-allocator.initialListDone
+
+	numWaiters := func() int {
+		allocator.mainCache.mutex.RLock()
+		defer allocator.mainCache.mutex.RUnlock()
+		return len(allocator.mainCache.waiters)
+	}
+
+	// Keys already present in the main cache are returned without waiting.
+	key := TestAllocatorKey("key1")
+	id, _, _, err := allocator.Allocate(context.Background(), key)
+	require.NoError(t, err)
+
+	got, err := allocator.WaitForID(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, id, got)
+	require.Equal(t, 0, numWaiters())
+
+	// A waiter for an unknown key wakes up once the key is delivered
+	// through the cache mutation handler.
+	done := make(chan waitForIDResult)
+	go func() {
+		id, err := allocator.WaitForID(context.Background(), TestAllocatorKey("key2"))
+		done <- waitForIDResult{id, err}
+	}()
+
+	require.Eventually(t, func() bool { return numWaiters() == 1 }, time.Second, time.Millisecond)
+
+	_, err = backend.AllocateID(context.Background(), idpool.ID(42), TestAllocatorKey("key2"))
+	require.NoError(t, err)
+
+	res := <-done
+	require.NoError(t, res.err)
+	require.Equal(t, idpool.ID(42), res.id)
+	require.Equal(t, 0, numWaiters())
+
+	// Cancelling the context aborts the wait and drops the registration.
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		id, err := allocator.WaitForID(ctx, TestAllocatorKey("key3"))
+		done <- waitForIDResult{id, err}
+	}()
+
+	require.Eventually(t, func() bool { return numWaiters() == 1 }, time.Second, time.Millisecond)
+	cancel()
+
+	res = <-done
+	require.ErrorIs(t, res.err, context.Canceled)
+	require.Equal(t, 0, numWaiters())
+}
+
+func TestWaitForIDRemoteCaches(t *testing.T) {
+	var wg sync.WaitGroup
+
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithWaitForIDIncludeRemoteCaches())
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+	<-allocator.initialListDone
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	remoteBackend := newDummyBackend()
+	remote, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), remoteBackend, WithoutAutostart(), WithoutGC())
+	require.NoError(t, err)
+
+	rc := allocator.NewRemoteCache("remote", remote)
+	wg.Add(1)
+	go func() {
+		rc.Watch(ctx, func(context.Context) {})
+		wg.Done()
+	}()
+
+	require.Eventually(t, func() bool {
+		allocator.remoteCachesMutex.RLock()
+		defer allocator.remoteCachesMutex.RUnlock()
+		return len(allocator.remoteCaches) == 1
+	}, time.Second, time.Millisecond)
+
+	done := make(chan waitForIDResult)
+	go func() {
+		id, err := allocator.WaitForID(context.Background(), TestAllocatorKey("remote-key"))
+		done <- waitForIDResult{id, err}
+	}()
+
+	require.Eventually(t, func() bool {
+		remote.mainCache.mutex.RLock()
+		defer remote.mainCache.mutex.RUnlock()
+		return len(remote.mainCache.waiters) == 1
+	}, time.Second, time.Millisecond)
+
+	// The upsert arriving via the remote cache wakes the waiter up.
+	_, err = remoteBackend.AllocateID(ctx, idpool.ID(7), TestAllocatorKey("remote-key"))
+	require.NoError(t, err)
+
+	res := <-done
+	require.NoError(t, res.err)
+	require.Equal(t, idpool.ID(7), res.id)
+
+	// The registration on the main cache has been dropped again.
+	allocator.mainCache.mutex.RLock()
+	defer allocator.mainCache.mutex.RUnlock()
+	require.Empty(t, allocator.mainCache.waiters)
+}
+
+func TestAllocateOperatorIDManagement(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithOperatorIDManagement())
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+
+	// Allocate blocks on the main cache until the identity created by
+	// cilium-operator arrives through the watcher, without polling the
+	// backend.
+	done := make(chan waitForIDResult)
+	var isNew, firstUse bool
+	go func() {
+		var id idpool.ID
+		var err error
+		id, isNew, firstUse, err = allocator.Allocate(context.Background(), TestAllocatorKey("key1"))
+		done <- waitForIDResult{id, err}
+	}()
+
+	require.Eventually(t, func() bool {
+		allocator.mainCache.mutex.RLock()
+		defer allocator.mainCache.mutex.RUnlock()
+		return len(allocator.mainCache.waiters) == 1
+	}, time.Second, time.Millisecond)
+
+	_, err = backend.AllocateID(context.Background(), idpool.ID(100), TestAllocatorKey("key1"))
+	require.NoError(t, err)
+
+	res := <-done
+	require.NoError(t, res.err)
+	require.Equal(t, idpool.ID(100), res.id)
+	require.False(t, isNew)
+	require.False(t, firstUse)
+}
+
+func TestAllocateWithID(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+	<-allocator.initialListDone
+
+	// Reclaiming an unused ID recreates the master key at exactly that ID.
+	key := TestAllocatorKey("restored")
+	available := allocator.idPool.AvailableIDs()
+	isNew, err := allocator.AllocateWithID(context.Background(), key, idpool.ID(17))
+	require.NoError(t, err)
+	require.True(t, isNew)
+
+	backend.mutex.RLock()
+	require.Equal(t, key, backend.masterKeys[idpool.ID(17)])
+	backend.mutex.RUnlock()
+
+	id, err := allocator.Get(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, idpool.ID(17), id)
+
+	// The reclaimed ID is no longer handed out by the local pool.
+	require.Equal(t, available-1, allocator.idPool.AvailableIDs())
+
+	// Reclaiming again only takes another reference on the existing key.
+	isNew, err = allocator.AllocateWithID(context.Background(), key, idpool.ID(17))
+	require.NoError(t, err)
+	require.False(t, isNew)
+	require.Equal(t, uint64(2), allocator.localKeys.keys[key.GetKey()].refcnt)
+
+	// An ID held by a different key cannot be reclaimed.
+	otherKey := TestAllocatorKey("other")
+	otherID, _, _, err := allocator.Allocate(context.Background(), otherKey)
+	require.NoError(t, err)
+
+	var conflict IDConflictError
+	_, err = allocator.AllocateWithID(context.Background(), TestAllocatorKey("intruder"), otherID)
+	require.ErrorAs(t, err, &conflict)
+	require.Equal(t, otherID, conflict.ID)
+	require.Equal(t, otherKey, conflict.ExistingKey)
+
+	// A key that is already associated with a different ID cannot be moved.
+	// Pick a target ID that is guaranteed to differ from the randomly
+	// allocated otherID.
+	moveID := idpool.ID(99)
+	if otherID == moveID {
+		moveID = idpool.ID(98)
+	}
+	conflict = IDConflictError{}
+	_, err = allocator.AllocateWithID(context.Background(), otherKey, moveID)
+	require.ErrorAs(t, err, &conflict)
+	require.Equal(t, otherID, conflict.ExistingID)
+	require.Nil(t, conflict.ExistingKey)
+}
+
+func TestTypedAllocationErrors(t *testing.T) {
+	// Exhausting the two available IDs surfaces ErrIDSpaceExhausted through
+	// the wrapping of the retry loop.
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(2)), WithoutGC(), WithMaxAllocAttempts(1))
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+
+	for i := range 2 {
+		_, _, _, err := allocator.Allocate(context.Background(), TestAllocatorKey(fmt.Sprintf("key%d", i)))
+		require.NoError(t, err)
+	}
+
+	_, _, _, err = allocator.Allocate(context.Background(), TestAllocatorKey("key3"))
+	require.ErrorIs(t, err, ErrIDSpaceExhausted)
+
+	// A master key appearing between the initial lookup and the conditional
+	// create is reported as ErrKeyConflict.
+	backend = newDummyBackend()
+	allocator, err = NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithMaxAllocAttempts(1))
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+	<-allocator.initialListDone
+
+	var lookups atomic.Int32
+	backend.mutex.Lock()
+	backend.getHandler = func(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
+		if lookups.Add(1) == 1 {
+			return idpool.NoID, nil
+		}
+		return idpool.ID(5), nil
+	}
+	backend.mutex.Unlock()
+
+	_, _, _, err = allocator.Allocate(context.Background(), TestAllocatorKey("contended"))
+	require.ErrorIs(t, err, ErrKeyConflict)
+
+	// In operator-managed mode, giving up on the backoff wait surfaces
+	// ErrBackendTimeout.
+	backend = newDummyBackend()
+	allocator, err = NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithOperatorIDManagement(), WithoutAutostart())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = allocator.GetWithRetry(ctx, TestAllocatorKey("missing"))
+	require.ErrorIs(t, err, ErrBackendTimeout)
+}
+
+func TestVerifyConsistency(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+
+	keys := make([]AllocatorKey, 3)
+	ids := make([]idpool.ID, 3)
+	for i := range keys {
+		keys[i] = TestAllocatorKey(fmt.Sprintf("key%d", i))
+		id, _, _, err := allocator.Allocate(context.Background(), keys[i])
+		require.NoError(t, err)
+		ids[i] = id
+	}
+
+	// A consistent state yields no findings.
+	inconsistencies, err := allocator.VerifyConsistency(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, inconsistencies)
+
+	// Remove the master key of the first ID, remap the second ID to another
+	// key, and let the forward lookup of the third key resolve to a
+	// different ID.
+	backend.mutex.Lock()
+	delete(backend.masterKeys, ids[0])
+	backend.masterKeys[ids[1]] = TestAllocatorKey("hijacked")
+	backend.getHandler = func(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
+		if key.GetKey() == keys[2].GetKey() {
+			return ids[2] + 1, nil
+		}
+		return idpool.NoID, nil
+	}
+	backend.mutex.Unlock()
+
+	inconsistencies, err = allocator.VerifyConsistency(context.Background())
+	require.NoError(t, err)
+	require.Len(t, inconsistencies, 3)
+
+	byID := map[idpool.ID]ConsistencyError{}
+	for _, c := range inconsistencies {
+		byID[c.ID] = c
+	}
+	for i, id := range ids {
+		require.Equal(t, keys[i], byID[id].LocalKey)
+	}
+
+	require.Equal(t, ConsistencyErrorMissingMaster, byID[ids[0]].ErrorType)
+	require.Nil(t, byID[ids[0]].BackendKey)
+
+	require.Equal(t, ConsistencyErrorKeyMismatch, byID[ids[1]].ErrorType)
+	require.Equal(t, TestAllocatorKey("hijacked"), byID[ids[1]].BackendKey)
+
+	require.Equal(t, ConsistencyErrorReverseMismatch, byID[ids[2]].ErrorType)
+	require.Equal(t, keys[2], byID[ids[2]].BackendKey)
+
+	// A failing backend lookup aborts the verification.
+	backend.mutex.Lock()
+	backend.getHandler = func(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
+		return idpool.NoID, errors.New("connection refused")
+	}
+	backend.mutex.Unlock()
+
+	_, err = allocator.VerifyConsistency(context.Background())
+	require.Error(t, err)
+}
+
+func TestLeasedIDLeakDetection(t *testing.T) {
+	backend := newDummyBackend()
+	m := newMockMetrics()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(16)), WithoutGC(), WithMaxAllocAttempts(1), WithMetrics(m))
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+	<-allocator.initialListDone
+
+	// Any lease still pending by the time of a check counts as leaked.
+	allocator.leakedLeaseThreshold = 0
+
+	// An allocation that fails after the ID was leased releases the lease
+	// again and must not be reported as a leak, no matter how old it is.
+	backend.mutex.Lock()
+	backend.getHandler = func(ctx context.Context, key AllocatorKey) (idpool.ID, error) {
+		return idpool.NoID, nil
+	}
+	for id := idpool.ID(1); id <= 16; id++ {
+		backend.masterKeys[id] = TestAllocatorKey("occupied")
+	}
+	backend.mutex.Unlock()
+
+	_, _, _, err = allocator.Allocate(context.Background(), TestAllocatorKey("failing"))
+	require.Error(t, err)
+
+	allocator.checkLeasedIDLeaks()
+	require.Equal(t, 0, m.leakedLeaseCount())
+
+	backend.mutex.Lock()
+	backend.getHandler = nil
+	backend.masterKeys = map[idpool.ID]AllocatorKey{}
+	backend.mutex.Unlock()
+
+	// Simulate an error path that forgot to call Release: the ID is leased
+	// from the pool but allocation never completes.
+	id, _, unmaskedID := allocator.selectAvailableID()
+	require.NotEqual(t, idpool.NoID, id)
+
+	available, leased, used := allocator.IDPoolStats()
+	require.Equal(t, uint64(15), available)
+	require.Equal(t, uint64(1), leased)
+	require.Equal(t, uint64(0), used)
+
+	allocator.checkLeasedIDLeaks()
+	require.Equal(t, 1, m.leakedLeaseCount())
+
+	// Releasing the lease clears the report on the next check.
+	allocator.releaseLeasedID(unmaskedID)
+	allocator.checkLeasedIDLeaks()
+	require.Equal(t, 0, m.leakedLeaseCount())
+
+	// A completed allocation moves the ID from leased to used.
+	_, _, _, err = allocator.Allocate(context.Background(), TestAllocatorKey("completed"))
+	require.NoError(t, err)
+
+	available, leased, used = allocator.IDPoolStats()
+	require.Equal(t, uint64(15), available)
+	require.Equal(t, uint64(0), leased)
+	require.Equal(t, uint64(1), used)
+
+	allocator.checkLeasedIDLeaks()
+	require.Equal(t, 0, m.leakedLeaseCount())
+}
+
+func TestReleaseBatch(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+
+	keys := make([]AllocatorKey, 0, 4)
+	for i := range 4 {
+		key := TestAllocatorKey(fmt.Sprintf("key%04d", i))
+		_, _, _, err := allocator.Allocate(context.Background(), key)
+		require.NoError(t, err)
+		keys = append(keys, key)
+	}
+
+	// take a second reference on the first two keys
+	for _, key := range keys[:2] {
+		_, _, _, err := allocator.Allocate(context.Background(), key)
+		require.NoError(t, err)
+	}
+
+	errs := allocator.ReleaseBatch(context.Background(), keys)
+	require.Nil(t, errs)
+
+	slaveKeyHeld := func(key AllocatorKey) bool {
+		backend.mutex.RLock()
+		defer backend.mutex.RUnlock()
+		for _, k := range backend.slaveKeys {
+			if k.GetKey() == key.GetKey() {
+				return true
+			}
+		}
+		return false
+	}
+
+	// the keys with remaining references are still held locally and in the
+	// backend
+	for _, key := range keys[:2] {
+		require.Equal(t, uint64(1), allocator.localKeys.keys[key.GetKey()].refcnt)
+		require.True(t, slaveKeyHeld(key))
+	}
+
+	// the last use of the other keys was released, removing their slave keys
+	for _, key := range keys[2:] {
+		require.NotContains(t, allocator.localKeys.keys, key.GetKey())
+		require.False(t, slaveKeyHeld(key))
+	}
+
+	// an unknown key yields a per-key error, without preventing the release
+	// of the other keys in the batch
+	errs = allocator.ReleaseBatch(context.Background(), []AllocatorKey{keys[0], TestAllocatorKey("unknown")})
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs["unknown"], ErrNotAllocatedLocally)
+	require.NotContains(t, allocator.localKeys.keys, keys[0].GetKey())
+	require.False(t, slaveKeyHeld(keys[0]))
+}
+
+func TestBulkRelease(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+
+	keys := make([]AllocatorKey, 0, 4)
+	for i := range 4 {
+		key := TestAllocatorKey(fmt.Sprintf("key%04d", i))
+		_, _, _, err := allocator.Allocate(context.Background(), key)
+		require.NoError(t, err)
+		keys = append(keys, key)
+	}
+
+	// take a second reference on the first two keys
+	for _, key := range keys[:2] {
+		_, _, _, err := allocator.Allocate(context.Background(), key)
+		require.NoError(t, err)
+	}
+
+	lastUses, err := allocator.BulkRelease(context.Background(), keys)
+	require.NoError(t, err)
+	require.Len(t, lastUses, 4)
+
+	// the keys with remaining references are reported as still in use, the
+	// others as last use
+	for _, key := range keys[:2] {
+		require.False(t, lastUses[key])
+		require.Equal(t, uint64(1), allocator.localKeys.keys[key.GetKey()].refcnt)
+	}
+	for _, key := range keys[2:] {
+		require.True(t, lastUses[key])
+		require.NotContains(t, allocator.localKeys.keys, key.GetKey())
+	}
+
+	// an unknown key surfaces in the joined error, without preventing the
+	// release of the other keys in the batch
+	lastUses, err = allocator.BulkRelease(context.Background(), []AllocatorKey{keys[0], TestAllocatorKey("unknown")})
+	require.ErrorIs(t, err, ErrNotAllocatedLocally)
+	require.Len(t, lastUses, 1)
+	require.True(t, lastUses[keys[0]])
+	require.NotContains(t, allocator.localKeys.keys, keys[0].GetKey())
+}
+
+func TestReleaseNotAllocatedLocally(t *testing.T) {
+	backend := newDummyBackend()
+	metrics := newMockMetrics()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC(), WithMetrics(metrics))
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+
+	ctx := context.Background()
+	key := TestAllocatorKey("foo")
+
+	// Releasing a key that was never allocated locally returns the typed
+	// error and is counted.
+	lastUse, err := allocator.Release(ctx, key)
+	require.ErrorIs(t, err, ErrNotAllocatedLocally)
+	require.False(t, lastUse)
+	require.Equal(t, 1, metrics.invalidReleaseCount())
+
+	// A normal last-use release succeeds as usual.
+	_, _, _, err = allocator.Allocate(ctx, key)
+	require.NoError(t, err)
+	lastUse, err = allocator.Release(ctx, key)
+	require.NoError(t, err)
+	require.True(t, lastUse)
+	require.Equal(t, 1, metrics.invalidReleaseCount())
+
+	// A second release of the same key is a double release.
+	lastUse, err = allocator.Release(ctx, key)
+	require.ErrorIs(t, err, ErrNotAllocatedLocally)
+	require.False(t, lastUse)
+	require.Equal(t, 2, metrics.invalidReleaseCount())
+}
+
+func TestReleaseAllLocal(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	t.Cleanup(allocator.Delete)
+
+	numKeys := 32
+	for i := range numKeys {
+		key := TestAllocatorKey(fmt.Sprintf("key%04d", i))
+		for range 2 {
+			_, _, _, err := allocator.Allocate(context.Background(), key)
+			require.NoError(t, err)
+		}
+	}
+
+	// single key releases racing with the bulk path must not corrupt the
+	// local state, regardless of which side drops the key
+	var wg sync.WaitGroup
+	for i := range numKeys / 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			allocator.Release(context.Background(), TestAllocatorKey(fmt.Sprintf("key%04d", i)))
+		}()
+	}
+
+	errs := allocator.ReleaseAllLocal(context.Background())
+	require.Nil(t, errs)
+	wg.Wait()
+
+	// all local references and all slave keys are gone
+	allocator.localKeys.RLock()
+	require.Empty(t, allocator.localKeys.keys)
+	require.Empty(t, allocator.localKeys.ids)
+	allocator.localKeys.RUnlock()
+
+	backend.mutex.RLock()
+	require.Empty(t, backend.slaveKeys)
+	backend.mutex.RUnlock()
+}
+
+func TestSyncLocalKeys(t *testing.T) {
+	numIDs := idpool.ID(3)
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMax(numIDs))
+	require.NoError(t, err)
+	require.NotNil(t, allocator)
+
+	var ids []idpool.ID
+
+	// allocate IDs
+	for i := idpool.ID(1); i <= numIDs; i++ {
+		key := TestAllocatorKey(fmt.Sprintf("key-%04d", i))
+		id, _, _, err := allocator.Allocate(context.Background(), key)
+		require.NoError(t, err)
+		require.NotEqual(t, idpool.NoID, id)
+		ids = append(ids, id)
+
+		// Ensure id stored in backend is the same
+		backendID, err := backend.Get(context.TODO(), key)
+		require.NoError(t, err)
+		require.Equal(t, backendID, id)
+	}
+
+	allocator.syncLocalKeys()
+
+	// Release the use one id/delete the slave key
+	key, err := backend.GetByID(context.TODO(), ids[0])
+	require.NoError(t, err)
+	err = backend.Release(context.TODO(), ids[0], key)
+	require.NoError(t, err)
+
+	// Delete the master key of one ID
+	err = backend.DeleteID(context.TODO(), ids[1])
+	require.NoError(t, err)
+
+	// Delete both master and slave key for another ID
+	key, err = backend.GetByID(context.TODO(), ids[2])
+	require.NoError(t, err)
+	err = backend.Release(context.TODO(), ids[2], key)
+	require.NoError(t, err)
+	err = backend.DeleteID(context.TODO(), ids[2])
+	require.NoError(t, err)
+
+	allocator.syncLocalKeys()
+
+	// Ensure all IDs are present
+	for i := idpool.ID(1); i <= numIDs; i++ {
+		key := TestAllocatorKey(fmt.Sprintf("key-%04d", i))
+
+		// Ensure all slave keys are present via Get
+		backendID, err := backend.Get(context.TODO(), key)
+		require.NoError(t, err)
+		require.NotEqual(t, idpool.NoID, backendID)
+
+		// Ensure all master keys are present via GetById
+		backendKey, err := backend.GetByID(context.TODO(), backendID)
+		require.NoError(t, err)
+		require.Equal(t, key, backendKey)
+	}
+
+}
+
+func TestSyncLocalKeysWithIdentityAllocations(t *testing.T) {
+	numIDs := idpool.ID(500)
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMax(100*numIDs))
+	require.NoError(t, err)
+	require.NotNil(t, allocator)
+
 	allocateKeys := func(prefix string) func() {
 		// allocate IDs
 		for i := idpool.ID(1); i <= numIDs; i++ {
@@ -826,3 +2967,291 @@ func TestSyncLocalKeysWithIdentityAllocations(t *testing.T) {
 	// Ensure all slave keys are deleted, and non are leaked
 	assert.Empty(t, backend.slaveKeys)
 }
+
+func TestGetByIDBatch(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMin(idpool.ID(1)), WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	require.NotNil(t, allocator)
+	defer allocator.Delete()
+
+	ids := make([]idpool.ID, 0, 5)
+	for i := range 5 {
+		key := TestAllocatorKey(fmt.Sprintf("key%04d", i))
+		id, _, _, err := allocator.Allocate(context.Background(), key)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	// All IDs are served from the main cache.
+	keys, err := allocator.GetByIDBatch(context.Background(), ids)
+	require.NoError(t, err)
+	require.Len(t, keys, 5)
+	for i, id := range ids {
+		require.Equal(t, TestAllocatorKey(fmt.Sprintf("key%04d", i)), keys[id])
+	}
+
+	// Cache misses fall through to the backend; unknown IDs are omitted.
+	unknownID := idpool.ID(4096)
+	allocator.mainCache.mutex.Lock()
+	delete(allocator.mainCache.cache, ids[0])
+	allocator.mainCache.mutex.Unlock()
+
+	keys, err = allocator.GetByIDBatch(context.Background(), append([]idpool.ID{unknownID}, ids...))
+	require.NoError(t, err)
+	require.Len(t, keys, 5)
+	require.Equal(t, TestAllocatorKey("key0000"), keys[ids[0]])
+	require.NotContains(t, keys, unknownID)
+}
+
+func TestForeachCacheByIDRange(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMin(idpool.ID(1)), WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	require.NotNil(t, allocator)
+	defer allocator.Delete()
+
+	ids := make([]idpool.ID, 0, 10)
+	for i := range 10 {
+		key := TestAllocatorKey(fmt.Sprintf("key%04d", i))
+		id, _, _, err := allocator.Allocate(context.Background(), key)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	// Iterate the range spanned by all but the smallest and largest
+	// allocated ID; exactly the entries within the bounds are visited.
+	slices.Sort(ids)
+	minID, maxID := ids[1], ids[len(ids)-2]
+	seen := map[idpool.ID]AllocatorKey{}
+	allocator.ForeachCacheByIDRange(minID, maxID, func(id idpool.ID, key AllocatorKey) {
+		seen[id] = key
+	})
+	require.Len(t, seen, len(ids)-2)
+	for id := range seen {
+		require.GreaterOrEqual(t, id, minID)
+		require.LessOrEqual(t, id, maxID)
+	}
+
+	// The full range matches ForeachCache.
+	all := map[idpool.ID]AllocatorKey{}
+	allocator.ForeachCache(func(id idpool.ID, key AllocatorKey) {
+		all[id] = key
+	})
+	ranged := map[idpool.ID]AllocatorKey{}
+	allocator.ForeachCacheByIDRange(idpool.ID(1), idpool.ID(256), func(id idpool.ID, key AllocatorKey) {
+		ranged[id] = key
+	})
+	require.Equal(t, all, ranged)
+}
+
+func TestGetAllLocalKeys(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	require.NotNil(t, allocator)
+	defer allocator.Delete()
+
+	fooID, _, _, err := allocator.Allocate(context.Background(), TestAllocatorKey("foo"))
+	require.NoError(t, err)
+	barID, _, _, err := allocator.Allocate(context.Background(), TestAllocatorKey("bar"))
+	require.NoError(t, err)
+
+	// Keys allocated by other nodes show up in the cache but are not held
+	// locally.
+	allocator.mainCache.OnUpsert(idpool.ID(1000), TestAllocatorKey("baz"))
+
+	keys := allocator.GetAllLocalKeys()
+	require.Equal(t, map[string]idpool.ID{"foo": fooID, "bar": barID}, keys)
+
+	// The returned map is a snapshot detached from the allocator state.
+	_, err = allocator.Release(context.Background(), TestAllocatorKey("bar"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]idpool.ID{"foo": fooID, "bar": barID}, keys)
+	require.Equal(t, map[string]idpool.ID{"foo": fooID}, allocator.GetAllLocalKeys())
+}
+
+func TestDumpRemoteCaches(t *testing.T) {
+	var wg sync.WaitGroup
+
+	global := Allocator{metrics: noopMetrics{}, remoteCaches: make(map[string]*remoteCache)}
+	global.logger.Store(hivetest.Logger(t))
+	events := make(AllocatorEventChan, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	// No remote caches registered yet.
+	require.Empty(t, global.DumpRemoteCaches())
+
+	newRemote := func(name string, ids ...idpool.ID) RemoteIDCache {
+		backend := newDummyBackend()
+		remote, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithEvents(events), WithoutAutostart(), WithoutGC())
+		require.NoError(t, err)
+
+		for _, id := range ids {
+			backend.AllocateID(ctx, id, TestAllocatorKey(fmt.Sprintf("key-%d", id)))
+		}
+
+		rc := global.NewRemoteCache(name, remote)
+		wg.Add(1)
+		go func() {
+			rc.Watch(ctx, func(context.Context) {})
+			wg.Done()
+		}()
+		return rc
+	}
+
+	before := time.Now()
+	newRemote("cluster-b", idpool.ID(1), idpool.ID(2), idpool.ID(3))
+	newRemote("cluster-a", idpool.ID(4))
+
+	require.Eventually(t, func() bool {
+		global.remoteCachesMutex.RLock()
+		defer global.remoteCachesMutex.RUnlock()
+		return len(global.remoteCaches) == 2
+	}, 1*time.Second, 10*time.Millisecond)
+
+	summaries := global.DumpRemoteCaches()
+	require.Len(t, summaries, 2)
+
+	// Summaries are sorted by name.
+	require.Equal(t, "cluster-a", summaries[0].Name)
+	require.Equal(t, 1, summaries[0].NumEntries)
+	require.Equal(t, "cluster-b", summaries[1].Name)
+	require.Equal(t, 3, summaries[1].NumEntries)
+
+	for _, summary := range summaries {
+		require.True(t, summary.Synced, "cache %s should be synchronized", summary.Name)
+		require.False(t, summary.LastUpdated.Before(before), "cache %s should have been updated during the test", summary.Name)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	var wg sync.WaitGroup
+
+	backend := newDummyBackend()
+	global, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend,
+		WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	t.Cleanup(global.Delete)
+	<-global.initialListDone
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	// Two keys in local use, one of them holding two references.
+	idFoo, _, _, err := global.Allocate(ctx, TestAllocatorKey("foo"))
+	require.NoError(t, err)
+	idBar, _, _, err := global.Allocate(ctx, TestAllocatorKey("bar"))
+	require.NoError(t, err)
+	_, _, _, err = global.Allocate(ctx, TestAllocatorKey("bar"))
+	require.NoError(t, err)
+
+	// One remote cache with two entries.
+	remoteBackend := newDummyBackend()
+	remote, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), remoteBackend,
+		WithoutAutostart(), WithoutGC())
+	require.NoError(t, err)
+	remoteBackend.AllocateID(ctx, idpool.ID(300), TestAllocatorKey("remote-a"))
+	remoteBackend.AllocateID(ctx, idpool.ID(301), TestAllocatorKey("remote-b"))
+
+	rc := global.NewRemoteCache("cluster-1", remote)
+	wg.Add(1)
+	go func() {
+		rc.Watch(ctx, func(context.Context) {})
+		wg.Done()
+	}()
+	require.Eventually(t, func() bool {
+		return len(global.Snapshot(false).Entries) == 4
+	}, time.Second, 10*time.Millisecond)
+
+	// Entries of the main cache come first, sorted by ID, followed by the
+	// remote entries.
+	snapshot := global.Snapshot(false)
+	firstID, secondID := idFoo, idBar
+	if firstID > secondID {
+		firstID, secondID = secondID, firstID
+	}
+	require.Equal(t, []idpool.ID{firstID, secondID, idpool.ID(300), idpool.ID(301)},
+		[]idpool.ID{snapshot.Entries[0].ID, snapshot.Entries[1].ID, snapshot.Entries[2].ID, snapshot.Entries[3].ID})
+
+	byKey := map[string]AllocatorSnapshotEntry{}
+	for _, entry := range snapshot.Entries {
+		byKey[entry.Key] = entry
+	}
+	require.Equal(t, SnapshotSourceLocal, byKey["foo"].Source)
+	require.True(t, byKey["foo"].Verified)
+	require.Equal(t, "cluster-1", byKey["remote-a"].Source)
+	require.False(t, byKey["remote-a"].Verified)
+
+	// Reference counts are only included in verbose snapshots.
+	require.Zero(t, byKey["bar"].RefCount)
+
+	snapshot = global.Snapshot(true)
+	for _, entry := range snapshot.Entries {
+		byKey[entry.Key] = entry
+	}
+	require.Equal(t, uint64(1), byKey["foo"].RefCount)
+	require.Equal(t, uint64(2), byKey["bar"].RefCount)
+	require.Zero(t, byKey["remote-a"].RefCount)
+}
+
+func TestRemoteCachePriority(t *testing.T) {
+	var wg sync.WaitGroup
+
+	global := Allocator{metrics: noopMetrics{}, remoteCaches: make(map[string]*remoteCache)}
+	global.logger.Store(hivetest.Logger(t))
+	WithRemoteCachePriority("cluster-z", 1)(&global)
+	WithRemoteCachePriority("cluster-a", 2)(&global)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	// All remote caches know the same key, under a different ID each.
+	newRemote := func(name string, id idpool.ID) {
+		backend := newDummyBackend()
+		remote, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithoutAutostart(), WithoutGC())
+		require.NoError(t, err)
+
+		backend.AllocateID(ctx, id, TestAllocatorKey("shared"))
+
+		rc := global.NewRemoteCache(name, remote)
+		wg.Add(1)
+		go func() {
+			rc.Watch(ctx, func(context.Context) {})
+			wg.Done()
+		}()
+	}
+
+	newRemote("cluster-z", idpool.ID(10))
+	newRemote("cluster-a", idpool.ID(20))
+	newRemote("cluster-b", idpool.ID(30))
+
+	require.Eventually(t, func() bool {
+		global.remoteCachesMutex.RLock()
+		defer global.remoteCachesMutex.RUnlock()
+		return len(global.remoteCaches) == 3
+	}, 1*time.Second, 10*time.Millisecond)
+
+	// Caches are consulted ascending by priority, unprioritized ones last.
+	names := make([]string, 0, 3)
+	for _, rc := range global.remoteCachesByPriority() {
+		names = append(names, rc.name)
+	}
+	require.Equal(t, []string{"cluster-z", "cluster-a", "cluster-b"}, names)
+
+	// The key resolves to the ID known by the highest-priority cache.
+	id, err := global.GetIncludeRemoteCaches(ctx, TestAllocatorKey("shared"))
+	require.NoError(t, err)
+	require.Equal(t, idpool.ID(10), id)
+}
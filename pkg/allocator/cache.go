@@ -6,6 +6,7 @@ package allocator
 import (
 	"context"
 	"log/slog"
+	"slices"
 	"sync"
 
 	"github.com/cilium/stream"
@@ -58,8 +59,27 @@ type cache struct {
 	// nextKeyCache follows the same logic as nextCache but for keyCache
 	nextKeyCache keyMap
 
+	// lastUpdate is the time the cache last processed a list, upsert or
+	// delete event. Zero if no event has been processed yet.
+	lastUpdate time.Time
+
+	// waiters are channels of callers blocked in subscribeKeyWait, keyed
+	// by the key they are waiting for. They are notified and dropped when
+	// a matching upsert is observed.
+	waiters map[string][]chan idpool.ID
+
 	listDone waitChan
 
+	// watchDone is closed when the watch goroutine started by the last
+	// start() call has terminated, whether because the context was
+	// canceled or because the backend gave up on the watch.
+	watchDone waitChan
+
+	// watchCancel cancels only the watch goroutine started by the last
+	// start() call, leaving the cache context intact so that the watch can
+	// be restarted by the watchdog.
+	watchCancel context.CancelFunc
+
 	// stopWatchWg is a wait group that gets conditions added when a
 	// watcher is started with the conditions marked as done when the
 	// watcher has exited
@@ -73,7 +93,7 @@ type cache struct {
 func newCache(a *Allocator) (c cache) {
 	ctx, cancel := context.WithCancel(context.Background())
 	c = cache{
-		logger:      a.logger,
+		logger:      a.getLogger(),
 		allocator:   a,
 		cache:       idMap{},
 		keyCache:    keyMap{},
@@ -106,7 +126,34 @@ type CacheMutations interface {
 }
 
 func (c *cache) sendEvent(typ AllocatorChangeKind, id idpool.ID, key AllocatorKey) {
-	if events := c.allocator.events; events != nil {
+	events := c.allocator.events
+	if events == nil {
+		return
+	}
+
+	switch c.allocator.eventBufferingMode {
+	case EventBufferingDrop:
+		select {
+		case events <- AllocatorEvent{Typ: typ, ID: id, Key: key}:
+		default:
+			// The consumer is not keeping up. Drop the event instead of
+			// stalling the allocator; the consumer is expected to
+			// periodically reconcile its state.
+			c.allocator.eventDropped()
+			c.logger.Debug("Dropping allocator event, events channel is full",
+				logfields.Identity, id,
+				logfields.Event, typ,
+			)
+		}
+	case EventBufferingRing:
+		if c.allocator.eventBuffer.push(AllocatorEvent{Typ: typ, ID: id, Key: key}) {
+			c.allocator.eventDropped()
+			c.logger.Debug("Dropping oldest allocator event, ring buffer is full",
+				logfields.Identity, id,
+				logfields.Event, typ,
+			)
+		}
+	default:
 		events <- AllocatorEvent{Typ: typ, ID: id, Key: key}
 	}
 }
@@ -116,6 +163,7 @@ func (c *cache) OnListDone() {
 	// nextCache is valid, point the live cache to it
 	c.cache = c.nextCache
 	c.keyCache = c.nextKeyCache
+	c.lastUpdate = time.Now()
 	c.mutex.Unlock()
 
 	c.logger.Debug("Initial list of identities received")
@@ -126,17 +174,51 @@ func (c *cache) OnListDone() {
 	close(c.listDone)
 }
 
-func (c *cache) OnUpsert(id idpool.ID, key AllocatorKey) {
-	for _, validator := range c.allocator.cacheValidators {
-		if err := validator(AllocatorChangeUpsert, id, key); err != nil {
+// validateChange runs the registered cache validators against the given
+// change, and reports whether it may be applied. Rejections are counted,
+// logged with a rate limit and, if a sink was registered through
+// WithRejectedEventSink, forwarded there for diagnostics.
+func (c *cache) validateChange(kind AllocatorChangeKind, id idpool.ID, key AllocatorKey) bool {
+	a := c.allocator
+	if len(a.cacheValidators) == 0 {
+		return true
+	}
+
+	for _, validator := range a.cacheValidators {
+		err := validator(kind, id, key)
+		if err == nil {
+			continue
+		}
+
+		a.metrics.IncCacheValidation(outcomeRejectedValue, string(kind))
+
+		if a.validationWarnLimiter.Allow() {
 			c.logger.Warn(
 				"Skipping event for invalid identity",
 				logfields.Error, err,
 				logfields.Identity, id,
-				logfields.Event, AllocatorChangeUpsert,
+				logfields.Key, key,
+				logfields.Event, kind,
 			)
-			return
 		}
+
+		if a.rejectedEvents != nil {
+			select {
+			case a.rejectedEvents <- AllocatorEvent{Typ: kind, ID: id, Key: key}:
+			default:
+			}
+		}
+
+		return false
+	}
+
+	a.metrics.IncCacheValidation(outcomeAcceptedValue, string(kind))
+	return true
+}
+
+func (c *cache) OnUpsert(id idpool.ID, key AllocatorKey) {
+	if !c.validateChange(AllocatorChangeUpsert, id, key) {
+		return
 	}
 
 	c.mutex.Lock()
@@ -149,7 +231,20 @@ func (c *cache) OnUpsert(id idpool.ID, key AllocatorKey) {
 	c.nextCache[id] = key
 	if key != nil {
 		c.nextKeyCache[key.GetKey()] = id
+
+		if waiters, ok := c.waiters[key.GetKey()]; ok {
+			for _, ch := range waiters {
+				// Non-blocking: a waiter registered on multiple caches
+				// may already have been notified by another one.
+				select {
+				case ch <- id:
+				default:
+				}
+			}
+			delete(c.waiters, key.GetKey())
+		}
 	}
+	c.lastUpdate = time.Now()
 
 	c.allocator.idPool.Remove(id)
 
@@ -159,16 +254,8 @@ func (c *cache) OnUpsert(id idpool.ID, key AllocatorKey) {
 }
 
 func (c *cache) OnDelete(id idpool.ID, key AllocatorKey) {
-	for _, validator := range c.allocator.cacheValidators {
-		if err := validator(AllocatorChangeDelete, id, key); err != nil {
-			c.logger.Warn(
-				"Skipping event for invalid identity",
-				logfields.Error, err,
-				logfields.Identity, id,
-				logfields.Event, AllocatorChangeDelete,
-			)
-			return
-		}
+	if !c.validateChange(AllocatorChangeDelete, id, key) {
+		return
 	}
 
 	c.mutex.Lock()
@@ -239,6 +326,7 @@ func (c *cache) onDeleteLocked(id idpool.ID, key AllocatorKey, recreateMissingLo
 	}
 
 	delete(c.nextCache, id)
+	c.lastUpdate = time.Now()
 	a.idPool.Insert(id)
 
 	c.emitChange(AllocatorChange{Kind: AllocatorChangeDelete, ID: id, Key: key})
@@ -259,15 +347,60 @@ func (c *cache) start() waitChan {
 	c.mutex.Unlock()
 
 	c.stopWatchWg.Add(1)
+	watchDone := make(waitChan)
+	c.watchDone = watchDone
+
+	watchCtx, watchCancel := context.WithCancel(c.ctx)
+	c.watchCancel = watchCancel
 
 	go func() {
-		c.allocator.backend.ListAndWatch(c.ctx, c)
+		c.allocator.backend.ListAndWatch(watchCtx, c)
+		if watchCtx.Err() == nil {
+			// The watch terminated even though the allocator is not
+			// shutting down. Feed this into the health tracking; for
+			// remote caches the watcher is restarted by the owner.
+			c.allocator.observeWatchRestart()
+		}
 		c.stopWatchWg.Done()
+		close(watchDone)
 	}()
 
 	return c.listDone
 }
 
+// restartWatch tears down the watch goroutine started by the last start()
+// call and launches a new one, without touching the cache context, the change
+// source or the sync identity controllers. The live cache remains valid
+// throughout: it is only re-pointed once the new watch completes its initial
+// list.
+func (c *cache) restartWatch() {
+	c.watchCancel()
+	c.stopWatchWg.Wait()
+
+	// Do not start a new watch when the allocator is shutting down.
+	if c.ctx.Err() != nil {
+		return
+	}
+
+	oldListDone := c.listDone
+	newListDone := c.start()
+
+	// If the watch was restarted before the initial list completed, release
+	// the callers blocked on the previous listDone channel once the new
+	// watch completes its list.
+	select {
+	case <-oldListDone:
+	default:
+		go func() {
+			select {
+			case <-newListDone:
+				close(oldListDone)
+			case <-c.ctx.Done():
+			}
+		}()
+	}
+}
+
 func (c *cache) stop() {
 	c.cancel()
 	c.stopWatchWg.Wait()
@@ -292,9 +425,10 @@ func (c *cache) drain() {
 }
 
 // drainIf emits a deletion event for all known IDs that are stale according to
-// the isStale function. It must be called after the cache has been stopped, to
-// ensure that no new events can be received afterwards.
-func (c *cache) drainIf(isStale func(id idpool.ID) bool) {
+// the isStale function, and returns the number of drained entries. It must be
+// called after the cache has been stopped, to ensure that no new events can be
+// received afterwards.
+func (c *cache) drainIf(isStale func(id idpool.ID) bool) (drained int) {
 	// Make sure we wait until the watch loop has been properly stopped, otherwise
 	// new IDs might be added afterwards we complete the draining process.
 	c.stopWatchWg.Wait()
@@ -303,6 +437,7 @@ func (c *cache) drainIf(isStale func(id idpool.ID) bool) {
 	for id, key := range c.nextCache {
 		if isStale(id) {
 			c.onDeleteLocked(id, key, false)
+			drained++
 			c.logger.Debug(
 				"Stale identity deleted",
 				logfields.ID, id,
@@ -311,6 +446,7 @@ func (c *cache) drainIf(isStale func(id idpool.ID) bool) {
 		}
 	}
 	c.mutex.Unlock()
+	return drained
 }
 
 func (c *cache) get(key string) idpool.ID {
@@ -324,6 +460,41 @@ func (c *cache) get(key string) idpool.ID {
 	return idpool.NoID
 }
 
+// subscribeKeyWait registers ch to be notified with the ID of the first
+// upsert carrying 'key'. When the key is already present in the cache no
+// registration takes place and its ID is returned directly. Otherwise the
+// returned function must be called once the caller stops waiting, to drop
+// the registration again. ch must be buffered; notifications to a full
+// channel are dropped.
+func (c *cache) subscribeKeyWait(key string, ch chan idpool.ID) (idpool.ID, func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if id, ok := c.keyCache[key]; ok {
+		return id, nil
+	}
+	// The watcher may already have delivered the key while the initial
+	// list is still in progress.
+	if id, ok := c.nextKeyCache[key]; ok {
+		return id, nil
+	}
+
+	if c.waiters == nil {
+		c.waiters = map[string][]chan idpool.ID{}
+	}
+	c.waiters[key] = append(c.waiters[key], ch)
+
+	return idpool.NoID, func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		c.waiters[key] = slices.DeleteFunc(c.waiters[key], func(w chan idpool.ID) bool { return w == ch })
+		if len(c.waiters[key]) == 0 {
+			delete(c.waiters, key)
+		}
+	}
+}
+
 func (c *cache) getByID(id idpool.ID) AllocatorKey {
 	c.mutex.RLock()
 	if v, ok := c.cache[id]; ok {
@@ -335,6 +506,23 @@ func (c *cache) getByID(id idpool.ID) AllocatorKey {
 	return nil
 }
 
+// getByIDs looks up multiple IDs under a single lock acquisition, filling
+// 'keys' with the associations found and returning the IDs missing from the
+// cache.
+func (c *cache) getByIDs(ids []idpool.ID, keys map[idpool.ID]AllocatorKey) []idpool.ID {
+	var missing []idpool.ID
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	for _, id := range ids {
+		if v, ok := c.cache[id]; ok {
+			keys[id] = v
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
 func (c *cache) foreach(cb RangeFunc) {
 	c.mutex.RLock()
 	for k, v := range c.cache {
@@ -343,6 +531,29 @@ func (c *cache) foreach(cb RangeFunc) {
 	c.mutex.RUnlock()
 }
 
+// foreachByIDRange calls cb for each cached entry with an ID in the range
+// [min, max].
+func (c *cache) foreachByIDRange(minID, maxID idpool.ID, cb RangeFunc) {
+	c.mutex.RLock()
+	for k, v := range c.cache {
+		if k >= minID && k <= maxID {
+			cb(k, v)
+		}
+	}
+	c.mutex.RUnlock()
+}
+
+// insertLookupResult populates the cache with an association learned from a
+// direct backend lookup, subject to the registered cache validators. Unlike
+// OnUpsert it does not emit any change events; those are left to the watcher,
+// once it catches up with the backend.
+func (c *cache) insertLookupResult(id idpool.ID, key AllocatorKey) {
+	if !c.validateChange(AllocatorChangeUpsert, id, key) {
+		return
+	}
+	c.insert(key, id)
+}
+
 func (c *cache) insert(key AllocatorKey, val idpool.ID) {
 	c.mutex.Lock()
 	c.nextCache[val] = key
@@ -356,6 +567,12 @@ func (c *cache) numEntries() int {
 	return len(c.nextCache)
 }
 
+func (c *cache) getLastUpdate() time.Time {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.lastUpdate
+}
+
 type AllocatorChangeKind string
 
 const (
@@ -403,3 +620,18 @@ func (c *cache) Observe(ctx context.Context, next func(AllocatorChange), complet
 	}()
 
 }
+
+// replayCurrentState calls cb for each entry currently in the cache, followed
+// by a sync event, with the cache's read lock held for the whole replay. No
+// upsert or delete can interleave with the replay, so the callback observes a
+// consistent snapshot of the state.
+func (c *cache) replayCurrentState(cb func(AllocatorChange)) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	for id, key := range c.cache {
+		cb(AllocatorChange{Kind: AllocatorChangeUpsert, ID: id, Key: key})
+	}
+
+	cb(AllocatorChange{Kind: AllocatorChangeSync})
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package allocator
+
+import (
+	"time"
+
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/metrics"
+)
+
+// EventBufferingMode selects how events are delivered to the channel provided
+// via WithEvents() when the channel is full. See WithEventBuffering.
+type EventBufferingMode string
+
+const (
+	// EventBufferingDrop drops the event when the events channel is full.
+	EventBufferingDrop EventBufferingMode = "drop"
+
+	// EventBufferingRing spills the event into a bounded ring buffer when
+	// the events channel is full. A helper goroutine flushes the buffer
+	// into the channel; only once the buffer itself is full is the oldest
+	// buffered event dropped.
+	EventBufferingRing EventBufferingMode = "ring"
+)
+
+// defaultEventBufferSize is the ring buffer capacity used when
+// WithEventBuffering is given a non-positive size.
+const defaultEventBufferSize = 1024
+
+// eventDropWarningInterval limits how often dropped events are reported at
+// warning level.
+const eventDropWarningInterval = time.Minute
+
+// eventRingBuffer is a bounded FIFO buffer of allocator events. When full,
+// pushing a new event overwrites the oldest buffered one.
+type eventRingBuffer struct {
+	mutex  lock.Mutex
+	events []AllocatorEvent
+	head   int // index of the oldest buffered event
+	length int
+
+	// wakeup signals the flusher goroutine that the buffer is non-empty
+	wakeup chan struct{}
+}
+
+func newEventRingBuffer(size int) *eventRingBuffer {
+	if size <= 0 {
+		size = defaultEventBufferSize
+	}
+	return &eventRingBuffer{
+		events: make([]AllocatorEvent, size),
+		wakeup: make(chan struct{}, 1),
+	}
+}
+
+// push appends an event, overwriting the oldest buffered one if the buffer is
+// full, and reports whether an event was dropped that way.
+func (b *eventRingBuffer) push(ev AllocatorEvent) (dropped bool) {
+	b.mutex.Lock()
+	tail := (b.head + b.length) % len(b.events)
+	b.events[tail] = ev
+	if b.length == len(b.events) {
+		b.head = (b.head + 1) % len(b.events)
+		dropped = true
+	} else {
+		b.length++
+	}
+	b.mutex.Unlock()
+
+	select {
+	case b.wakeup <- struct{}{}:
+	default:
+	}
+	return dropped
+}
+
+// pop removes and returns the oldest buffered event.
+func (b *eventRingBuffer) pop() (AllocatorEvent, bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.length == 0 {
+		return AllocatorEvent{}, false
+	}
+	ev := b.events[b.head]
+	b.head = (b.head + 1) % len(b.events)
+	b.length--
+	return ev, true
+}
+
+// flushEvents drains the event ring buffer into the events channel until the
+// allocator is deleted. Blocking on a full channel is acceptable here as only
+// this helper goroutine stalls, never the allocator itself.
+func (a *Allocator) flushEvents() {
+	for {
+		ev, ok := a.eventBuffer.pop()
+		if !ok {
+			select {
+			case <-a.eventBuffer.wakeup:
+				continue
+			case <-a.stopGC:
+				return
+			}
+		}
+		select {
+		case a.events <- ev:
+		case <-a.stopGC:
+			return
+		}
+	}
+}
+
+// eventDropped accounts for a dropped event and periodically reports the
+// number of events dropped since the previous warning.
+func (a *Allocator) eventDropped() {
+	metrics.IdentityEventsDropped.Inc()
+
+	a.eventDropMutex.Lock()
+	a.eventsDropped++
+	if time.Since(a.lastEventDropWarning) < eventDropWarningInterval {
+		a.eventDropMutex.Unlock()
+		return
+	}
+	dropped := a.eventsDropped - a.eventsDroppedReported
+	a.eventsDroppedReported = a.eventsDropped
+	a.lastEventDropWarning = time.Now()
+	a.eventDropMutex.Unlock()
+
+	a.getLogger().Warn("Dropped allocator events, consumer of the events channel is not keeping up",
+		logfields.Count, dropped,
+	)
+}
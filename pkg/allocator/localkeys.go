@@ -6,6 +6,7 @@ package allocator
 import (
 	"fmt"
 	"log/slog"
+	"sync/atomic"
 
 	"github.com/cilium/cilium/pkg/idpool"
 	"github.com/cilium/cilium/pkg/kvstore"
@@ -24,18 +25,31 @@ type localKey struct {
 // localKeys is a map of keys in use locally. Keys can be used multiple times.
 // A refcnt is managed to know when a key is no longer in use
 type localKeys struct {
-	logger *slog.Logger
+	// logger is held in an atomic pointer so that setLogger can swap it at
+	// runtime without synchronizing every log call.
+	logger atomic.Pointer[slog.Logger]
 	lock.RWMutex
 	keys map[string]*localKey
 	ids  map[idpool.ID]*localKey
 }
 
 func newLocalKeys(logger *slog.Logger) *localKeys {
-	return &localKeys{
-		logger: logger,
-		keys:   map[string]*localKey{},
-		ids:    map[idpool.ID]*localKey{},
+	lk := &localKeys{
+		keys: map[string]*localKey{},
+		ids:  map[idpool.ID]*localKey{},
 	}
+	lk.logger.Store(logger)
+	return lk
+}
+
+// getLogger returns the logger the key store currently writes through.
+func (lk *localKeys) getLogger() *slog.Logger {
+	return lk.logger.Load()
+}
+
+// setLogger replaces the logger the key store writes through.
+func (lk *localKeys) setLogger(logger *slog.Logger) {
+	lk.logger.Store(logger)
 }
 
 // allocate creates an entry for key in localKeys if needed and increments the
@@ -51,9 +65,14 @@ func (lk *localKeys) allocate(keyString string, key AllocatorKey, val idpool.ID)
 		if val != k.val {
 			return idpool.NoID, firstUse, fmt.Errorf("local key already allocated with different value (%s != %s)", val, k.val)
 		}
+		if !k.key.IsEqualTo(key) {
+			// Cannot happen unless GetKey is not injective for the key type
+			// and two distinct keys share the same encoding.
+			return idpool.NoID, firstUse, fmt.Errorf("local key %q already allocated for a different key", keyString)
+		}
 
 		k.refcnt++
-		kvstore.Trace(lk.logger, "Incremented local key refcnt",
+		kvstore.Trace(lk.getLogger(), "Incremented local key refcnt",
 			fieldKey, keyString,
 			fieldID, val,
 			fieldRefCnt, k.refcnt,
@@ -65,7 +84,7 @@ func (lk *localKeys) allocate(keyString string, key AllocatorKey, val idpool.ID)
 	k := &localKey{key: key, val: val, refcnt: 1}
 	lk.keys[keyString] = k
 	lk.ids[val] = k
-	kvstore.Trace(lk.logger, "New local key",
+	kvstore.Trace(lk.getLogger(), "New local key",
 		fieldKey, keyString,
 		fieldID, val,
 		fieldRefCnt, 1,
@@ -79,7 +98,7 @@ func (lk *localKeys) verify(key string) error {
 
 	if k, ok := lk.keys[key]; ok {
 		k.verified = true
-		kvstore.Trace(lk.logger, "Local key verified",
+		kvstore.Trace(lk.getLogger(), "Local key verified",
 			fieldKey, key,
 		)
 		return nil
@@ -125,7 +144,7 @@ func (lk *localKeys) use(key string) idpool.ID {
 		}
 
 		k.refcnt++
-		kvstore.Trace(lk.logger, "Incremented local key refcnt",
+		kvstore.Trace(lk.getLogger(), "Incremented local key refcnt",
 			fieldKey, key,
 			fieldID, k.val,
 			fieldRefCnt, k.refcnt,
@@ -138,13 +157,14 @@ func (lk *localKeys) use(key string) idpool.ID {
 
 // release releases the refcnt of a key. It returns the ID associated with the
 // given key. When the last reference was released, the key is deleted and the
-// returned lastUse value is true.
+// returned lastUse value is true. Releasing a key with no local references
+// returns ErrNotAllocatedLocally.
 func (lk *localKeys) release(key string) (lastUse bool, id idpool.ID, err error) {
 	lk.Lock()
 	defer lk.Unlock()
 	if k, ok := lk.keys[key]; ok {
 		k.refcnt--
-		kvstore.Trace(lk.logger, "Decremented local key refcnt",
+		kvstore.Trace(lk.getLogger(), "Decremented local key refcnt",
 			fieldKey, key,
 			fieldID, k.val,
 			fieldRefCnt, k.refcnt,
@@ -158,7 +178,67 @@ func (lk *localKeys) release(key string) (lastUse bool, id idpool.ID, err error)
 		return false, k.val, nil
 	}
 
-	return false, idpool.NoID, fmt.Errorf("unable to find key in local cache")
+	return false, idpool.NoID, ErrNotAllocatedLocally
+}
+
+// releaseAll drops all references to all keys in a single pass and returns
+// the released keys by ID, regardless of their refcnt
+func (lk *localKeys) releaseAll() map[idpool.ID]AllocatorKey {
+	lk.Lock()
+	defer lk.Unlock()
+
+	released := make(map[idpool.ID]AllocatorKey, len(lk.ids))
+	for id, k := range lk.ids {
+		released[id] = k.key
+		kvstore.Trace(lk.getLogger(), "Released local key",
+			fieldKey, k.key.GetKey(),
+			fieldID, id,
+			fieldRefCnt, 0,
+		)
+	}
+	clear(lk.keys)
+	clear(lk.ids)
+
+	return released
+}
+
+// count returns the number of keys currently in use locally
+func (lk *localKeys) count() int {
+	lk.RLock()
+	defer lk.RUnlock()
+	return len(lk.keys)
+}
+
+// getAllKeys returns a copy of the current key-to-ID map, keyed by the
+// encoded key string.
+func (lk *localKeys) getAllKeys() map[string]idpool.ID {
+	lk.RLock()
+	defer lk.RUnlock()
+
+	keys := make(map[string]idpool.ID, len(lk.keys))
+	for k, localKey := range lk.keys {
+		keys[k] = localKey.val
+	}
+	return keys
+}
+
+// localKeyInfo describes the local use of one key.
+type localKeyInfo struct {
+	refcnt   uint64
+	verified bool
+}
+
+// getKeyInfo returns the reference count and verification state of each key
+// in use locally, keyed by the encoded key string.
+func (lk *localKeys) getKeyInfo() map[string]localKeyInfo {
+	lk.RLock()
+	defer lk.RUnlock()
+
+	info := make(map[string]localKeyInfo, len(lk.keys))
+	for k, localKey := range lk.keys {
+		info[k] = localKeyInfo{refcnt: localKey.refcnt, verified: localKey.verified}
+	}
+	return info
 }
 
 func (lk *localKeys) getVerifiedIDs() map[idpool.ID]AllocatorKey {
@@ -0,0 +1,249 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package allocator
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cilium/cilium/pkg/metrics"
+	"github.com/cilium/cilium/pkg/metrics/metric"
+)
+
+const (
+	subsystem = "allocator"
+
+	// identitySubsystem scopes the metrics about the identity ListAndWatch
+	// stream, which are reported under the identity rather than the
+	// allocator subsystem.
+	identitySubsystem = "identity"
+
+	outcomeLabel  = "outcome"
+	clusterLabel  = "target_cluster"
+	resourceLabel = "resource"
+
+	resourceCacheValue     = "cache"
+	resourceLocalKeysValue = "local_keys"
+	resourceIDPoolValue    = "available_ids"
+
+	// outcomeReusedLocalValue is an allocation satisfied by a key already in
+	// use locally, without any backend operation.
+	outcomeReusedLocalValue = "reused-local"
+
+	// outcomeReusedGlobalValue is an allocation that took the first local
+	// reference to a key that already existed in the backend.
+	outcomeReusedGlobalValue = "reused-global"
+
+	// outcomeNewValue is an allocation that created a new key in the backend.
+	outcomeNewValue = "new"
+
+	// outcomeFailedValue is an allocation that returned an error.
+	outcomeFailedValue = "failed"
+
+	changeKindLabel = "change_kind"
+
+	// outcomeAcceptedValue is a cache event accepted by all registered
+	// validators, outcomeRejectedValue one rejected by a validator.
+	outcomeAcceptedValue = "accepted"
+	outcomeRejectedValue = "rejected"
+)
+
+// Metrics is the interface the Allocator reports its internal state through.
+// Implementations must be safe for concurrent use. Unless WithMetrics is
+// passed to NewAllocator, a no-op implementation is used.
+type Metrics interface {
+	IncAllocation(outcome string)
+	ObserveAllocateDuration(seconds float64)
+	ObserveAllocateAttempts(attempts int)
+	SetCacheSize(entries int)
+	SetLocalKeys(keys int)
+	SetAvailableIDs(ids int)
+	SetLeakedLeases(leases int)
+	SetRemoteCacheSize(remoteName string, entries int)
+	DeleteRemoteCacheSize(remoteName string)
+	IncCacheValidation(outcome string, kind string)
+	IncInvalidRelease()
+	IncListWatchRestart()
+}
+
+// allocationOutcome maps the return values of Allocate to the outcome label
+// value of the allocation counter.
+func allocationOutcome(isNew, firstUse bool, err error) string {
+	switch {
+	case err != nil:
+		return outcomeFailedValue
+	case isNew:
+		return outcomeNewValue
+	case firstUse:
+		return outcomeReusedGlobalValue
+	default:
+		return outcomeReusedLocalValue
+	}
+}
+
+var _ Metrics = noopMetrics{}
+
+// noopMetrics discards all observations.
+type noopMetrics struct{}
+
+func (noopMetrics) IncAllocation(outcome string)                      {}
+func (noopMetrics) ObserveAllocateDuration(seconds float64)           {}
+func (noopMetrics) ObserveAllocateAttempts(attempts int)              {}
+func (noopMetrics) SetCacheSize(entries int)                          {}
+func (noopMetrics) SetLocalKeys(keys int)                             {}
+func (noopMetrics) SetAvailableIDs(ids int)                           {}
+func (noopMetrics) SetLeakedLeases(leases int)                        {}
+func (noopMetrics) SetRemoteCacheSize(remoteName string, entries int) {}
+func (noopMetrics) DeleteRemoteCacheSize(remoteName string)           {}
+func (noopMetrics) IncCacheValidation(outcome string, kind string)    {}
+func (noopMetrics) IncInvalidRelease()                                {}
+func (noopMetrics) IncListWatchRestart()                              {}
+
+var _ Metrics = (*AllocatorMetrics)(nil)
+
+// AllocatorMetrics is the Prometheus-backed Metrics implementation.
+type AllocatorMetrics struct {
+	// AllocationCount is the number of completed Allocate calls, by outcome.
+	AllocationCount metric.Vec[metric.Counter]
+
+	// AllocateDuration is the total latency of Allocate calls, including
+	// retries and backoff.
+	AllocateDuration metric.Histogram
+
+	// AllocateAttempts is the number of attempts an Allocate call needed
+	// before returning.
+	AllocateAttempts metric.Histogram
+
+	// State is the number of entries in the allocator-internal state: the
+	// main cache, the keys in use locally and the IDs still available for
+	// allocation.
+	State metric.Vec[metric.Gauge]
+
+	// RemoteCacheSize is the number of entries in the cache of each remote
+	// cluster.
+	RemoteCacheSize metric.DeletableVec[metric.Gauge]
+
+	// LeakedLeases is the number of ID leases older than the leak threshold
+	// that were neither used nor released.
+	LeakedLeases metric.Gauge
+
+	// CacheValidations is the number of cache events processed by the
+	// registered cache validators, by outcome and change kind.
+	CacheValidations metric.Vec[metric.Counter]
+
+	// InvalidReleaseCount is the number of Release calls for keys with no
+	// local references, typically caused by double-release bugs.
+	InvalidReleaseCount metric.Counter
+
+	// ListWatchRestarts is the number of times the watchdog restarted the
+	// identity ListAndWatch stream because it stopped delivering events.
+	ListWatchRestarts metric.Counter
+}
+
+func NewAllocatorMetrics() *AllocatorMetrics {
+	return &AllocatorMetrics{
+		AllocationCount: metric.NewCounterVec(metric.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "allocations_total",
+			Help:      "Number of completed key allocations, by outcome",
+		}, []string{outcomeLabel}),
+		AllocateDuration: metric.NewHistogram(metric.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "allocation_duration_seconds",
+			Help:      "Latency of key allocations, including retries and backoff",
+		}),
+		AllocateAttempts: metric.NewHistogram(metric.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "allocation_attempts",
+			Help:      "Number of attempts a key allocation needed before returning",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 6),
+		}),
+		State: metric.NewGaugeVec(metric.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "state_entries",
+			Help:      "Number of entries in the allocator-internal state, by resource",
+		}, []string{resourceLabel}),
+		RemoteCacheSize: metric.NewGaugeVec(metric.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "remote_cache_entries",
+			Help:      "Number of entries in the identity cache of each remote cluster",
+		}, []string{clusterLabel}),
+		LeakedLeases: metric.NewGauge(metric.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "leaked_id_leases",
+			Help:      "Number of ID leases older than the leak threshold that were neither used nor released",
+		}),
+		CacheValidations: metric.NewCounterVec(metric.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "cache_validations_total",
+			Help:      "Number of cache events processed by the registered validators, by outcome and change kind",
+		}, []string{outcomeLabel, changeKindLabel}),
+		InvalidReleaseCount: metric.NewCounter(metric.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "invalid_releases_total",
+			Help:      "Number of key releases for keys with no local references",
+		}),
+		ListWatchRestarts: metric.NewCounter(metric.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: identitySubsystem,
+			Name:      "listwatch_restarts_total",
+			Help:      "Number of times the watchdog restarted the identity ListAndWatch stream",
+		}),
+	}
+}
+
+func (m *AllocatorMetrics) IncAllocation(outcome string) {
+	m.AllocationCount.WithLabelValues(outcome).Inc()
+}
+
+func (m *AllocatorMetrics) ObserveAllocateDuration(seconds float64) {
+	m.AllocateDuration.Observe(seconds)
+}
+
+func (m *AllocatorMetrics) ObserveAllocateAttempts(attempts int) {
+	m.AllocateAttempts.Observe(float64(attempts))
+}
+
+func (m *AllocatorMetrics) SetCacheSize(entries int) {
+	m.State.WithLabelValues(resourceCacheValue).Set(float64(entries))
+}
+
+func (m *AllocatorMetrics) SetLocalKeys(keys int) {
+	m.State.WithLabelValues(resourceLocalKeysValue).Set(float64(keys))
+}
+
+func (m *AllocatorMetrics) SetAvailableIDs(ids int) {
+	m.State.WithLabelValues(resourceIDPoolValue).Set(float64(ids))
+}
+
+func (m *AllocatorMetrics) SetLeakedLeases(leases int) {
+	m.LeakedLeases.Set(float64(leases))
+}
+
+func (m *AllocatorMetrics) SetRemoteCacheSize(remoteName string, entries int) {
+	m.RemoteCacheSize.WithLabelValues(remoteName).Set(float64(entries))
+}
+
+func (m *AllocatorMetrics) DeleteRemoteCacheSize(remoteName string) {
+	m.RemoteCacheSize.DeletePartialMatch(prometheus.Labels{clusterLabel: remoteName})
+}
+
+func (m *AllocatorMetrics) IncCacheValidation(outcome string, kind string) {
+	m.CacheValidations.WithLabelValues(outcome, kind).Inc()
+}
+
+func (m *AllocatorMetrics) IncInvalidRelease() {
+	m.InvalidReleaseCount.Inc()
+}
+
+func (m *AllocatorMetrics) IncListWatchRestart() {
+	m.ListWatchRestarts.Inc()
+}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package allocator
+
+import (
+	"github.com/cilium/cilium/pkg/lock"
+)
+
+type mockMetrics struct {
+	mutex lock.Mutex
+
+	allocations      map[string]int
+	durations        int
+	attempts         []int
+	cacheSize        int
+	localKeys        int
+	availableIDs     int
+	leakedLeases     int
+	remoteCaches     map[string]int
+	cacheValidations map[string]int
+	invalidReleases  int
+	watchRestarts    int
+}
+
+func newMockMetrics() *mockMetrics {
+	return &mockMetrics{
+		allocations:      map[string]int{},
+		remoteCaches:     map[string]int{},
+		cacheValidations: map[string]int{},
+	}
+}
+
+func (m *mockMetrics) IncAllocation(outcome string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.allocations[outcome]++
+}
+
+func (m *mockMetrics) ObserveAllocateDuration(seconds float64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.durations++
+}
+
+func (m *mockMetrics) ObserveAllocateAttempts(attempts int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.attempts = append(m.attempts, attempts)
+}
+
+func (m *mockMetrics) SetCacheSize(entries int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.cacheSize = entries
+}
+
+func (m *mockMetrics) SetLocalKeys(keys int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.localKeys = keys
+}
+
+func (m *mockMetrics) SetAvailableIDs(ids int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.availableIDs = ids
+}
+
+func (m *mockMetrics) SetLeakedLeases(leases int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.leakedLeases = leases
+}
+
+func (m *mockMetrics) SetRemoteCacheSize(remoteName string, entries int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.remoteCaches[remoteName] = entries
+}
+
+func (m *mockMetrics) DeleteRemoteCacheSize(remoteName string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.remoteCaches, remoteName)
+}
+
+func (m *mockMetrics) IncCacheValidation(outcome string, kind string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.cacheValidations[outcome+"/"+kind]++
+}
+
+func (m *mockMetrics) IncInvalidRelease() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.invalidReleases++
+}
+
+func (m *mockMetrics) IncListWatchRestart() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.watchRestarts++
+}
+
+func (m *mockMetrics) totalAttempts() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	total := 0
+	for _, attempts := range m.attempts {
+		total += attempts
+	}
+	return total
+}
+
+func (m *mockMetrics) allocationCount(outcome string) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.allocations[outcome]
+}
+
+func (m *mockMetrics) leakedLeaseCount() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.leakedLeases
+}
+
+func (m *mockMetrics) cacheValidationCount(outcome string, kind AllocatorChangeKind) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.cacheValidations[outcome+"/"+string(kind)]
+}
+
+func (m *mockMetrics) invalidReleaseCount() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.invalidReleases
+}
+
+func (m *mockMetrics) listWatchRestartCount() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.watchRestarts
+}
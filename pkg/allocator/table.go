@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package allocator
+
+import (
+	"context"
+
+	"github.com/cilium/statedb"
+	"github.com/cilium/statedb/index"
+
+	"github.com/cilium/cilium/pkg/idpool"
+)
+
+// IdentityEntry is the statedb representation of one key to ID association
+// in the allocator cache. These are stored in the table registered via
+// RegisterAllocatorTable and can be inspected with "db/show identities" in
+// "cilium-dbg shell".
+type IdentityEntry struct {
+	ID  idpool.ID
+	Key AllocatorKey
+}
+
+func (e IdentityEntry) TableHeader() []string {
+	return []string{
+		"ID",
+		"Key",
+	}
+}
+
+func (e IdentityEntry) TableRow() []string {
+	return []string{
+		e.ID.String(),
+		e.Key.String(),
+	}
+}
+
+const (
+	IdentityTableName = "identities"
+)
+
+var (
+	identityIDIndex = statedb.Index[IdentityEntry, idpool.ID]{
+		Name: "id",
+		FromObject: func(obj IdentityEntry) index.KeySet {
+			return index.NewKeySet(index.Uint64(uint64(obj.ID)))
+		},
+		FromKey: func(id idpool.ID) index.Key {
+			return index.Uint64(uint64(id))
+		},
+		Unique: true,
+	}
+
+	IdentityByID = identityIDIndex.Query
+)
+
+// RegisterAllocatorTable registers a table mirroring the allocator main cache
+// in 'db' and keeps it up to date by observing the cache changes. The table is
+// marked initialized once the initial cache contents have been replayed into
+// it. The observation stops when the allocator is deleted.
+func RegisterAllocatorTable(db *statedb.DB, a *Allocator) (statedb.Table[IdentityEntry], error) {
+	tbl, err := statedb.NewTable(
+		IdentityTableName,
+		identityIDIndex,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.RegisterTable(tbl); err != nil {
+		return nil, err
+	}
+
+	wtxn := db.WriteTxn(tbl)
+	initDone := tbl.RegisterInitializer(wtxn, "allocator")
+	wtxn.Commit()
+
+	// Stop observing when the allocator is deleted.
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-a.stopGC
+		cancel()
+	}()
+
+	a.Observe(ctx,
+		func(change AllocatorChange) {
+			txn := db.WriteTxn(tbl)
+			defer txn.Commit()
+
+			switch change.Kind {
+			case AllocatorChangeUpsert:
+				tbl.Insert(txn, IdentityEntry{ID: change.ID, Key: change.Key})
+			case AllocatorChangeDelete:
+				tbl.Delete(txn, IdentityEntry{ID: change.ID})
+			case AllocatorChangeSync:
+				initDone(txn)
+			}
+		},
+		func(error) { cancel() },
+	)
+
+	return tbl, nil
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package allocator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cilium/hive/hivetest"
+	"github.com/cilium/statedb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cilium/cilium/pkg/idpool"
+	"github.com/cilium/cilium/pkg/time"
+)
+
+func TestRegisterAllocatorTable(t *testing.T) {
+	backend := newDummyBackend()
+	allocator, err := NewAllocator(hivetest.Logger(t), TestAllocatorKey(""), backend, WithMax(idpool.ID(256)), WithoutGC())
+	require.NoError(t, err)
+	require.NotNil(t, allocator)
+	t.Cleanup(allocator.Delete)
+	<-allocator.initialListDone
+
+	ctx := context.Background()
+	fooID, _, _, err := allocator.Allocate(ctx, TestAllocatorKey("foo"))
+	require.NoError(t, err)
+	barID, _, _, err := allocator.Allocate(ctx, TestAllocatorKey("bar"))
+	require.NoError(t, err)
+
+	db := statedb.New()
+	tbl, err := RegisterAllocatorTable(db, allocator)
+	require.NoError(t, err)
+
+	// The current cache contents are replayed into the table, after which
+	// it is marked initialized.
+	require.Eventually(t, func() bool {
+		txn := db.ReadTxn()
+		init, _ := tbl.Initialized(txn)
+		return init && tbl.NumObjects(txn) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	entry, _, found := tbl.Get(db.ReadTxn(), IdentityByID(fooID))
+	require.True(t, found)
+	require.Equal(t, TestAllocatorKey("foo"), entry.Key)
+
+	// Live changes keep the table in sync.
+	allocator.mainCache.OnDelete(barID, TestAllocatorKey("bar"))
+	require.Eventually(t, func() bool {
+		_, _, found := tbl.Get(db.ReadTxn(), IdentityByID(barID))
+		return !found
+	}, time.Second, 10*time.Millisecond)
+
+	allocator.mainCache.OnUpsert(idpool.ID(42), TestAllocatorKey("baz"))
+	require.Eventually(t, func() bool {
+		entry, _, found := tbl.Get(db.ReadTxn(), IdentityByID(idpool.ID(42)))
+		return found && entry.Key == TestAllocatorKey("baz")
+	}, time.Second, 10*time.Millisecond)
+}
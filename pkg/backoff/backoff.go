@@ -42,6 +42,27 @@ func (n *nodeManager) ClusterSizeDependantInterval(baseInterval time.Duration) t
 	return n.clusterSizeDependantInterval(baseInterval)
 }
 
+// BackoffInterface abstracts over the backoff strategy used when retrying an
+// operation.
+type BackoffInterface interface {
+	// Wait waits for the duration of the next backoff interval. It returns
+	// an error when the context is cancelled before the interval has
+	// elapsed.
+	Wait(ctx context.Context) error
+
+	// Reset resets the backoff attempt counter.
+	Reset()
+
+	// Attempt returns the number of attempts since the last reset.
+	Attempt() int
+}
+
+var (
+	_ BackoffInterface = (*Exponential)(nil)
+	_ BackoffInterface = (*Linear)(nil)
+	_ BackoffInterface = (*Constant)(nil)
+)
+
 // Exponential implements an exponential backoff
 type Exponential struct {
 	Logger *slog.Logger
@@ -172,6 +193,118 @@ func (b *Exponential) Wait(ctx context.Context) error {
 	return nil
 }
 
+// Linear implements a backoff that grows linearly with the number of attempts
+type Linear struct {
+	Logger *slog.Logger
+
+	// Step is the amount the backoff time grows with every attempt, if
+	// unspecified, 1 second will be used
+	Step time.Duration
+
+	// Max is the maximum backoff time, if unspecified, no maximum time is
+	// applied
+	Max time.Duration
+
+	// Name is a free form string describing the operation subject to the
+	// backoff, used for logging purposes.
+	Name string
+
+	attempt int
+}
+
+// Reset backoff attempt counter
+func (b *Linear) Reset() {
+	b.attempt = 0
+}
+
+// Attempt returns the number of attempts since the last reset.
+func (b *Linear) Attempt() int {
+	return b.attempt
+}
+
+// Wait waits for the required time using a linear backoff
+func (b *Linear) Wait(ctx context.Context) error {
+	b.attempt++
+	t := b.Duration(b.attempt)
+
+	b.Logger.Debug("Sleeping with linear backoff",
+		logfields.Duration, t,
+		logfields.Attempt, b.attempt,
+		logfields.Name, b.Name,
+	)
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("linear backoff cancelled via context: %w", ctx.Err())
+	case <-time.After(t):
+	}
+
+	return nil
+}
+
+// Duration returns the wait duration for the nth attempt
+func (b *Linear) Duration(attempt int) time.Duration {
+	step := time.Duration(1) * time.Second
+	if b.Step != time.Duration(0) {
+		step = b.Step
+	}
+
+	t := step * time.Duration(attempt)
+	if b.Max != time.Duration(0) && t > b.Max {
+		t = b.Max
+	}
+
+	return t
+}
+
+// Constant implements a backoff waiting a fixed interval between attempts
+type Constant struct {
+	Logger *slog.Logger
+
+	// Interval is the fixed backoff time, if unspecified, 1 second will be
+	// used
+	Interval time.Duration
+
+	// Name is a free form string describing the operation subject to the
+	// backoff, used for logging purposes.
+	Name string
+
+	attempt int
+}
+
+// Reset backoff attempt counter
+func (b *Constant) Reset() {
+	b.attempt = 0
+}
+
+// Attempt returns the number of attempts since the last reset.
+func (b *Constant) Attempt() int {
+	return b.attempt
+}
+
+// Wait waits for the configured interval
+func (b *Constant) Wait(ctx context.Context) error {
+	b.attempt++
+	t := b.Interval
+	if t == time.Duration(0) {
+		t = time.Duration(1) * time.Second
+	}
+
+	b.Logger.Debug("Sleeping with constant backoff",
+		logfields.Duration, t,
+		logfields.Attempt, b.attempt,
+		logfields.Name, b.Name,
+	)
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("constant backoff cancelled via context: %w", ctx.Err())
+	case <-time.After(t):
+	}
+
+	return nil
+}
+
 // Duration returns the wait duration for the nth attempt
 func (b *Exponential) Duration(attempt int) time.Duration {
 	if b.Name == "" {
@@ -4,6 +4,7 @@
 package backoff
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"testing"
@@ -73,6 +74,44 @@ func TestClusterSizeDependantInterval(t *testing.T) {
 	}
 }
 
+func TestLinearDuration(t *testing.T) {
+	linear := &Linear{
+		Logger: hivetest.Logger(t),
+		Step:   time.Second,
+		Max:    3 * time.Second,
+	}
+
+	require.Equal(t, 1*time.Second, linear.Duration(1))
+	require.Equal(t, 2*time.Second, linear.Duration(2))
+	require.Equal(t, 3*time.Second, linear.Duration(3))
+	// Capped at Max.
+	require.Equal(t, 3*time.Second, linear.Duration(4))
+
+	// Step defaults to 1 second.
+	linear = &Linear{Logger: hivetest.Logger(t)}
+	require.Equal(t, 2*time.Second, linear.Duration(2))
+}
+
+func TestConstantWait(t *testing.T) {
+	constant := &Constant{
+		Logger:   hivetest.Logger(t),
+		Interval: time.Millisecond,
+	}
+
+	require.NoError(t, constant.Wait(context.Background()))
+	require.NoError(t, constant.Wait(context.Background()))
+	require.Equal(t, 2, constant.Attempt())
+
+	constant.Reset()
+	require.Equal(t, 0, constant.Attempt())
+
+	// Cancelled context aborts the wait.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	constant.Interval = time.Minute
+	require.Error(t, constant.Wait(ctx))
+}
+
 func TestJitterDistribution(t *testing.T) {
 	nodeBackoff := &Exponential{
 		Logger: hivetest.Logger(t),
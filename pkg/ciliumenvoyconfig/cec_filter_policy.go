@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package ciliumenvoyconfig
+
+import (
+	"fmt"
+	"strings"
+
+	envoy_config_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_config_http "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/cilium/cilium/pkg/envoy"
+)
+
+const anyTypeURLPrefix = "type.googleapis.com/"
+
+// filterPolicy is the operator-configured allowlist and denylist of Envoy
+// extensions permitted in CiliumEnvoyConfig resources. Entries match an
+// extension by its declared name (e.g. "envoy.filters.http.lua") or by the
+// type URL of its typed config, with or without the "type.googleapis.com/"
+// prefix. An extension matching the denylist is always rejected; with a
+// non-empty allowlist, extensions matching neither list are rejected as
+// well. A nil policy allows everything.
+type filterPolicy struct {
+	allowed map[string]struct{}
+	denied  map[string]struct{}
+}
+
+// newFilterPolicy returns the policy enforcing the given allowlist and
+// denylist, or nil if both are empty, preserving the allow-everything
+// default.
+func newFilterPolicy(allowed []string, denied []string) *filterPolicy {
+	if len(allowed) == 0 && len(denied) == 0 {
+		return nil
+	}
+	policy := &filterPolicy{
+		allowed: make(map[string]struct{}, len(allowed)),
+		denied:  make(map[string]struct{}, len(denied)),
+	}
+	for _, name := range allowed {
+		policy.allowed[strings.TrimPrefix(name, anyTypeURLPrefix)] = struct{}{}
+	}
+	for _, name := range denied {
+		policy.denied[strings.TrimPrefix(name, anyTypeURLPrefix)] = struct{}{}
+	}
+	return policy
+}
+
+// check validates a single extension, identified by its declared name and
+// the type URL of its typed config, against the policy.
+func (p *filterPolicy) check(kind string, name string, tc *anypb.Any) error {
+	ids := make([]string, 0, 2)
+	if name != "" {
+		ids = append(ids, name)
+	}
+	if tc != nil {
+		ids = append(ids, strings.TrimPrefix(tc.GetTypeUrl(), anyTypeURLPrefix))
+	}
+	for _, id := range ids {
+		if _, ok := p.denied[id]; ok {
+			return fmt.Errorf("%s %q is denied by policy", kind, id)
+		}
+	}
+	if len(p.allowed) > 0 {
+		for _, id := range ids {
+			if _, ok := p.allowed[id]; ok {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s %q is not allowed by policy", kind, strings.Join(ids, " / "))
+	}
+	return nil
+}
+
+func (p *filterPolicy) checkTransportSocket(ts *envoy_config_core.TransportSocket) error {
+	if ts == nil {
+		return nil
+	}
+	return p.check("transport socket", ts.Name, ts.GetTypedConfig())
+}
+
+// checkListener validates the listener filters, network filters, HTTP
+// filters nested in HTTP connection managers, and transport sockets of the
+// given listener. Intended to be called on the listener as given in the
+// CEC, before any Cilium filters are injected.
+func (p *filterPolicy) checkListener(listener *envoy_config_listener.Listener) error {
+	if p == nil {
+		return nil
+	}
+	for _, lf := range listener.ListenerFilters {
+		if err := p.check("listener filter", lf.Name, lf.GetTypedConfig()); err != nil {
+			return err
+		}
+	}
+	for _, fc := range listener.FilterChains {
+		if err := p.checkFilterChain(fc); err != nil {
+			return err
+		}
+	}
+	if fc := listener.GetDefaultFilterChain(); fc != nil {
+		if err := p.checkFilterChain(fc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *filterPolicy) checkFilterChain(fc *envoy_config_listener.FilterChain) error {
+	if err := p.checkTransportSocket(fc.TransportSocket); err != nil {
+		return err
+	}
+	for _, filter := range fc.Filters {
+		if err := p.check("network filter", filter.Name, filter.GetTypedConfig()); err != nil {
+			return err
+		}
+		// Walk the HTTP filters nested in the HTTP connection manager's Any
+		// payload.
+		tc := filter.GetTypedConfig()
+		if tc == nil || tc.GetTypeUrl() != envoy.HttpConnectionManagerTypeURL {
+			continue
+		}
+		message, err := tc.UnmarshalNew()
+		if err != nil {
+			continue
+		}
+		hcmConfig, ok := message.(*envoy_config_http.HttpConnectionManager)
+		if !ok {
+			continue
+		}
+		for _, httpFilter := range hcmConfig.HttpFilters {
+			if err := p.check("HTTP filter", httpFilter.Name, httpFilter.GetTypedConfig()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkCluster validates the transport sockets of the given cluster.
+func (p *filterPolicy) checkCluster(cluster *envoy_config_cluster.Cluster) error {
+	if p == nil {
+		return nil
+	}
+	if err := p.checkTransportSocket(cluster.TransportSocket); err != nil {
+		return err
+	}
+	for _, tsm := range cluster.TransportSocketMatches {
+		if err := p.checkTransportSocket(tsm.TransportSocket); err != nil {
+			return err
+		}
+	}
+	return nil
+}
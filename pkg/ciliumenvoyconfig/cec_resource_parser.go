@@ -25,6 +25,7 @@ import (
 	envoy_config_types "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -38,6 +39,7 @@ import (
 	"github.com/cilium/cilium/pkg/node"
 	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/policy/api"
+	"github.com/cilium/cilium/pkg/time"
 )
 
 const (
@@ -63,6 +65,28 @@ type CECResourceParser struct {
 
 	defaultMaxConcurrentRetries uint32
 	httpLingerConfig            int
+	defaultHealthCheck          healthCheckDefaults
+
+	// originalDstClusterName is the name of the shared ORIGINAL_DST egress
+	// cluster that routes may reference without defining it. Empty if the
+	// shared cluster is disabled.
+	originalDstClusterName         string
+	originalDstAllowHeaderOverride bool
+
+	// filterPolicy is the allowlist/denylist of Envoy extensions permitted
+	// in CEC resources. Nil if no lists are configured, allowing everything.
+	filterPolicy *filterPolicy
+}
+
+// healthCheckDefaults holds the parameters of the active health check that is
+// injected into clusters that don't define one, if enabled.
+type healthCheckDefaults struct {
+	enabled            bool
+	interval           time.Duration
+	timeout            time.Duration
+	healthyThreshold   uint32
+	unhealthyThreshold uint32
+	httpPath           string
 }
 
 type parserParams struct {
@@ -84,6 +108,17 @@ func newCECResourceParser(params parserParams) *CECResourceParser {
 		portAllocator:               params.PortAllocator,
 		defaultMaxConcurrentRetries: params.EnvoyConfig.ProxyMaxConcurrentRetries,
 		httpLingerConfig:            params.EnvoyConfig.EnvoyHTTPUpstreamLingerTimeout,
+		defaultHealthCheck: healthCheckDefaults{
+			enabled:            params.CecConfig.EnvoyConfigDefaultHealthCheck,
+			interval:           params.CecConfig.EnvoyConfigDefaultHealthCheckInterval,
+			timeout:            params.CecConfig.EnvoyConfigDefaultHealthCheckTimeout,
+			healthyThreshold:   params.CecConfig.EnvoyConfigDefaultHealthCheckHealthyThreshold,
+			unhealthyThreshold: params.CecConfig.EnvoyConfigDefaultHealthCheckUnhealthyThreshold,
+			httpPath:           params.CecConfig.EnvoyConfigDefaultHealthCheckHttpPath,
+		},
+		originalDstClusterName:         params.CecConfig.EnvoyConfigOriginalDstClusterName,
+		originalDstAllowHeaderOverride: params.CecConfig.EnvoyConfigOriginalDstAllowHeaderOverride,
+		filterPolicy:                   newFilterPolicy(params.CecConfig.EnvoyConfigAllowedFilters, params.CecConfig.EnvoyConfigDeniedFilters),
 	}
 
 	// Retrieve Ingress IPs from local Node.
@@ -140,7 +175,11 @@ func (r *CECResourceParser) ParseResources(cecNamespace string, cecName string,
 	// and downstream filters were injected.
 	injectCiliumUpstreamFilters := false
 
-	resources := envoy.Resources{}
+	// set if any route or TCP proxy references the shared ORIGINAL_DST
+	// egress cluster, which is then added to the parsed resources.
+	sharedClusterRef := false
+
+	resources := envoy.Resources{L7LB: isL7LB}
 	for _, res := range xdsResources {
 		// Skip empty TypeURLs, which are left behind when Unmarshalling resource JSON fails
 		if res.TypeUrl == "" {
@@ -162,6 +201,15 @@ func (r *CECResourceParser) ParseResources(cecNamespace string, cecName string,
 				return envoy.Resources{}, fmt.Errorf("unspecified Listener name")
 			}
 
+			// Enforce the configured filter allowlist/denylist on the
+			// listener as given in the CEC, before any Cilium filters are
+			// injected.
+			if validate {
+				if err := r.filterPolicy.checkListener(listener); err != nil {
+					return envoy.Resources{}, fmt.Errorf("Listener %q: %w", listener.Name, err)
+				}
+			}
+
 			if option.Config.EnableBPFTProxy {
 				// Envoy since 1.20.0 uses SO_REUSEPORT on listeners by default.
 				// BPF TPROXY is currently not compatible with SO_REUSEPORT, so
@@ -215,7 +263,7 @@ func (r *CECResourceParser) ParseResources(cecNamespace string, cecName string,
 							}
 						}
 						if routeConfig := hcmConfig.GetRouteConfig(); routeConfig != nil {
-							if qualifyRouteConfigurationResourceNames(cecNamespace, cecName, routeConfig) {
+							if r.qualifyRouteConfigurationResourceNames(cecNamespace, cecName, routeConfig, &sharedClusterRef) {
 								updated = true
 							}
 						}
@@ -242,7 +290,7 @@ func (r *CECResourceParser) ParseResources(cecNamespace string, cecName string,
 							continue
 						}
 
-						if qualifyTcpProxyResourceNames(cecNamespace, cecName, tcpProxy) {
+						if r.qualifyTcpProxyResourceNames(cecNamespace, cecName, tcpProxy, &sharedClusterRef) {
 							filter.ConfigType = &envoy_config_listener.Filter_TypedConfig{
 								TypedConfig: toAny(tcpProxy),
 							}
@@ -295,7 +343,7 @@ func (r *CECResourceParser) ParseResources(cecNamespace string, cecName string,
 				return envoy.Resources{}, fmt.Errorf("unspecified RouteConfiguration name")
 			}
 
-			qualifyRouteConfigurationResourceNames(cecNamespace, cecName, route)
+			r.qualifyRouteConfigurationResourceNames(cecNamespace, cecName, route, &sharedClusterRef)
 
 			name := route.Name
 			route.Name, _ = api.ResourceQualifiedName(cecNamespace, cecName, name, api.ForceNamespace)
@@ -328,10 +376,18 @@ func (r *CECResourceParser) ParseResources(cecNamespace string, cecName string,
 				return envoy.Resources{}, fmt.Errorf("unspecified Cluster name")
 			}
 
+			if validate {
+				if err := r.filterPolicy.checkCluster(cluster); err != nil {
+					return envoy.Resources{}, fmt.Errorf("Cluster %q: %w", cluster.Name, err)
+				}
+			}
+
 			fillInTransportSocketXDS(cecNamespace, cecName, cluster.TransportSocket)
 
 			fillInCircuitBreakers(cluster, r.defaultMaxConcurrentRetries)
 
+			fillInHealthCheck(cluster, r.defaultHealthCheck)
+
 			// Fill in EDS config source if unset
 			if enum := cluster.GetType(); enum == envoy_config_cluster.Cluster_EDS {
 				if cluster.EdsClusterConfig == nil {
@@ -456,7 +512,10 @@ func (r *CECResourceParser) ParseResources(cecNamespace string, cecName string,
 					resources.PortAllocationCallbacks[listenerName] = func(_ context.Context) error { return r.portAllocator.ReleaseProxyPort(listenerName) }
 				}
 
-				listener.Address, listener.AdditionalAddresses = envoy.GetLocalListenerAddresses(port, option.Config.IPv4Enabled(), option.Config.IPv6Enabled())
+				listener.Address, listener.AdditionalAddresses, err = envoy.GetLocalListenerAddresses(port, option.Config.IPv4Enabled(), option.Config.IPv6Enabled())
+				if err != nil {
+					return envoy.Resources{}, fmt.Errorf("listener address generation for %q failed: %w", listenerName, err)
+				}
 			}
 
 			// Inject Cilium bpf metadata listener filter, if not already present.
@@ -556,6 +615,23 @@ func (r *CECResourceParser) ParseResources(cecNamespace string, cecName string,
 		}
 	}
 
+	if sharedClusterRef {
+		// Add the shared ORIGINAL_DST egress cluster so that the references
+		// resolve without the CEC defining it. Defined clusters get their
+		// names qualified above, so a clash can only occur if the CEC spells
+		// out the unqualified shared name itself.
+		defined := false
+		for i := range resources.Clusters {
+			if resources.Clusters[i].Name == r.originalDstClusterName {
+				defined = true
+				break
+			}
+		}
+		if !defined {
+			resources.Clusters = append(resources.Clusters, envoy.GetOriginalDstEgressCluster(r.originalDstClusterName, r.originalDstAllowHeaderOverride))
+		}
+	}
+
 	return resources, nil
 }
 
@@ -692,17 +768,30 @@ func validateEDSEndpoints(namespace, name string, eds *envoy_config_endpoint.Clu
 	return nil
 }
 
-func qualifyTcpProxyResourceNames(namespace, name string, tcpProxy *envoy_config_tcp.TcpProxy) (updated bool) {
+// qualifyClusterRef qualifies a cluster reference with the CEC namespace and
+// name. References to the shared ORIGINAL_DST egress cluster are passed
+// through unchanged, as that cluster exists outside of any CEC; such
+// references are recorded in 'sharedClusterRef' so that the shared cluster can
+// be added to the parsed resources.
+func (r *CECResourceParser) qualifyClusterRef(namespace, name, clusterName string, sharedClusterRef *bool) (string, bool) {
+	if r.originalDstClusterName != "" && clusterName == r.originalDstClusterName {
+		*sharedClusterRef = true
+		return clusterName, false
+	}
+	return api.ResourceQualifiedName(namespace, name, clusterName)
+}
+
+func (r *CECResourceParser) qualifyTcpProxyResourceNames(namespace, name string, tcpProxy *envoy_config_tcp.TcpProxy, sharedClusterRef *bool) (updated bool) {
 	switch c := tcpProxy.GetClusterSpecifier().(type) {
 	case *envoy_config_tcp.TcpProxy_Cluster:
 		if c != nil {
-			c.Cluster, updated = api.ResourceQualifiedName(namespace, name, c.Cluster)
+			c.Cluster, updated = r.qualifyClusterRef(namespace, name, c.Cluster, sharedClusterRef)
 		}
 	case *envoy_config_tcp.TcpProxy_WeightedClusters:
 		if c != nil {
 			for _, wc := range c.WeightedClusters.Clusters {
 				var nameUpdated bool
-				wc.Name, nameUpdated = api.ResourceQualifiedName(namespace, name, wc.Name)
+				wc.Name, nameUpdated = r.qualifyClusterRef(namespace, name, wc.Name, sharedClusterRef)
 				if nameUpdated {
 					updated = true
 				}
@@ -712,7 +801,7 @@ func qualifyTcpProxyResourceNames(namespace, name string, tcpProxy *envoy_config
 	return updated
 }
 
-func qualifyRouteConfigurationResourceNames(namespace, name string, routeConfig *envoy_config_route.RouteConfiguration) (updated bool) {
+func (r *CECResourceParser) qualifyRouteConfigurationResourceNames(namespace, name string, routeConfig *envoy_config_route.RouteConfiguration, sharedClusterRef *bool) (updated bool) {
 	// Strictly not a reference, and may be an empty string
 	routeConfig.Name, updated = api.ResourceQualifiedName(namespace, name, routeConfig.Name, api.ForceNamespace)
 
@@ -725,14 +814,14 @@ func qualifyRouteConfigurationResourceNames(namespace, name string, routeConfig
 		for _, rt := range vhost.Routes {
 			if action := rt.GetRoute(); action != nil {
 				if clusterName := action.GetCluster(); clusterName != "" {
-					action.GetClusterSpecifier().(*envoy_config_route.RouteAction_Cluster).Cluster, nameUpdated = api.ResourceQualifiedName(namespace, name, clusterName)
+					action.GetClusterSpecifier().(*envoy_config_route.RouteAction_Cluster).Cluster, nameUpdated = r.qualifyClusterRef(namespace, name, clusterName, sharedClusterRef)
 					if nameUpdated {
 						updated = true
 					}
 				}
-				for _, r := range action.GetRequestMirrorPolicies() {
-					if clusterName := r.GetCluster(); clusterName != "" {
-						r.Cluster, nameUpdated = api.ResourceQualifiedName(namespace, name, clusterName)
+				for _, mirror := range action.GetRequestMirrorPolicies() {
+					if clusterName := mirror.GetCluster(); clusterName != "" {
+						mirror.Cluster, nameUpdated = r.qualifyClusterRef(namespace, name, clusterName, sharedClusterRef)
 						if nameUpdated {
 							updated = true
 						}
@@ -740,7 +829,7 @@ func qualifyRouteConfigurationResourceNames(namespace, name string, routeConfig
 				}
 				if weightedClusters := action.GetWeightedClusters(); weightedClusters != nil {
 					for _, cluster := range weightedClusters.GetClusters() {
-						cluster.Name, nameUpdated = api.ResourceQualifiedName(namespace, name, cluster.Name)
+						cluster.Name, nameUpdated = r.qualifyClusterRef(namespace, name, cluster.Name, sharedClusterRef)
 						if nameUpdated {
 							updated = true
 						}
@@ -752,6 +841,15 @@ func qualifyRouteConfigurationResourceNames(namespace, name string, routeConfig
 	return updated
 }
 
+// ciliumL7Filter returns the Cilium HTTP filter configured from the agent
+// options, as injected into CEC-supplied filter chains.
+func ciliumL7Filter() *envoy_config_http.HttpFilter {
+	return envoy.GetCiliumHttpFilter(
+		envoy.GetAccessLogSocketPath(envoy.GetSocketDir(option.Config.RunDir)),
+		option.Config.HTTP403Message,
+	)
+}
+
 // injectCiliumL7Filter injects the Cilium HTTP filter just before the HTTP Router filter
 func injectCiliumL7Filter(hcmConfig *envoy_config_http.HttpConnectionManager) bool {
 	foundCiliumL7Filter := false
@@ -763,7 +861,7 @@ func injectCiliumL7Filter(hcmConfig *envoy_config_http.HttpConnectionManager) bo
 		case envoyRouterFilterName:
 			if !foundCiliumL7Filter {
 				hcmConfig.HttpFilters = append(hcmConfig.HttpFilters[:j+1], hcmConfig.HttpFilters[j:]...)
-				hcmConfig.HttpFilters[j] = envoy.GetCiliumHttpFilter()
+				hcmConfig.HttpFilters[j] = ciliumL7Filter()
 				return true
 			}
 		}
@@ -841,9 +939,9 @@ func injectCiliumUpstreamL7Filter(opts *envoy_config_upstream.HttpProtocolOption
 		j := codecFilterIndex
 		if j >= 0 {
 			filters = append(filters[:j+1], filters[j:]...)
-			filters[j] = envoy.GetCiliumHttpFilter()
+			filters[j] = ciliumL7Filter()
 		} else {
-			filters = append(filters, envoy.GetCiliumHttpFilter())
+			filters = append(filters, ciliumL7Filter())
 		}
 		changed = true
 	}
@@ -963,6 +1061,41 @@ func fillInCircuitBreakers(cluster *envoy_config_cluster.Cluster, defaultConcurr
 	}
 }
 
+// fillInHealthCheck injects a default active health check into clusters that don't
+// define one. Explicit user configuration is never overridden, and ORIGINAL_DST
+// clusters are skipped as their upstream hosts are not known up front. HTTP
+// clusters, recognized by the presence of HTTP protocol options, get an HTTP
+// health check; all others get a plain TCP connect check.
+func fillInHealthCheck(cluster *envoy_config_cluster.Cluster, defaults healthCheckDefaults) {
+	if !defaults.enabled || len(cluster.HealthChecks) > 0 {
+		return
+	}
+	if cluster.GetType() == envoy_config_cluster.Cluster_ORIGINAL_DST {
+		return
+	}
+
+	healthCheck := &envoy_config_core.HealthCheck{
+		Interval:           durationpb.New(defaults.interval),
+		Timeout:            durationpb.New(defaults.timeout),
+		HealthyThreshold:   &wrapperspb.UInt32Value{Value: defaults.healthyThreshold},
+		UnhealthyThreshold: &wrapperspb.UInt32Value{Value: defaults.unhealthyThreshold},
+	}
+
+	if _, isHTTP := cluster.TypedExtensionProtocolOptions[httpProtocolOptionsType]; isHTTP {
+		healthCheck.HealthChecker = &envoy_config_core.HealthCheck_HttpHealthCheck_{
+			HttpHealthCheck: &envoy_config_core.HealthCheck_HttpHealthCheck{
+				Path: defaults.httpPath,
+			},
+		}
+	} else {
+		healthCheck.HealthChecker = &envoy_config_core.HealthCheck_TcpHealthCheck_{
+			TcpHealthCheck: &envoy_config_core.HealthCheck_TcpHealthCheck{},
+		}
+	}
+
+	cluster.HealthChecks = []*envoy_config_core.HealthCheck{healthCheck}
+}
+
 func toAny(message proto.Message) *anypb.Any {
 	a, err := anypb.New(message)
 	if err != nil {
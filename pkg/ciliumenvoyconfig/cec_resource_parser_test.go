@@ -15,6 +15,7 @@ import (
 	envoy_config_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_config_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoy_config_http_healthcheck "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/health_check/v3"
+	_ "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
 	envoy_upstream_codec "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/upstream_codec/v3"
 	envoy_config_http "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	envoy_config_tcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
@@ -22,6 +23,7 @@ import (
 	envoy_upstreams_http_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
@@ -30,6 +32,7 @@ import (
 	"github.com/cilium/cilium/pkg/envoy"
 	cilium_v2 "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2"
 	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/time"
 )
 
 type MockPort struct {
@@ -192,6 +195,78 @@ func TestUpstreamInject(t *testing.T) {
 	assert.ErrorContains(t, err, "filter after codec filter: name:\"cilium.l7policy\"")
 }
 
+func TestFillInHealthCheck(t *testing.T) {
+	defaults := healthCheckDefaults{
+		enabled:            true,
+		interval:           5 * time.Second,
+		timeout:            3 * time.Second,
+		healthyThreshold:   2,
+		unhealthyThreshold: 3,
+		httpPath:           "/healthz",
+	}
+
+	//
+	// TCP cluster gets a TCP connect health check
+	//
+	cluster := &envoy_config_cluster.Cluster{Name: "tcp-cluster"}
+	fillInHealthCheck(cluster, defaults)
+	require.Len(t, cluster.HealthChecks, 1)
+	hc := cluster.HealthChecks[0]
+	assert.Equal(t, 5*time.Second, hc.Interval.AsDuration())
+	assert.Equal(t, 3*time.Second, hc.Timeout.AsDuration())
+	assert.Equal(t, uint32(2), hc.HealthyThreshold.GetValue())
+	assert.Equal(t, uint32(3), hc.UnhealthyThreshold.GetValue())
+	assert.NotNil(t, hc.GetTcpHealthCheck())
+	assert.Nil(t, hc.GetHttpHealthCheck())
+
+	//
+	// HTTP cluster gets an HTTP health check with the configured path
+	//
+	cluster = &envoy_config_cluster.Cluster{
+		Name: "http-cluster",
+		TypedExtensionProtocolOptions: map[string]*anypb.Any{
+			httpProtocolOptionsType: toAny(&envoy_upstreams_http_v3.HttpProtocolOptions{}),
+		},
+	}
+	fillInHealthCheck(cluster, defaults)
+	require.Len(t, cluster.HealthChecks, 1)
+	require.NotNil(t, cluster.HealthChecks[0].GetHttpHealthCheck())
+	assert.Equal(t, "/healthz", cluster.HealthChecks[0].GetHttpHealthCheck().Path)
+
+	//
+	// Explicit health check configuration is not overridden
+	//
+	explicit := &envoy_config_core.HealthCheck{
+		HealthChecker: &envoy_config_core.HealthCheck_GrpcHealthCheck_{
+			GrpcHealthCheck: &envoy_config_core.HealthCheck_GrpcHealthCheck{},
+		},
+	}
+	cluster = &envoy_config_cluster.Cluster{
+		Name:         "explicit-cluster",
+		HealthChecks: []*envoy_config_core.HealthCheck{explicit},
+	}
+	fillInHealthCheck(cluster, defaults)
+	require.Len(t, cluster.HealthChecks, 1)
+	assert.Same(t, explicit, cluster.HealthChecks[0])
+
+	//
+	// ORIGINAL_DST clusters are skipped
+	//
+	cluster = &envoy_config_cluster.Cluster{
+		Name:                 "original-dst-cluster",
+		ClusterDiscoveryType: &envoy_config_cluster.Cluster_Type{Type: envoy_config_cluster.Cluster_ORIGINAL_DST},
+	}
+	fillInHealthCheck(cluster, defaults)
+	assert.Empty(t, cluster.HealthChecks)
+
+	//
+	// Nothing is injected when defaulting is disabled
+	//
+	cluster = &envoy_config_cluster.Cluster{Name: "tcp-cluster"}
+	fillInHealthCheck(cluster, healthCheckDefaults{})
+	assert.Empty(t, cluster.HealthChecks)
+}
+
 var xds1 = `version_info: "0"
 resources:
 - "@type": type.googleapis.com/envoy.config.listener.v3.Listener
@@ -440,6 +515,83 @@ func TestCiliumEnvoyConfigValidation(t *testing.T) {
 	assert.Error(t, err)
 }
 
+var ciliumEnvoyConfigLuaFilter = `apiVersion: cilium.io/v2
+kind: CiliumEnvoyConfig
+metadata:
+  name: envoy-lua-listener
+spec:
+  version_info: "0"
+  resources:
+  - "@type": type.googleapis.com/envoy.config.listener.v3.Listener
+    name: envoy-lua-listener
+    address:
+      socket_address:
+        address: 127.0.0.1
+        port_value: 10000
+    filter_chains:
+    - filters:
+      - name: envoy.filters.network.http_connection_manager
+        typed_config:
+          "@type": type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager
+          stat_prefix: ingress_http
+          codec_type: AUTO
+          rds:
+            route_config_name: local_route
+          http_filters:
+          - name: envoy.filters.http.lua
+            typed_config:
+              "@type": type.googleapis.com/envoy.extensions.filters.http.lua.v3.Lua
+              inline_code: "function envoy_on_request(request_handle) end"
+          - name: envoy.filters.http.router
+`
+
+func TestCiliumEnvoyConfigFilterPolicy(t *testing.T) {
+	jsonBytes, err := yaml.YAMLToJSON([]byte(ciliumEnvoyConfigLuaFilter))
+	require.NoError(t, err)
+	cec := &cilium_v2.CiliumEnvoyConfig{}
+	err = json.Unmarshal(jsonBytes, cec)
+	require.NoError(t, err)
+
+	newParser := func(allowed, denied []string) *CECResourceParser {
+		return &CECResourceParser{
+			logger:        hivetest.Logger(t),
+			portAllocator: NewMockPortAllocator(),
+			filterPolicy:  newFilterPolicy(allowed, denied),
+		}
+	}
+
+	// Without configured lists everything is allowed.
+	parser := newParser(nil, nil)
+	_, err = parser.ParseResources("namespace", "name", cec.Spec.Resources, false, false, false, true)
+	require.NoError(t, err)
+
+	// A denied HTTP filter nested in the HTTP connection manager rejects the
+	// whole CEC, with the error naming the offending filter.
+	parser = newParser(nil, []string{"envoy.filters.http.lua"})
+	_, err = parser.ParseResources("namespace", "name", cec.Spec.Resources, false, false, false, true)
+	require.ErrorContains(t, err, `HTTP filter "envoy.filters.http.lua" is denied by policy`)
+
+	// Denylist entries also match the typed config type URL.
+	parser = newParser(nil, []string{"type.googleapis.com/envoy.extensions.filters.http.lua.v3.Lua"})
+	_, err = parser.ParseResources("namespace", "name", cec.Spec.Resources, false, false, false, true)
+	require.ErrorContains(t, err, "denied by policy")
+
+	// An allowlist rejects extensions not on it.
+	parser = newParser([]string{"envoy.filters.network.http_connection_manager", "envoy.filters.http.router"}, nil)
+	_, err = parser.ParseResources("namespace", "name", cec.Spec.Resources, false, false, false, true)
+	require.ErrorContains(t, err, "is not allowed by policy")
+
+	// ...and passes once the filter is listed.
+	parser = newParser([]string{"envoy.filters.network.http_connection_manager", "envoy.filters.http.router", "envoy.filters.http.lua"}, nil)
+	_, err = parser.ParseResources("namespace", "name", cec.Spec.Resources, false, false, false, true)
+	require.NoError(t, err)
+
+	// Old resources are already applied and are not validated.
+	parser = newParser(nil, []string{"envoy.filters.http.lua"})
+	_, err = parser.ParseResources("namespace", "name", cec.Spec.Resources, false, false, false, false)
+	require.NoError(t, err)
+}
+
 var ciliumEnvoyConfigNoAddress = `apiVersion: cilium.io/v2
 kind: CiliumEnvoyConfig
 metadata:
@@ -1786,3 +1938,82 @@ func Test_injectCiliumEnvoyFilters(t *testing.T) {
 		})
 	}
 }
+
+var ciliumEnvoyConfigOriginalDstCluster = `apiVersion: cilium.io/v2
+kind: CiliumEnvoyConfig
+metadata:
+  name: envoy-original-dst-listener
+spec:
+  resources:
+  - "@type": type.googleapis.com/envoy.config.listener.v3.Listener
+    name: envoy-original-dst-listener
+    filter_chains:
+    - filters:
+      - name: envoy.filters.network.http_connection_manager
+        typed_config:
+          "@type": type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager
+          stat_prefix: proxy
+          route_config:
+            virtual_hosts:
+            - name: "proxy-route"
+              domains: ["*"]
+              routes:
+              - match:
+                  prefix: "/"
+                route:
+                  cluster: egress-cluster-original-dst
+          http_filters:
+          - name: envoy.filters.http.router
+`
+
+func TestCiliumEnvoyConfigOriginalDstCluster(t *testing.T) {
+	parser := CECResourceParser{
+		logger:                 hivetest.Logger(t),
+		portAllocator:          NewMockPortAllocator(),
+		originalDstClusterName: envoy.OriginalDstEgressClusterName,
+	}
+
+	jsonBytes, err := yaml.YAMLToJSON([]byte(ciliumEnvoyConfigOriginalDstCluster))
+	require.NoError(t, err)
+	cec := &cilium_v2.CiliumEnvoyConfig{}
+	err = json.Unmarshal(jsonBytes, cec)
+	require.NoError(t, err)
+
+	resources, err := parser.ParseResources("namespace", "name", cec.Spec.Resources, true, false, true, true)
+	require.NoError(t, err)
+	require.Len(t, resources.Listeners, 1)
+
+	// The shared cluster is added even though the CEC does not define it.
+	require.Len(t, resources.Clusters, 1)
+	cluster := resources.Clusters[0]
+	assert.Equal(t, envoy.OriginalDstEgressClusterName, cluster.Name)
+	assert.Equal(t, envoy_config_cluster.Cluster_ORIGINAL_DST, cluster.GetType())
+	// Header-based upstream overrides are off by default.
+	assert.Nil(t, cluster.GetOriginalDstLbConfig())
+
+	// The route still references the shared cluster by its unqualified name.
+	message, err := resources.Listeners[0].FilterChains[0].Filters[1].GetTypedConfig().UnmarshalNew()
+	require.NoError(t, err)
+	hcm, ok := message.(*envoy_config_http.HttpConnectionManager)
+	require.True(t, ok)
+	assert.Equal(t, envoy.OriginalDstEgressClusterName, hcm.GetRouteConfig().VirtualHosts[0].Routes[0].GetRoute().GetCluster())
+
+	// With the override flag set, the cluster honors x-envoy-original-dst-host.
+	parser.originalDstAllowHeaderOverride = true
+	resources, err = parser.ParseResources("namespace", "name", cec.Spec.Resources, true, false, true, true)
+	require.NoError(t, err)
+	require.Len(t, resources.Clusters, 1)
+	assert.True(t, resources.Clusters[0].GetOriginalDstLbConfig().GetUseHttpHeader())
+
+	// With the shared cluster disabled, the reference is qualified as usual
+	// and no cluster is generated.
+	parser.originalDstClusterName = ""
+	resources, err = parser.ParseResources("namespace", "name", cec.Spec.Resources, true, false, true, true)
+	require.NoError(t, err)
+	assert.Empty(t, resources.Clusters)
+	message, err = resources.Listeners[0].FilterChains[0].Filters[1].GetTypedConfig().UnmarshalNew()
+	require.NoError(t, err)
+	hcm, ok = message.(*envoy_config_http.HttpConnectionManager)
+	require.True(t, ok)
+	assert.Equal(t, "namespace/name/"+envoy.OriginalDstEgressClusterName, hcm.GetRouteConfig().VirtualHosts[0].Routes[0].GetRoute().GetCluster())
+}
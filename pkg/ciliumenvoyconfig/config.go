@@ -6,15 +6,39 @@ package ciliumenvoyconfig
 import (
 	"github.com/spf13/pflag"
 
+	"github.com/cilium/cilium/pkg/envoy"
 	"github.com/cilium/cilium/pkg/time"
 )
 
 type CECConfig struct {
 	EnvoyConfigRetryInterval time.Duration
 	EnvoyConfigTimeout       time.Duration
+
+	EnvoyConfigDefaultHealthCheck                   bool
+	EnvoyConfigDefaultHealthCheckInterval           time.Duration
+	EnvoyConfigDefaultHealthCheckTimeout            time.Duration
+	EnvoyConfigDefaultHealthCheckHealthyThreshold   uint32
+	EnvoyConfigDefaultHealthCheckUnhealthyThreshold uint32
+	EnvoyConfigDefaultHealthCheckHttpPath           string
+
+	EnvoyConfigOriginalDstClusterName         string
+	EnvoyConfigOriginalDstAllowHeaderOverride bool
+
+	EnvoyConfigAllowedFilters []string
+	EnvoyConfigDeniedFilters  []string
 }
 
 func (r CECConfig) Flags(flags *pflag.FlagSet) {
 	flags.Duration("envoy-config-retry-interval", 15*time.Second, "Interval in which an attempt is made to reconcile failed EnvoyConfigs. If the duration is zero, the retry is deactivated.")
 	flags.Duration("envoy-config-timeout", 2*time.Minute, "Timeout that determines how long to wait for Envoy to N/ACK CiliumEnvoyConfig resources")
+	flags.Bool("envoy-config-default-health-check", false, "Inject a default active health check into CiliumEnvoyConfig clusters that don't define one. Explicit health check configuration and ORIGINAL_DST clusters are left untouched.")
+	flags.Duration("envoy-config-default-health-check-interval", 5*time.Second, "Interval between the default active health checks injected into CiliumEnvoyConfig clusters")
+	flags.Duration("envoy-config-default-health-check-timeout", 3*time.Second, "Timeout of the default active health checks injected into CiliumEnvoyConfig clusters")
+	flags.Uint32("envoy-config-default-health-check-healthy-threshold", 2, "Number of successful default health checks before an unhealthy host is marked healthy again")
+	flags.Uint32("envoy-config-default-health-check-unhealthy-threshold", 3, "Number of failed default health checks before a host is marked unhealthy")
+	flags.String("envoy-config-default-health-check-http-path", "/", "HTTP path requested by the default health checks injected into HTTP CiliumEnvoyConfig clusters")
+	flags.String("envoy-config-original-dst-cluster-name", envoy.OriginalDstEgressClusterName, "Name of the shared ORIGINAL_DST egress cluster that CiliumEnvoyConfig routes may reference without defining it. An empty name disables the shared cluster.")
+	flags.Bool("envoy-config-original-dst-allow-header-override", false, "Allow routing via the shared ORIGINAL_DST egress cluster to be overridden per request with the x-envoy-original-dst-host header. This lets HTTP clients steer requests to arbitrary backends, so only enable it if the routes referencing the cluster sanitize that header.")
+	flags.StringSlice("envoy-config-allowed-filters", nil, "List of Envoy filter and transport socket extensions, given by name or typed config type URL, permitted in CiliumEnvoyConfig resources. Extensions not on the list are rejected. An empty list permits all extensions.")
+	flags.StringSlice("envoy-config-denied-filters", nil, "List of Envoy filter and transport socket extensions, given by name or typed config type URL, rejected in CiliumEnvoyConfig resources even if they appear in envoy-config-allowed-filters")
 }
@@ -176,8 +176,9 @@ func (rc *remoteCluster) Status() *models.RemoteCluster {
 	}
 
 	if rc.remoteIdentityCache != nil {
-		status.NumIdentities = int64(rc.remoteIdentityCache.NumEntries())
-		status.Synced.Identities = rc.remoteIdentityCache.Synced()
+		identities := rc.remoteIdentityCache.Status()
+		status.NumIdentities = int64(identities.NumEntries)
+		status.Synced.Identities = identities.Synced
 	}
 
 	status.Ready = status.Ready &&
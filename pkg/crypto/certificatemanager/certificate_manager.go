@@ -9,11 +9,13 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/cilium/hive/cell"
 	"github.com/spf13/pflag"
 
 	k8sClient "github.com/cilium/cilium/pkg/k8s/client"
+	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging/logfields"
 	"github.com/cilium/cilium/pkg/policy/api"
 )
@@ -36,6 +38,12 @@ type SecretManager interface {
 	PolicySecretSyncEnabled() bool
 	SecretsOnlyFromSecretsNamespace() bool
 	GetSecretSyncNamespace() string
+
+	// OnSecretRotated registers a callback to be invoked whenever the named
+	// secret is rotated. Multiple callbacks may be registered for the same
+	// secret; they are invoked in registration order, without any manager
+	// lock held. Callbacks cannot be unregistered.
+	OnSecretRotated(secretName string, callback func())
 }
 
 var defaultManagerConfig = managerConfig{
@@ -69,6 +77,13 @@ type manager struct {
 	secretSyncNamespace            string
 	secretSyncEnabled              bool
 	secretsFromSecretSyncNamespace bool
+
+	// rotationMutex protects rotationCallbacks.
+	rotationMutex lock.Mutex
+
+	// rotationCallbacks are the callbacks registered via OnSecretRotated,
+	// indexed by secret name.
+	rotationCallbacks map[string][]func()
 }
 
 // NewManager returns a new manager.
@@ -79,6 +94,7 @@ func NewManager(cfg managerConfig, clientset k8sClient.Clientset, logger *slog.L
 		Logger:                         logger,
 		secretSyncEnabled:              cfg.EnablePolicySecretsSync,
 		secretsFromSecretSyncNamespace: cfg.PolicySecretsOnlyFromSecretsNamespace,
+		rotationCallbacks:              map[string][]func(){},
 	}
 
 	if cfg.PolicySecretsOnlyFromSecretsNamespace {
@@ -106,6 +122,28 @@ func (m *manager) SecretsOnlyFromSecretsNamespace() bool {
 	return m.secretsFromSecretSyncNamespace
 }
 
+// OnSecretRotated registers a callback to be invoked whenever the named
+// secret is rotated.
+func (m *manager) OnSecretRotated(secretName string, callback func()) {
+	m.rotationMutex.Lock()
+	defer m.rotationMutex.Unlock()
+	m.rotationCallbacks[secretName] = append(m.rotationCallbacks[secretName], callback)
+}
+
+// NotifySecretRotated invokes the callbacks registered for the named secret.
+// It is intended to be called by the secret synchronization machinery when
+// the content of a secret changes. The callbacks are invoked synchronously,
+// without the manager lock held.
+func (m *manager) NotifySecretRotated(secretName string) {
+	m.rotationMutex.Lock()
+	callbacks := slices.Clone(m.rotationCallbacks[secretName])
+	m.rotationMutex.Unlock()
+
+	for _, callback := range callbacks {
+		callback()
+	}
+}
+
 // getSecrets returns either local or k8s secrets, giving precedence for local secrets if configured.
 // It also returns a boolean indicating if the values were read from disk or not.
 // The 'ns' parameter is used as the secret namespace if 'secret.Namespace' is an empty string, and is
@@ -49,3 +49,5 @@ func (m mockSecretManager) SecretsOnlyFromSecretsNamespace() bool {
 func (m mockSecretManager) GetSecretSyncNamespace() string {
 	return ""
 }
+
+func (m mockSecretManager) OnSecretRotated(secretName string, callback func()) {}
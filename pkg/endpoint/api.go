@@ -441,6 +441,8 @@ func (e *Endpoint) GetPolicyModel() *models.EndpointPolicyStatus {
 	for _, stats := range e.proxyStatistics {
 		proxyStats = append(proxyStats, stats.DeepCopy())
 	}
+	proxyPolicyIngress := e.proxyPolicyIngress
+	proxyPolicyEgress := e.proxyPolicyEgress
 	e.proxyStatisticsMutex.RUnlock()
 	sortProxyStats(proxyStats)
 
@@ -486,6 +488,8 @@ func (e *Endpoint) GetPolicyModel() *models.EndpointPolicyStatus {
 		Realized:            mdl,
 		ProxyPolicyRevision: int64(e.proxyPolicyRevision),
 		ProxyStatistics:     proxyStats,
+		ProxyPolicyIngress:  proxyPolicyIngress,
+		ProxyPolicyEgress:   proxyPolicyEgress,
 	}
 }
 
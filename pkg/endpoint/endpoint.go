@@ -350,6 +350,12 @@ type Endpoint struct {
 	// the proxy.
 	proxyPolicyRevision uint64
 
+	// ingressVisibilityPorts and egressVisibilityPorts are the ports that are
+	// redirected to the proxy for L7 visibility only, without policy
+	// enforcement. The endpoint mutex must be held to read or write them.
+	ingressVisibilityPorts []uint16
+	egressVisibilityPorts  []uint16
+
 	// proxyStatisticsMutex is the mutex that must be held to read or write
 	// proxyStatistics.
 	// No other locks may be taken while holding proxyStatisticsMutex.
@@ -362,6 +368,12 @@ type Endpoint struct {
 	// You must hold Endpoint.proxyStatisticsMutex to read or write it.
 	proxyStatistics map[string]*models.ProxyStatistics
 
+	// proxyPolicyIngress and proxyPolicyEgress are the per-direction counts
+	// of the network policy last pushed to the proxy for this endpoint.
+	// You must hold Endpoint.proxyStatisticsMutex to read or write them.
+	proxyPolicyIngress *models.ProxyPolicyCounts
+	proxyPolicyEgress  *models.ProxyPolicyCounts
+
 	// nextPolicyRevision is the policy revision that the endpoint has
 	// updated to and that will become effective with the next regenerate.
 	// Must hold the endpoint mutex *and* buildMutex to write, and either to read.
@@ -1631,6 +1643,26 @@ func (e *Endpoint) GetListenerProxyPort(listener string) uint16 {
 	return e.proxy.GetListenerProxyPort(listener)
 }
 
+// GetVisibilityPorts returns the ports in the given traffic direction that
+// are redirected to the proxy for L7 visibility only.
+// Must be called with the endpoint's read lock taken.
+func (e *Endpoint) GetVisibilityPorts(ingress bool) []uint16 {
+	if ingress {
+		return e.ingressVisibilityPorts
+	}
+	return e.egressVisibilityPorts
+}
+
+// SetVisibilityPorts sets the ports that are redirected to the proxy for L7
+// visibility only in each traffic direction.
+func (e *Endpoint) SetVisibilityPorts(ingress, egress []uint16) {
+	// NOTE: unconditionalLock is used here because this method has no way of reporting an error
+	e.unconditionalLock()
+	e.ingressVisibilityPorts = ingress
+	e.egressVisibilityPorts = egress
+	e.unlock()
+}
+
 // getProxyStatistics gets the ProxyStatistics for the flows with the
 // given characteristics, or adds a new one and returns it.
 func (e *Endpoint) getProxyStatistics(key string, l7Protocol string, port uint16, ingress bool, redirectPort uint16) *models.ProxyStatistics {
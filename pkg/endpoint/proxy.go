@@ -7,8 +7,10 @@ import (
 	"context"
 	"reflect"
 
+	"github.com/cilium/cilium/api/v1/models"
 	"github.com/cilium/cilium/pkg/completion"
 	"github.com/cilium/cilium/pkg/identity"
+	"github.com/cilium/cilium/pkg/labels"
 	"github.com/cilium/cilium/pkg/policy"
 	"github.com/cilium/cilium/pkg/proxy/endpoint"
 	"github.com/cilium/cilium/pkg/revert"
@@ -25,6 +27,34 @@ type EndpointProxy interface {
 	GetListenerProxyPort(listener string) uint16
 }
 
+// GetIdentityLabels returns the sorted labels of the endpoint's security
+// identity, or nil if the endpoint has no identity yet.
+// Must be called with the endpoint's read lock taken.
+func (e *Endpoint) GetIdentityLabels() labels.LabelArray {
+	if e.SecurityIdentity == nil {
+		return nil
+	}
+	return e.SecurityIdentity.LabelArray
+}
+
+// OnNetworkPolicyStatsUpdate records the per-direction counts of the network
+// policy pushed to the proxy, for exposure in the endpoint API model.
+func (e *Endpoint) OnNetworkPolicyStatsUpdate(ingress, egress endpoint.NetworkPolicyStats) {
+	e.proxyStatisticsMutex.Lock()
+	e.proxyPolicyIngress = proxyPolicyCountsModel(ingress)
+	e.proxyPolicyEgress = proxyPolicyCountsModel(egress)
+	e.proxyStatisticsMutex.Unlock()
+}
+
+func proxyPolicyCountsModel(stats endpoint.NetworkPolicyStats) *models.ProxyPolicyCounts {
+	return &models.ProxyPolicyCounts{
+		PortPolicies:     stats.PortPolicies,
+		Rules:            stats.Rules,
+		RemoteIdentities: stats.RemoteIdentities,
+		L7Rules:          stats.L7Rules,
+	}
+}
+
 func (e *Endpoint) removeNetworkPolicy() {
 	if e.IsProxyDisabled() {
 		return
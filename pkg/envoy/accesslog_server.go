@@ -39,7 +39,7 @@ func newAccessLogServer(logger *slog.Logger, accessLogger accesslog.ProxyAccessL
 	return &AccessLogServer{
 		logger:             logger,
 		accessLogger:       accessLogger,
-		socketPath:         getAccessLogSocketPath(envoySocketDir),
+		socketPath:         GetAccessLogSocketPath(envoySocketDir),
 		proxyGID:           proxyGID,
 		localEndpointStore: localEndpointStore,
 		bufferSize:         bufferSize,
@@ -7,14 +7,20 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
 	"path/filepath"
 	"runtime/pprof"
 
 	"github.com/cilium/hive/cell"
 	"github.com/cilium/hive/job"
+	"github.com/cilium/statedb"
+	envoy_config_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel"
 
 	"github.com/cilium/cilium/pkg/crypto/certificatemanager"
+	"github.com/cilium/cilium/pkg/dynamicconfig"
 	"github.com/cilium/cilium/pkg/endpointstate"
 	envoypolicy "github.com/cilium/cilium/pkg/envoy/policy"
 	"github.com/cilium/cilium/pkg/envoy/xds"
@@ -75,12 +81,45 @@ type ProxyConfig struct {
 	HTTPMaxGRPCTimeout                uint
 	HTTPRetryCount                    uint
 	HTTPRetryTimeout                  uint
+	HTTPRetryBufferLimitBytes         uint32
 	HTTPStreamIdleTimeout             uint
+	HTTPMaxRequestHeadersKb           uint32
+	HTTPMaxResponseHeadersKb          uint32
+	HTTPForwardTrailers               bool
+	HTTPRequestMirrorCluster          string
+	HTTPRequestMirrorPercent          uint32
 	UseFullTLSContext                 bool
 	ProxyXffNumTrustedHopsIngress     uint32
 	ProxyXffNumTrustedHopsEgress      uint32
+	MayUseOriginalSourceAddrIngress   bool
+	MayUseOriginalSourceAddrEgress    bool
 	EnvoyPolicyRestoreTimeout         time.Duration
 	EnvoyHTTPUpstreamLingerTimeout    int
+	EnvoyNetworkPolicyMaxSize         int
+	EnvoyNetworkPolicyEndpointLabels  bool
+	EnvoyXDSSocketMode                string
+	EnvoyInternalListeners            bool
+	EnvoyAlpnChainSelection           bool
+	EnvoyPerListenerStatPrefix        bool
+	EnvoyConfigDebugDump              bool
+	EnvoyTLSMinVersion                string
+	EnvoyTLSCipherSuites              []string
+	EnvoyTLSUpstreamSNI               string
+	EnvoyTLSUpstreamALPN              []string
+	EnvoyTLSUpstreamMaxSessionKeys    int
+	EnvoyTLSUpstreamKeylogPath        string
+	EnvoyTransactionalResourceUpserts bool
+	EnvoyPolicyCoalesceWindow         time.Duration
+	EnvoyMetricsListenerCompression   bool
+	EnvoyTCPProxyTunnelMode           string
+	EnvoyTCPProxyTunnelHost           string
+	EnvoyTCPProxyTunnelCluster        string
+
+	EnvoyL7LBOutlierDetection                   bool
+	EnvoyL7LBOutlierDetectionConsecutive5xx     uint32
+	EnvoyL7LBOutlierDetectionInterval           time.Duration
+	EnvoyL7LBOutlierDetectionBaseEjectionTime   time.Duration
+	EnvoyL7LBOutlierDetectionMaxEjectionPercent uint32
 }
 
 func (r ProxyConfig) Flags(flags *pflag.FlagSet) {
@@ -105,14 +144,48 @@ func (r ProxyConfig) Flags(flags *pflag.FlagSet) {
 	flags.Uint("http-max-grpc-timeout", 0, "Time after which a forwarded gRPC request is considered failed unless completed (in seconds). A \"grpc-timeout\" header may override this with a shorter value; defaults to 0 (unlimited)")
 	flags.Uint("http-retry-count", 3, "Number of retries performed after a forwarded request attempt fails")
 	flags.Uint("http-retry-timeout", 0, "Time after which a forwarded but uncompleted request is retried (connection failures are retried immediately); defaults to 0 (never)")
+	flags.Uint32("http-retry-buffer-limit-bytes", 0, "Buffer up to this many bytes of each HTTP request handled by the L7 policy enforcement Envoy listeners, so that retries can replay the request body. Default 0 (no buffering; retries of requests with a partially forwarded body fail)")
 	flags.Uint("http-stream-idle-timeout", 5*60, "Set Envoy the amount of time that the connection manager will allow a stream to exist with no upstream or downstream activity. Default 300s")
+	flags.Uint32("http-max-request-headers-kb", 60, "Maximum total size of HTTP request headers accepted by the proxy, in KiB. Requests with larger headers are rejected with a 431. Default 60 (the Envoy default); use 0 to keep the Envoy default explicitly.")
+	flags.Uint32("http-max-response-headers-kb", 0, "Maximum total size of HTTP response headers accepted by the proxy, in KiB. Responses with larger headers are dropped with a 502. Default 0 (unlimited)")
+	flags.Bool("http-forward-trailers", false, "Forward HTTP/1.1 trailers, e.g. gRPC status trailers, through the L7 policy enforcement Envoy listeners. Without this, trailers are silently dropped. Trailers on HTTP/2 are forwarded regardless")
+	flags.String("http-request-mirror-cluster", "", "Name of the shadow cluster to mirror HTTP requests handled by the L7 policy enforcement Envoy listeners to. The mirrored requests are fire-and-forget and do not affect the response returned to the client. Empty disables mirroring")
+	flags.Uint32("http-request-mirror-percent", 100, "Percentage of HTTP requests mirrored to the cluster given in http-request-mirror-cluster. Values above 100 are treated as 100")
 	// This should default to false in 1.16+ (i.e., we don't implement buggy behaviour) and true in 1.15 and earlier (i.e., we keep compatibility with an existing bug).
 	flags.Bool("use-full-tls-context", false, "If enabled, persist ca.crt keys into the Envoy config even in a terminatingTLS block on an L7 Cilium Policy. This is to enable compatibility with previously buggy behaviour. This flag is deprecated and will be removed in a future release.")
 	flags.Uint32("proxy-xff-num-trusted-hops-ingress", 0, "Number of trusted hops regarding the x-forwarded-for and related HTTP headers for the ingress L7 policy enforcement Envoy listeners.")
 	flags.Uint32("proxy-xff-num-trusted-hops-egress", 0, "Number of trusted hops regarding the x-forwarded-for and related HTTP headers for the egress L7 policy enforcement Envoy listeners.")
+	flags.Bool("may-use-original-source-addr-ingress", false, "Use the original source address for connections upstream of ingress L7 policy enforcement Envoy listeners, if supported by the datapath.")
+	flags.Bool("may-use-original-source-addr-egress", true, "Use the original source address for connections upstream of egress L7 policy enforcement Envoy listeners, if supported by the datapath.")
 	flags.Duration("envoy-policy-restore-timeout", 3*time.Minute, "Maxiumum time to wait for enpoint policy restoration before starting serving resources to Envoy")
 	flags.Int("envoy-http-upstream-linger-timeout", -1, "Time in seconds to block Envoy worker thread while an upstream HTTP connection is closing. "+
 		"If set to 0, the connection is closed immediately (with TCP RST). If set to -1, the connection is closed asynchronously in the background.")
+	flags.Int("envoy-network-policy-max-size", 0, "Maximum serialized size in bytes of a network policy resource sent to Envoy. Policies exceeding the limit "+
+		"have the remote identities of their allow rules collapsed to wildcard (L4-only enforcement) instead of failing the policy update. Default 0 (no limit)")
+	flags.Bool("envoy-network-policy-endpoint-labels", false, "Include the endpoint's sorted identity labels in the network policy resources sent to Envoy, "+
+		"so that the cilium Envoy filter can enrich access logs with them. Increases the size of the network policy resources.")
+	flags.Bool("envoy-internal-listeners", false, "Experimental: Create L7 policy redirect listeners as Envoy internal listeners instead of binding a TCP port on loopback. Requires traffic to be handed to Envoy via the matching internal-address clusters.")
+	flags.Bool("envoy-alpn-chain-selection", false, "Route TLS connections that negotiate an HTTP protocol via ALPN into an HTTP filter chain on listeners created for non-HTTP parsers, to gain L7 visibility for such connections.")
+	flags.Bool("envoy-per-listener-stat-prefix", false, "Namespace the Envoy stats of L7 policy enforcement listeners by listener name instead of merging them under the shared \"proxy\" and \"tcp_proxy\" prefixes. Note that enabling this changes the names of the emitted Envoy stats.")
+	flags.Bool("envoy-config-debug-dump", false, "Dump every Envoy resource pushed through the xDS mutators as timestamped protojson files under <rundir>/envoy-debug/, keeping the last few versions per resource")
+	flags.String("envoy-tls-min-version", "", "Minimum TLS protocol version accepted on TLS connections terminated or originated by the proxy, given as an Envoy TlsProtocol enum value name (e.g. \"TLSv1_2\"). Policies may still require a higher minimum for individual rules. Empty keeps the Envoy default.")
+	flags.StringSlice("envoy-tls-cipher-suites", nil, "Cipher suites allowed on TLS 1.0-1.2 connections terminated or originated by the proxy, unless the policy rule lists its own cipher suites. Empty keeps the Envoy defaults.")
+	flags.String("envoy-tls-upstream-sni", "", "Static SNI set on TLS connections originated by the TLS egress/ingress clusters of the embedded Envoy. Setting this or any other envoy-tls-upstream-* override replaces the cilium TLS wrapper transport socket of these clusters, so the TLS parameters of the network policy no longer apply. Empty keeps the current behavior.")
+	flags.StringSlice("envoy-tls-upstream-alpn", nil, "ALPN protocols offered on TLS connections originated by the TLS egress/ingress clusters of the embedded Envoy. See envoy-tls-upstream-sni for the effect of setting any envoy-tls-upstream-* override. Empty keeps the current behavior.")
+	flags.Int("envoy-tls-upstream-max-session-keys", -1, "Maximum number of TLS session keys cached for session resumption on connections originated by the TLS egress/ingress clusters of the embedded Envoy. Use 0 to disable session resumption. See envoy-tls-upstream-sni for the effect of setting any envoy-tls-upstream-* override. Default -1 keeps the current behavior.")
+	flags.String("envoy-tls-upstream-keylog-path", "", "Path to a file the TLS egress/ingress clusters of the embedded Envoy log TLS key material to, for debugging purposes only. See envoy-tls-upstream-sni for the effect of setting any envoy-tls-upstream-* override. Empty keeps the current behavior.")
+	flags.Bool("envoy-transactional-resource-upserts", false, "Commit the resources of each xDS type as a single cache transaction when upserting Envoy resources, so that Envoy observes the update in one DiscoveryResponse per type instead of one per resource")
+	flags.Duration("envoy-policy-coalesce-window", 0, "Window during which repeated network policy updates for the same endpoint are collapsed into a single push to Envoy, bounding the policy churn during large-scale identity changes. Updates waiting for an ACK bypass the window. Default 0 (disabled)")
+	flags.Bool("envoy-metrics-listener-compression", false, "Compress the responses of the Envoy prometheus metrics listener with gzip, reducing the scraping bandwidth for deployments with many time series")
+	flags.String("envoy-tcp-proxy-tunnel-mode", "", "Tunnel the TCP payload proxied by the L7 policy enforcement listeners through an HTTP proxy. \"pre_connect\" establishes the tunnel with an HTTP/1.1 CONNECT request, \"post_connect\" uses a POST request for proxies that do not accept CONNECT. Empty disables tunneling.")
+	flags.String("envoy-tcp-proxy-tunnel-host", "", "host:port the tunnel is requested for when envoy-tcp-proxy-tunnel-mode is set")
+	flags.String("envoy-tcp-proxy-tunnel-cluster", "", "Name of the cluster of the tunnel endpoint when envoy-tcp-proxy-tunnel-mode is set. Empty uses the payload cluster of each filter chain.")
+	flags.String("envoy-xds-socket-mode", xdsSocketModePath, "Socket mode for the xDS listener. \"path\" (default) binds a unix domain socket under the Envoy sockets directory, \"abstract\" binds a socket in the abstract namespace and \"inherited\" uses a LISTEN_FDS-style listener file descriptor inherited from the parent process. The latter two avoid sharing a filesystem path with the Envoy pod.")
+	flags.Bool("envoy-l7lb-outlier-detection", true, "Apply default outlier detection to Envoy clusters created for L7 load balancing. Clusters with explicit outlier detection configuration are left untouched. Set to false to disable the defaults entirely.")
+	flags.Uint32("envoy-l7lb-outlier-detection-consecutive-5xx", 5, "Number of consecutive 5xx responses before a L7 LB backend is ejected")
+	flags.Duration("envoy-l7lb-outlier-detection-interval", 10*time.Second, "Interval between the L7 LB outlier detection ejection analysis sweeps")
+	flags.Duration("envoy-l7lb-outlier-detection-base-ejection-time", 30*time.Second, "Base time a L7 LB backend is ejected for. The real time is this value multiplied by the number of times the backend has been ejected.")
+	flags.Uint32("envoy-l7lb-outlier-detection-max-ejection-percent", 10, "Maximum percentage of L7 LB backends that can be ejected at the same time")
 }
 
 type secretSyncConfig struct {
@@ -155,6 +228,16 @@ type xdsServerParams struct {
 
 	SecretManager certificatemanager.SecretManager
 	Metrics       *xds.XDSMetrics
+
+	JobRegistry job.Registry
+	Health      cell.Health
+
+	// DB and DynamicConfigTable back the watch for hot-reloadable proxy
+	// configuration changes. Optional so that hives without the dynamic
+	// config cell can still provide the xDS server.
+	DB                 *statedb.DB                                `optional:"true"`
+	DynamicConfigTable statedb.Table[dynamicconfig.DynamicConfig] `optional:"true"`
+	DynamicConfig      dynamicconfig.Config                       `optional:"true"`
 }
 
 func newEnvoyXDSServer(params xdsServerParams) (XDSServer, error) {
@@ -162,30 +245,99 @@ func newEnvoyXDSServer(params xdsServerParams) (XDSServer, error) {
 	// the xDS ConfigSource is used for CEC/CCEC.
 	CiliumXDSConfigSource.InitialFetchTimeout.Seconds = int64(params.EnvoyProxyConfig.ProxyInitialFetchTimeout)
 
+	debugDumpDir := ""
+	if params.EnvoyProxyConfig.EnvoyConfigDebugDump {
+		debugDumpDir = filepath.Join(option.Config.RunDir, "envoy-debug")
+	}
+
+	if v := params.EnvoyProxyConfig.EnvoyTLSMinVersion; v != "" {
+		if _, ok := envoy_config_tls.TlsParameters_TlsProtocol_value[v]; !ok {
+			return nil, fmt.Errorf("invalid envoy-tls-min-version %q: must be an Envoy TlsProtocol enum value name (e.g. \"TLSv1_2\")", v)
+		}
+	}
+
+	switch v := params.EnvoyProxyConfig.EnvoyTCPProxyTunnelMode; v {
+	case "", tcpTunnelModePreConnect, tcpTunnelModePostConnect:
+	default:
+		return nil, fmt.Errorf("invalid envoy-tcp-proxy-tunnel-mode %q: must be %q, %q or empty", v, tcpTunnelModePreConnect, tcpTunnelModePostConnect)
+	}
+	if params.EnvoyProxyConfig.EnvoyTCPProxyTunnelMode != "" && params.EnvoyProxyConfig.EnvoyTCPProxyTunnelHost == "" {
+		return nil, fmt.Errorf("envoy-tcp-proxy-tunnel-host must be set when envoy-tcp-proxy-tunnel-mode is set")
+	}
+
+	tlsUpstreamOverride := upstreamTLSOverrideConfig{
+		sni:           params.EnvoyProxyConfig.EnvoyTLSUpstreamSNI,
+		alpnProtocols: params.EnvoyProxyConfig.EnvoyTLSUpstreamALPN,
+		keyLogPath:    params.EnvoyProxyConfig.EnvoyTLSUpstreamKeylogPath,
+	}
+	if v := params.EnvoyProxyConfig.EnvoyTLSUpstreamMaxSessionKeys; v >= 0 {
+		if uint64(v) > math.MaxUint32 {
+			return nil, fmt.Errorf("invalid envoy-tls-upstream-max-session-keys %d: exceeds the maximum of %d", v, uint64(math.MaxUint32))
+		}
+		maxSessionKeys := uint32(v)
+		tlsUpstreamOverride.maxSessionKeys = &maxSessionKeys
+	} else if v != -1 {
+		return nil, fmt.Errorf("invalid envoy-tls-upstream-max-session-keys %d: must be -1 (keep the current behavior), 0 (disable session resumption) or positive", v)
+	}
+	if err := tlsUpstreamOverride.validate(); err != nil {
+		return nil, fmt.Errorf("invalid upstream TLS override configuration: %w", err)
+	}
+
 	xdsServer := newXDSServer(
 		params.Logger,
 		params.RestorerPromise,
 		params.IPCache,
 		params.LocalEndpointStore,
 		xdsServerConfig{
-			envoySocketDir:                GetSocketDir(option.Config.RunDir),
-			proxyGID:                      int(params.EnvoyProxyConfig.ProxyGID),
-			httpRequestTimeout:            int(params.EnvoyProxyConfig.HTTPRequestTimeout),
-			httpIdleTimeout:               params.EnvoyProxyConfig.ProxyIdleTimeoutSeconds,
-			httpMaxGRPCTimeout:            int(params.EnvoyProxyConfig.HTTPMaxGRPCTimeout),
-			httpRetryCount:                int(params.EnvoyProxyConfig.HTTPRetryCount),
-			httpRetryTimeout:              int(params.EnvoyProxyConfig.HTTPRetryTimeout),
-			httpStreamIdleTimeout:         int(params.EnvoyProxyConfig.HTTPStreamIdleTimeout),
-			httpNormalizePath:             params.EnvoyProxyConfig.HTTPNormalizePath,
-			useFullTLSContext:             params.EnvoyProxyConfig.UseFullTLSContext,
-			useSDS:                        params.SecretManager.PolicySecretSyncEnabled(),
-			proxyXffNumTrustedHopsIngress: params.EnvoyProxyConfig.ProxyXffNumTrustedHopsIngress,
-			proxyXffNumTrustedHopsEgress:  params.EnvoyProxyConfig.ProxyXffNumTrustedHopsEgress,
-			policyRestoreTimeout:          params.EnvoyProxyConfig.EnvoyPolicyRestoreTimeout,
-			metrics:                       params.Metrics,
-			httpLingerConfig:              params.EnvoyProxyConfig.EnvoyHTTPUpstreamLingerTimeout,
+			envoySocketDir:                  GetSocketDir(option.Config.RunDir),
+			xdsSocketMode:                   params.EnvoyProxyConfig.EnvoyXDSSocketMode,
+			proxyGID:                        int(params.EnvoyProxyConfig.ProxyGID),
+			httpRequestTimeout:              int(params.EnvoyProxyConfig.HTTPRequestTimeout),
+			httpIdleTimeout:                 params.EnvoyProxyConfig.ProxyIdleTimeoutSeconds,
+			httpMaxGRPCTimeout:              int(params.EnvoyProxyConfig.HTTPMaxGRPCTimeout),
+			httpRetryCount:                  int(params.EnvoyProxyConfig.HTTPRetryCount),
+			httpRetryTimeout:                int(params.EnvoyProxyConfig.HTTPRetryTimeout),
+			httpRetryBufferLimitBytes:       params.EnvoyProxyConfig.HTTPRetryBufferLimitBytes,
+			httpStreamIdleTimeout:           int(params.EnvoyProxyConfig.HTTPStreamIdleTimeout),
+			httpMaxRequestHeadersKb:         params.EnvoyProxyConfig.HTTPMaxRequestHeadersKb,
+			httpMaxResponseHeadersKb:        params.EnvoyProxyConfig.HTTPMaxResponseHeadersKb,
+			httpForwardTrailers:             params.EnvoyProxyConfig.HTTPForwardTrailers,
+			mirrorClusterName:               params.EnvoyProxyConfig.HTTPRequestMirrorCluster,
+			mirrorPercent:                   params.EnvoyProxyConfig.HTTPRequestMirrorPercent,
+			httpNormalizePath:               params.EnvoyProxyConfig.HTTPNormalizePath,
+			useFullTLSContext:               params.EnvoyProxyConfig.UseFullTLSContext,
+			useSDS:                          params.SecretManager.PolicySecretSyncEnabled(),
+			proxyXffNumTrustedHopsIngress:   params.EnvoyProxyConfig.ProxyXffNumTrustedHopsIngress,
+			proxyXffNumTrustedHopsEgress:    params.EnvoyProxyConfig.ProxyXffNumTrustedHopsEgress,
+			mayUseOriginalSourceAddrIngress: params.EnvoyProxyConfig.MayUseOriginalSourceAddrIngress,
+			mayUseOriginalSourceAddrEgress:  params.EnvoyProxyConfig.MayUseOriginalSourceAddrEgress,
+			policyRestoreTimeout:            params.EnvoyProxyConfig.EnvoyPolicyRestoreTimeout,
+			metrics:                         params.Metrics,
+			httpLingerConfig:                params.EnvoyProxyConfig.EnvoyHTTPUpstreamLingerTimeout,
+			networkPolicyMaxSize:            params.EnvoyProxyConfig.EnvoyNetworkPolicyMaxSize,
+			includeEndpointLabels:           params.EnvoyProxyConfig.EnvoyNetworkPolicyEndpointLabels,
+			useInternalListeners:            params.EnvoyProxyConfig.EnvoyInternalListeners,
+			useAlpnChainSelection:           params.EnvoyProxyConfig.EnvoyAlpnChainSelection,
+			useListenerStatPrefix:           params.EnvoyProxyConfig.EnvoyPerListenerStatPrefix,
+			debugDumpDir:                    debugDumpDir,
+			tlsMinVersion:                   params.EnvoyProxyConfig.EnvoyTLSMinVersion,
+			tlsCipherSuites:                 params.EnvoyProxyConfig.EnvoyTLSCipherSuites,
+			useTransactionalUpserts:         params.EnvoyProxyConfig.EnvoyTransactionalResourceUpserts,
+			policyCoalesceWindow:            params.EnvoyProxyConfig.EnvoyPolicyCoalesceWindow,
+			compressMetrics:                 params.EnvoyProxyConfig.EnvoyMetricsListenerCompression,
+			tcpProxyTunnelMode:              params.EnvoyProxyConfig.EnvoyTCPProxyTunnelMode,
+			tcpProxyTunnelHost:              params.EnvoyProxyConfig.EnvoyTCPProxyTunnelHost,
+			tcpProxyTunnelCluster:           params.EnvoyProxyConfig.EnvoyTCPProxyTunnelCluster,
+			l7lbOutlierDetection: outlierDetectionDefaults{
+				enabled:            params.EnvoyProxyConfig.EnvoyL7LBOutlierDetection,
+				consecutive5xx:     params.EnvoyProxyConfig.EnvoyL7LBOutlierDetectionConsecutive5xx,
+				interval:           params.EnvoyProxyConfig.EnvoyL7LBOutlierDetectionInterval,
+				baseEjectionTime:   params.EnvoyProxyConfig.EnvoyL7LBOutlierDetectionBaseEjectionTime,
+				maxEjectionPercent: params.EnvoyProxyConfig.EnvoyL7LBOutlierDetectionMaxEjectionPercent,
+			},
 		},
-		params.SecretManager)
+		params.SecretManager,
+		otel.Tracer("cilium/envoy"))
 
 	if !option.Config.EnableL7Proxy {
 		params.Logger.Debug("L7 proxies are disabled - not starting Envoy xDS server")
@@ -193,10 +345,20 @@ func newEnvoyXDSServer(params xdsServerParams) (XDSServer, error) {
 	}
 
 	params.Lifecycle.Append(cell.Hook{
-		OnStart: func(_ cell.HookContext) error {
+		OnStart: func(ctx cell.HookContext) error {
 			if err := xdsServer.start(); err != nil {
 				return fmt.Errorf("failed to start Envoy xDS server: %w", err)
 			}
+			// Register the shadow cluster referenced by the request mirror
+			// policies of the generated proxy listeners. No listener refers
+			// to it yet, so this does not wait for an ACK.
+			if name := params.EnvoyProxyConfig.HTTPRequestMirrorCluster; name != "" {
+				if err := xdsServer.UpsertEnvoyResources(ctx, Resources{
+					Clusters: []*envoy_config_cluster.Cluster{getMirrorClusterResource(name)},
+				}); err != nil {
+					return fmt.Errorf("failed to register request mirror cluster: %w", err)
+				}
+			}
 			return nil
 		},
 		OnStop: func(_ cell.HookContext) error {
@@ -205,6 +367,20 @@ func newEnvoyXDSServer(params xdsServerParams) (XDSServer, error) {
 		},
 	})
 
+	// Watch the agent configuration source for changes to the hot-reloadable
+	// proxy settings and apply them to the running server.
+	if params.DB != nil && params.DynamicConfigTable != nil && params.DynamicConfig.EnableDynamicConfig {
+		jobGroup := params.JobRegistry.NewGroup(
+			params.Health,
+			params.Lifecycle,
+			job.WithLogger(params.Logger),
+			job.WithPprofLabels(pprof.Labels("cell", "envoy")),
+		)
+		jobGroup.Add(job.OneShot("proxy-config-reload", func(ctx context.Context, _ cell.Health) error {
+			return xdsServer.watchProxyConfig(ctx, params.DB, params.DynamicConfigTable)
+		}))
+	}
+
 	if !option.Config.ExternalEnvoyProxy {
 		return &onDemandXdsStarter{
 			XDSServer:                xdsServer,
@@ -221,6 +397,8 @@ func newEnvoyXDSServer(params xdsServerParams) (XDSServer, error) {
 			maxConnectionDuration:    time.Duration(params.EnvoyProxyConfig.ProxyMaxConnectionDurationSeconds) * time.Second,
 			idleTimeout:              time.Duration(params.EnvoyProxyConfig.ProxyIdleTimeoutSeconds) * time.Second,
 			maxConcurrentRetries:     params.EnvoyProxyConfig.ProxyMaxConcurrentRetries,
+			xdsSocketMode:            params.EnvoyProxyConfig.EnvoyXDSSocketMode,
+			tlsUpstreamOverride:      tlsUpstreamOverride,
 		}, nil
 	}
 
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package envoy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/cilium/cilium/pkg/logging/logfields"
+)
+
+// xdsCacheDumpPath is the HTTP path under which the contents of the xDS caches
+// are exposed for debugging.
+const xdsCacheDumpPath = "/debug/envoy/xds-cache"
+
+// registerDebugHandlers registers the xDS cache dump handler on the default
+// HTTP mux. Only called when debug mode is enabled.
+func (s *xdsServer) registerDebugHandlers() {
+	http.HandleFunc(xdsCacheDumpPath, s.xdsCacheDumpHandler)
+}
+
+// xdsCacheDumpResource is one entry in the response of the xDS cache dump
+// handler.
+type xdsCacheDumpResource struct {
+	Name string `json:"name"`
+	// Resource is the protojson encoding of the cached proto message.
+	Resource json.RawMessage `json:"resource"`
+}
+
+// xdsCacheDumpHandler returns a JSON-marshaled list of all resources currently
+// in the xDS cache of the type given in the 'type' query parameter (e.g.,
+// ListenerTypeURL for LDS). The proto payloads are converted with protojson
+// for accurate proto-to-JSON conversion.
+func (s *xdsServer) xdsCacheDumpHandler(w http.ResponseWriter, r *http.Request) {
+	typeURL := r.URL.Query().Get("type")
+	config, ok := s.resourceConfig[typeURL]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown xDS type URL %q", typeURL), http.StatusBadRequest)
+		return
+	}
+
+	// Version 0 and no resource names returns all current resources.
+	res, err := config.Source.GetResources(typeURL, 0, "", nil)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to get resources for type URL %q: %s", typeURL, err), http.StatusInternalServerError)
+		return
+	}
+
+	dump := make([]xdsCacheDumpResource, 0, len(res.Resources))
+	for i, msg := range res.Resources {
+		data, err := protojson.Marshal(msg)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to marshal resource %q: %s", res.ResourceNames[i], err), http.StatusInternalServerError)
+			return
+		}
+		dump = append(dump, xdsCacheDumpResource{
+			Name:     res.ResourceNames[i],
+			Resource: data,
+		})
+	}
+	sort.Slice(dump, func(i, j int) bool { return dump[i].Name < dump[j].Name })
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(dump); err != nil {
+		s.logger.Warn("Failed to write xDS cache dump response",
+			logfields.Error, err,
+		)
+	}
+}
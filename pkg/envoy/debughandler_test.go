@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package envoy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cilium/hive/hivetest"
+	envoy_config_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cilium/cilium/pkg/envoy/xds"
+)
+
+func TestXdsCacheDumpHandler(t *testing.T) {
+	logger := hivetest.Logger(t)
+	ldsCache := xds.NewCache(logger)
+	s := &xdsServer{
+		logger: logger,
+		resourceConfig: map[string]*xds.ResourceTypeConfiguration{
+			ListenerTypeURL: {Source: ldsCache},
+		},
+	}
+
+	ldsCache.Upsert(ListenerTypeURL, "listener-b", &envoy_config_listener.Listener{Name: "listener-b"})
+	ldsCache.Upsert(ListenerTypeURL, "listener-a", &envoy_config_listener.Listener{Name: "listener-a"})
+
+	// Unknown type URLs are rejected
+	rec := httptest.NewRecorder()
+	s.xdsCacheDumpHandler(rec, httptest.NewRequest(http.MethodGet, xdsCacheDumpPath+"?type=unknown", nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// All cached resources are returned, sorted by name
+	rec = httptest.NewRecorder()
+	s.xdsCacheDumpHandler(rec, httptest.NewRequest(http.MethodGet, xdsCacheDumpPath+"?type="+ListenerTypeURL, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var dump []xdsCacheDumpResource
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &dump))
+	require.Len(t, dump, 2)
+	require.Equal(t, "listener-a", dump[0].Name)
+	require.Equal(t, "listener-b", dump[1].Name)
+	require.JSONEq(t, `{"name": "listener-a"}`, string(dump[0].Resource))
+}
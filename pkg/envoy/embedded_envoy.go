@@ -24,6 +24,7 @@ import (
 	envoy_config_overload "github.com/envoyproxy/go-control-plane/envoy/config/overload/v3"
 	envoy_extensions_bootstrap_internal_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/bootstrap/internal_listener/v3"
 	envoy_extensions_resource_monitors_downstream_connections "github.com/envoyproxy/go-control-plane/envoy/extensions/resource_monitors/downstream_connections/v3"
+	envoy_config_tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoy_config_upstream "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -113,6 +114,70 @@ type embeddedEnvoyConfig struct {
 	maxConnectionDuration    time.Duration
 	idleTimeout              time.Duration
 	maxConcurrentRetries     uint32
+	xdsSocketMode            string
+	tlsUpstreamOverride      upstreamTLSOverrideConfig
+	bootstrapExtensions      []proto.Message
+}
+
+// upstreamTLSOverrideConfig carries the optional overrides for the upstream
+// TLS parameters of the TLS egress/ingress clusters. With no field set the
+// clusters keep the cilium TLS wrapper transport socket, which takes all TLS
+// parameters from the network policy.
+type upstreamTLSOverrideConfig struct {
+	sni            string
+	alpnProtocols  []string
+	maxSessionKeys *uint32
+	keyLogPath     string
+}
+
+func (c upstreamTLSOverrideConfig) isSet() bool {
+	return c.sni != "" || len(c.alpnProtocols) > 0 || c.maxSessionKeys != nil || c.keyLogPath != ""
+}
+
+// tlsContext builds the UpstreamTlsContext carrying the configured overrides.
+func (c upstreamTLSOverrideConfig) tlsContext() *envoy_config_tls.UpstreamTlsContext {
+	tlsContext := &envoy_config_tls.UpstreamTlsContext{
+		CommonTlsContext: &envoy_config_tls.CommonTlsContext{
+			AlpnProtocols: c.alpnProtocols,
+		},
+		Sni: c.sni,
+	}
+	if c.maxSessionKeys != nil {
+		tlsContext.MaxSessionKeys = wrapperspb.UInt32(*c.maxSessionKeys)
+	}
+	if c.keyLogPath != "" {
+		tlsContext.CommonTlsContext.KeyLog = &envoy_config_tls.TlsKeyLog{Path: c.keyLogPath}
+	}
+	return tlsContext
+}
+
+// validate checks the configured overrides at agent startup, before the
+// bootstrap referring to them is written.
+func (c upstreamTLSOverrideConfig) validate() error {
+	if !c.isSet() {
+		return nil
+	}
+	return c.tlsContext().Validate()
+}
+
+// transportSocket returns the transport socket for the TLS egress/ingress
+// clusters: the cilium TLS wrapper by default, or an Envoy upstream TLS
+// transport socket built from the overrides when any is configured.
+func (c upstreamTLSOverrideConfig) transportSocket() *envoy_config_core.TransportSocket {
+	if !c.isSet() {
+		return &envoy_config_core.TransportSocket{
+			Name: "cilium.tls_wrapper",
+			ConfigType: &envoy_config_core.TransportSocket_TypedConfig{
+				TypedConfig: toAny(&cilium.UpstreamTlsWrapperContext{}),
+			},
+		}
+	}
+	return &envoy_config_core.TransportSocket{
+		Name: "envoy.transport_sockets.tls",
+		ConfigType: &envoy_config_core.TransportSocket_TypedConfig{
+			TypedConfig: toAny(c.tlsContext()),
+		},
+	}
 }
 
 // startEmbeddedEnvoyInternal starts an Envoy proxy instance.
@@ -138,7 +203,7 @@ func (o *onDemandXdsStarter) startEmbeddedEnvoyInternal(config embeddedEnvoyConf
 		nodeId:                   "host~127.0.0.1~no-id~localdomain", // node id format inherited from Istio
 		cluster:                  ingressClusterName,
 		adminPath:                getAdminSocketPath(GetSocketDir(config.runDir)),
-		xdsSock:                  getXDSSocketPath(GetSocketDir(config.runDir)),
+		xdsSock:                  getXDSSocketAddress(GetSocketDir(config.runDir), config.xdsSocketMode),
 		egressClusterName:        egressClusterName,
 		ingressClusterName:       ingressClusterName,
 		connectTimeout:           config.connectTimeout,
@@ -146,6 +211,8 @@ func (o *onDemandXdsStarter) startEmbeddedEnvoyInternal(config embeddedEnvoyConf
 		maxConnectionDuration:    config.maxConnectionDuration,
 		idleTimeout:              config.idleTimeout,
 		maxConcurrentRetries:     config.maxConcurrentRetries,
+		tlsUpstreamOverride:      config.tlsUpstreamOverride,
+		bootstrapExtensions:      config.bootstrapExtensions,
 	})
 
 	o.logger.Debug("Envoy: Starting embedded Envoy")
@@ -366,6 +433,8 @@ type bootstrapConfig struct {
 	maxConnectionDuration    time.Duration
 	idleTimeout              time.Duration
 	maxConcurrentRetries     uint32
+	tlsUpstreamOverride      upstreamTLSOverrideConfig
+	bootstrapExtensions      []proto.Message
 }
 
 func (o *onDemandXdsStarter) writeBootstrapConfigFile(config bootstrapConfig) {
@@ -416,6 +485,21 @@ func (o *onDemandXdsStarter) writeBootstrapConfigFile(config bootstrapConfig) {
 		}},
 	}
 
+	tlsTransportSocket := config.tlsUpstreamOverride.transportSocket()
+
+	bootstrapExtensions := []*envoy_config_core.TypedExtensionConfig{
+		{
+			Name:        "envoy.bootstrap.internal_listener",
+			TypedConfig: toAny(&envoy_extensions_bootstrap_internal_listener_v3.InternalListener{}),
+		},
+	}
+	for _, ext := range config.bootstrapExtensions {
+		bootstrapExtensions = append(bootstrapExtensions, &envoy_config_core.TypedExtensionConfig{
+			Name:        string(ext.ProtoReflect().Descriptor().FullName()),
+			TypedConfig: toAny(ext),
+		})
+	}
+
 	bs := &envoy_config_bootstrap.Bootstrap{
 		Node: &envoy_config_core.Node{Id: config.nodeId, Cluster: config.cluster},
 		StaticResources: &envoy_config_bootstrap.Bootstrap_StaticResources{
@@ -436,12 +520,7 @@ func (o *onDemandXdsStarter) writeBootstrapConfigFile(config bootstrapConfig) {
 					CleanupInterval:               &durationpb.Duration{Seconds: config.connectTimeout, Nanos: 500000000},
 					LbPolicy:                      envoy_config_cluster.Cluster_CLUSTER_PROVIDED,
 					TypedExtensionProtocolOptions: useDownstreamProtocolAutoSNI,
-					TransportSocket: &envoy_config_core.TransportSocket{
-						Name: "cilium.tls_wrapper",
-						ConfigType: &envoy_config_core.TransportSocket_TypedConfig{
-							TypedConfig: toAny(&cilium.UpstreamTlsWrapperContext{}),
-						},
-					},
+					TransportSocket:               tlsTransportSocket,
 				},
 				{
 					Name:                          ingressClusterName,
@@ -458,12 +537,7 @@ func (o *onDemandXdsStarter) writeBootstrapConfigFile(config bootstrapConfig) {
 					CleanupInterval:               &durationpb.Duration{Seconds: config.connectTimeout, Nanos: 500000000},
 					LbPolicy:                      envoy_config_cluster.Cluster_CLUSTER_PROVIDED,
 					TypedExtensionProtocolOptions: useDownstreamProtocolAutoSNI,
-					TransportSocket: &envoy_config_core.TransportSocket{
-						Name: "cilium.tls_wrapper",
-						ConfigType: &envoy_config_core.TransportSocket_TypedConfig{
-							TypedConfig: toAny(&cilium.UpstreamTlsWrapperContext{}),
-						},
-					},
+					TransportSocket:               tlsTransportSocket,
 				},
 				{
 					Name:                 CiliumXDSClusterName,
@@ -523,12 +597,7 @@ func (o *onDemandXdsStarter) writeBootstrapConfigFile(config bootstrapConfig) {
 				},
 			},
 		},
-		BootstrapExtensions: []*envoy_config_core.TypedExtensionConfig{
-			{
-				Name:        "envoy.bootstrap.internal_listener",
-				TypedConfig: toAny(&envoy_extensions_bootstrap_internal_listener_v3.InternalListener{}),
-			},
-		},
+		BootstrapExtensions: bootstrapExtensions,
 		OverloadManager: &envoy_config_overload.OverloadManager{
 			ResourceMonitors: []*envoy_config_overload.ResourceMonitor{{
 				Name: "envoy.resource_monitors.global_downstream_max_connections",
@@ -5,13 +5,19 @@ package envoy
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/cilium/hive/hivetest"
+	cilium "github.com/cilium/proxy/go/cilium/api"
+	envoy_config_bootstrap "github.com/envoyproxy/go-control-plane/envoy/config/bootstrap/v3"
+	envoy_config_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/cilium/cilium/pkg/completion"
 	"github.com/cilium/cilium/pkg/envoy/xds"
@@ -79,7 +85,7 @@ func TestEnvoy(t *testing.T) {
 			proxyGID:          1337,
 			httpNormalizePath: true,
 		},
-		nil)
+		nil, nil)
 	require.NotNil(t, xdsServer)
 
 	err = xdsServer.start()
@@ -115,13 +121,13 @@ func TestEnvoy(t *testing.T) {
 	s.waitGroup = completion.NewWaitGroup(ctx)
 
 	t.Log("adding listener1")
-	xdsServer.AddListener("listener1", policy.ParserTypeHTTP, 8081, true, false, s.waitGroup, nil)
+	xdsServer.AddListener("listener1", policy.ParserTypeHTTP, 8081, true, false, false, s.waitGroup, nil)
 
 	t.Log("adding listener2")
-	xdsServer.AddListener("listener2", policy.ParserTypeHTTP, 8082, true, false, s.waitGroup, nil)
+	xdsServer.AddListener("listener2", policy.ParserTypeHTTP, 8082, true, false, false, s.waitGroup, nil)
 
 	t.Log("adding listener3")
-	xdsServer.AddListener("listener3", policy.ParserTypeHTTP, 8083, false, false, s.waitGroup, nil)
+	xdsServer.AddListener("listener3", policy.ParserTypeHTTP, 8083, false, false, false, s.waitGroup, nil)
 
 	err = s.waitForProxyCompletion()
 	require.NoError(t, err)
@@ -141,7 +147,7 @@ func TestEnvoy(t *testing.T) {
 	t.Log("adding listener 3")
 	var cbErr error
 	cbCalled := false
-	xdsServer.AddListener("listener3", "test.headerparser", 8083, false, false, s.waitGroup,
+	xdsServer.AddListener("listener3", "test.headerparser", 8083, false, false, false, s.waitGroup,
 		func(err error) {
 			cbCalled = true
 			cbErr = err
@@ -196,7 +202,7 @@ func TestEnvoyNACK(t *testing.T) {
 			envoySocketDir:    GetSocketDir(testRunDir),
 			proxyGID:          1337,
 			httpNormalizePath: true,
-		}, nil)
+		}, nil, nil)
 	require.NotNil(t, xdsServer)
 
 	err = xdsServer.start()
@@ -228,7 +234,7 @@ func TestEnvoyNACK(t *testing.T) {
 	t.Log("adding ", rName)
 	var cbErr error
 	cbCalled := false
-	xdsServer.AddListener(rName, policy.ParserTypeHTTP, 22, true, false, s.waitGroup,
+	xdsServer.AddListener(rName, policy.ParserTypeHTTP, 22, true, false, false, s.waitGroup,
 		func(err error) {
 			cbCalled = true
 			cbErr = err
@@ -238,7 +244,9 @@ func TestEnvoyNACK(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, cbCalled)
 	require.Equal(t, err, cbErr)
-	require.EqualValues(t, &xds.ProxyError{Err: xds.ErrNackReceived, Detail: "Error adding/updating listener(s) listener:22: cannot bind '127.0.0.1:22': Address already in use\n"}, err)
+	require.ErrorIs(t, err, xds.ErrNackReceived)
+	require.ErrorContains(t, err, fmt.Sprintf("NACK for %s/%s", ListenerTypeURL, rName))
+	require.ErrorContains(t, err, "cannot bind '127.0.0.1:22': Address already in use")
 
 	s.waitGroup = completion.NewWaitGroup(ctx)
 	// Remove listener1
@@ -248,6 +256,152 @@ func TestEnvoyNACK(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func upstreamTLSContextFromOverride(t *testing.T, override upstreamTLSOverrideConfig) *envoy_config_tls.UpstreamTlsContext {
+	t.Helper()
+
+	require.NoError(t, override.validate())
+	socket := override.transportSocket()
+	require.Equal(t, "envoy.transport_sockets.tls", socket.GetName())
+	tlsContext := &envoy_config_tls.UpstreamTlsContext{}
+	require.NoError(t, socket.GetTypedConfig().UnmarshalTo(tlsContext))
+	return tlsContext
+}
+
+func TestUpstreamTLSOverrideTransportSocket(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		override := upstreamTLSOverrideConfig{}
+		require.False(t, override.isSet())
+		require.NoError(t, override.validate())
+		socket := override.transportSocket()
+		require.Equal(t, "cilium.tls_wrapper", socket.GetName())
+		require.NoError(t, socket.GetTypedConfig().UnmarshalTo(&cilium.UpstreamTlsWrapperContext{}))
+	})
+
+	t.Run("sni", func(t *testing.T) {
+		tlsContext := upstreamTLSContextFromOverride(t, upstreamTLSOverrideConfig{sni: "proxy.example.com"})
+		require.Equal(t, "proxy.example.com", tlsContext.GetSni())
+		require.Nil(t, tlsContext.GetMaxSessionKeys())
+	})
+
+	t.Run("alpn", func(t *testing.T) {
+		tlsContext := upstreamTLSContextFromOverride(t, upstreamTLSOverrideConfig{alpnProtocols: []string{"h2", "http/1.1"}})
+		require.Equal(t, []string{"h2", "http/1.1"}, tlsContext.GetCommonTlsContext().GetAlpnProtocols())
+	})
+
+	t.Run("session resumption disabled", func(t *testing.T) {
+		maxSessionKeys := uint32(0)
+		tlsContext := upstreamTLSContextFromOverride(t, upstreamTLSOverrideConfig{maxSessionKeys: &maxSessionKeys})
+		require.NotNil(t, tlsContext.GetMaxSessionKeys())
+		require.Equal(t, uint32(0), tlsContext.GetMaxSessionKeys().GetValue())
+	})
+
+	t.Run("max session keys", func(t *testing.T) {
+		maxSessionKeys := uint32(4)
+		tlsContext := upstreamTLSContextFromOverride(t, upstreamTLSOverrideConfig{maxSessionKeys: &maxSessionKeys})
+		require.Equal(t, uint32(4), tlsContext.GetMaxSessionKeys().GetValue())
+	})
+
+	t.Run("keylog", func(t *testing.T) {
+		tlsContext := upstreamTLSContextFromOverride(t, upstreamTLSOverrideConfig{keyLogPath: "/tmp/tls-keys.log"})
+		require.Equal(t, "/tmp/tls-keys.log", tlsContext.GetCommonTlsContext().GetKeyLog().GetPath())
+	})
+}
+
+func TestBootstrapUpstreamTLSTransportSocket(t *testing.T) {
+	writeBootstrap := func(t *testing.T, override upstreamTLSOverrideConfig) *envoy_config_bootstrap.Bootstrap {
+		t.Helper()
+
+		bootstrapPath := filepath.Join(t.TempDir(), "bootstrap.pb")
+		starter := &onDemandXdsStarter{logger: hivetest.Logger(t)}
+		starter.writeBootstrapConfigFile(bootstrapConfig{
+			filePath:            bootstrapPath,
+			nodeId:              "host~127.0.0.1~no-id~localdomain",
+			cluster:             ingressClusterName,
+			egressClusterName:   egressClusterName,
+			ingressClusterName:  ingressClusterName,
+			connectTimeout:      1,
+			tlsUpstreamOverride: override,
+		})
+		data, err := os.ReadFile(bootstrapPath)
+		require.NoError(t, err)
+		bootstrap := &envoy_config_bootstrap.Bootstrap{}
+		require.NoError(t, proto.Unmarshal(data, bootstrap))
+		return bootstrap
+	}
+
+	tlsClusterSocket := func(t *testing.T, bootstrap *envoy_config_bootstrap.Bootstrap, name string) *envoy_config_core.TransportSocket {
+		t.Helper()
+
+		for _, cluster := range bootstrap.GetStaticResources().GetClusters() {
+			if cluster.GetName() == name {
+				return cluster.GetTransportSocket()
+			}
+		}
+		t.Fatalf("cluster %q not found in bootstrap", name)
+		return nil
+	}
+
+	// Without overrides both TLS clusters keep the cilium TLS wrapper, which
+	// takes the TLS parameters from the network policy.
+	bootstrap := writeBootstrap(t, upstreamTLSOverrideConfig{})
+	for _, name := range []string{egressTLSClusterName, ingressTLSClusterName} {
+		socket := tlsClusterSocket(t, bootstrap, name)
+		require.Equal(t, "cilium.tls_wrapper", socket.GetName())
+		require.NoError(t, socket.GetTypedConfig().UnmarshalTo(&cilium.UpstreamTlsWrapperContext{}))
+	}
+
+	// Any override replaces the wrapper with the Envoy upstream TLS transport
+	// socket on both TLS clusters.
+	bootstrap = writeBootstrap(t, upstreamTLSOverrideConfig{sni: "proxy.example.com"})
+	for _, name := range []string{egressTLSClusterName, ingressTLSClusterName} {
+		socket := tlsClusterSocket(t, bootstrap, name)
+		require.Equal(t, "envoy.transport_sockets.tls", socket.GetName())
+		tlsContext := &envoy_config_tls.UpstreamTlsContext{}
+		require.NoError(t, socket.GetTypedConfig().UnmarshalTo(tlsContext))
+		require.Equal(t, "proxy.example.com", tlsContext.GetSni())
+	}
+}
+
+func TestBootstrapExtensions(t *testing.T) {
+	writeBootstrap := func(t *testing.T, starter *onDemandXdsStarter) *envoy_config_bootstrap.Bootstrap {
+		t.Helper()
+
+		bootstrapPath := filepath.Join(t.TempDir(), "bootstrap.pb")
+		starter.writeBootstrapConfigFile(bootstrapConfig{
+			filePath:            bootstrapPath,
+			nodeId:              "host~127.0.0.1~no-id~localdomain",
+			cluster:             ingressClusterName,
+			egressClusterName:   egressClusterName,
+			ingressClusterName:  ingressClusterName,
+			connectTimeout:      1,
+			bootstrapExtensions: starter.bootstrapExtensions(),
+		})
+		data, err := os.ReadFile(bootstrapPath)
+		require.NoError(t, err)
+		bootstrap := &envoy_config_bootstrap.Bootstrap{}
+		require.NoError(t, proto.Unmarshal(data, bootstrap))
+		return bootstrap
+	}
+
+	// By default only the internal listener extension is serialized.
+	server := testXdsServer(t)
+	starter := &onDemandXdsStarter{XDSServer: server, logger: hivetest.Logger(t)}
+	bootstrap := writeBootstrap(t, starter)
+	extensions := bootstrap.GetBootstrapExtensions()
+	require.Len(t, extensions, 1)
+	require.Equal(t, "envoy.bootstrap.internal_listener", extensions[0].GetName())
+
+	// Registered extensions are appended, named after their proto message.
+	server.AddBootstrapExtension(&envoy_config_tls.UpstreamTlsContext{Sni: "custom.example.com"})
+	bootstrap = writeBootstrap(t, starter)
+	extensions = bootstrap.GetBootstrapExtensions()
+	require.Len(t, extensions, 2)
+	require.Equal(t, "envoy.extensions.transport_sockets.tls.v3.UpstreamTlsContext", extensions[1].GetName())
+	tlsContext := &envoy_config_tls.UpstreamTlsContext{}
+	require.NoError(t, extensions[1].GetTypedConfig().UnmarshalTo(tlsContext))
+	require.Equal(t, "custom.example.com", tlsContext.GetSni())
+}
+
 type proxyAccessLoggerMock struct{}
 
 func (p *proxyAccessLoggerMock) NewLogRecord(t accesslog.FlowType, ingress bool, tags ...accesslog.LogTag) *accesslog.LogRecord {
@@ -27,13 +27,12 @@ import (
 var ErrNotImplemented = errors.New("not implemented")
 
 // startXDSGRPCServer starts a gRPC server to serve xDS APIs using the given
-// resource watcher and network listener.
+// network listener.
 // Returns a function that stops the GRPC server when called.
-func (s *xdsServer) startXDSGRPCServer(listener net.Listener, config map[string]*xds.ResourceTypeConfiguration) context.CancelFunc {
+func (s *xdsServer) startXDSGRPCServer(listener net.Listener) context.CancelFunc {
 	grpcServer := grpc.NewServer()
 
-	// xdsServer optionally pauses serving any resources until endpoints have been restored
-	xdsServer := xds.NewServer(s.logger, config, s.restorerPromise, s.config.metrics)
+	xdsServer := s.streamServer
 	dsServer := (*xdsGRPCServer)(xdsServer)
 
 	// TODO: https://github.com/cilium/cilium/issues/5051
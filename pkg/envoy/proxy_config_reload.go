@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package envoy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cilium/statedb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/cilium/cilium/pkg/dynamicconfig"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/option"
+)
+
+// watchProxyConfig watches the dynamic agent configuration table and applies
+// changes to the hot-reloadable proxy settings to the running server without
+// an agent restart.
+func (s *xdsServer) watchProxyConfig(ctx context.Context, db *statedb.DB, table statedb.Table[dynamicconfig.DynamicConfig]) error {
+	for {
+		keys, watch := dynamicconfig.WatchAllKeys(db.ReadTxn(), table)
+		if len(keys) > 0 {
+			newConfig := s.snapshotConfig()
+			if s.applyProxyConfigOverrides(&newConfig, keys) {
+				if err := s.updateConfig(newConfig); err != nil {
+					s.logger.Warn("Envoy: Rejecting proxy configuration change",
+						logfields.Error, err,
+					)
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-watch:
+		}
+	}
+}
+
+// snapshotConfig returns a copy of the current server configuration.
+func (s *xdsServer) snapshotConfig() xdsServerConfig {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.config
+}
+
+// applyProxyConfigOverrides overrides the configuration fields backed by the
+// given dynamic configuration keys, returning true if any override was
+// applied. Values that do not parse are ignored with a warning.
+func (s *xdsServer) applyProxyConfigOverrides(config *xdsServerConfig, keys map[string]dynamicconfig.DynamicConfig) bool {
+	overridden := false
+	setInt := func(flag string, apply func(int)) {
+		entry, ok := keys[flag]
+		if !ok {
+			return
+		}
+		value, err := strconv.Atoi(entry.Value)
+		if err != nil || value < 0 {
+			s.logger.Warn("Envoy: Ignoring invalid value for proxy configuration flag",
+				logfields.Key, flag,
+				logfields.Value, entry.Value,
+				logfields.Error, err,
+			)
+			return
+		}
+		apply(value)
+		overridden = true
+	}
+	setInt("http-request-timeout", func(v int) { config.httpRequestTimeout = v })
+	setInt("proxy-idle-timeout-seconds", func(v int) { config.httpIdleTimeout = v })
+	setInt("http-max-grpc-timeout", func(v int) { config.httpMaxGRPCTimeout = v })
+	setInt("http-retry-count", func(v int) { config.httpRetryCount = v })
+	setInt("http-retry-timeout", func(v int) { config.httpRetryTimeout = v })
+	setInt("http-stream-idle-timeout", func(v int) { config.httpStreamIdleTimeout = v })
+	setInt("proxy-xff-num-trusted-hops-ingress", func(v int) { config.proxyXffNumTrustedHopsIngress = uint32(v) })
+	setInt("proxy-xff-num-trusted-hops-egress", func(v int) { config.proxyXffNumTrustedHopsEgress = uint32(v) })
+	// Parsed so that attempts to change them are explicitly rejected by
+	// updateConfig instead of being silently ignored.
+	setInt("proxy-gid", func(v int) { config.proxyGID = v })
+	if entry, ok := keys["envoy-xds-socket-mode"]; ok {
+		config.xdsSocketMode = entry.Value
+		overridden = true
+	}
+	return overridden
+}
+
+// updateConfig applies a changed proxy configuration to the running server.
+// Only the HTTP timeout, retry and XFF trusted-hops settings are
+// hot-reloadable; when any of them change, the delta is logged and the
+// retained proxy listeners are regenerated and upserted in place, so that
+// Envoy swaps in the new filter configuration without listener churn.
+// Changes to fields tied to process-level resources (socket directory, proxy
+// GID, socket mode) are rejected. Other changed fields are left untouched.
+func (s *xdsServer) updateConfig(newConfig xdsServerConfig) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	old := &s.config
+	if newConfig.envoySocketDir != old.envoySocketDir {
+		return fmt.Errorf("envoy socket directory cannot be changed without an agent restart")
+	}
+	if newConfig.proxyGID != old.proxyGID {
+		return fmt.Errorf("proxy GID cannot be changed without an agent restart")
+	}
+	if newConfig.xdsSocketMode != old.xdsSocketMode {
+		return fmt.Errorf("xDS socket mode cannot be changed without an agent restart")
+	}
+
+	var changes []string
+	reload := func(flag string, oldValue, newValue any, apply func()) {
+		if oldValue == newValue {
+			return
+		}
+		changes = append(changes, fmt.Sprintf("%s: %v -> %v", flag, oldValue, newValue))
+		apply()
+	}
+	reload("http-request-timeout", old.httpRequestTimeout, newConfig.httpRequestTimeout, func() { old.httpRequestTimeout = newConfig.httpRequestTimeout })
+	reload("proxy-idle-timeout-seconds", old.httpIdleTimeout, newConfig.httpIdleTimeout, func() { old.httpIdleTimeout = newConfig.httpIdleTimeout })
+	reload("http-max-grpc-timeout", old.httpMaxGRPCTimeout, newConfig.httpMaxGRPCTimeout, func() { old.httpMaxGRPCTimeout = newConfig.httpMaxGRPCTimeout })
+	reload("http-retry-count", old.httpRetryCount, newConfig.httpRetryCount, func() { old.httpRetryCount = newConfig.httpRetryCount })
+	reload("http-retry-timeout", old.httpRetryTimeout, newConfig.httpRetryTimeout, func() { old.httpRetryTimeout = newConfig.httpRetryTimeout })
+	reload("http-stream-idle-timeout", old.httpStreamIdleTimeout, newConfig.httpStreamIdleTimeout, func() { old.httpStreamIdleTimeout = newConfig.httpStreamIdleTimeout })
+	reload("proxy-xff-num-trusted-hops-ingress", old.proxyXffNumTrustedHopsIngress, newConfig.proxyXffNumTrustedHopsIngress, func() { old.proxyXffNumTrustedHopsIngress = newConfig.proxyXffNumTrustedHopsIngress })
+	reload("proxy-xff-num-trusted-hops-egress", old.proxyXffNumTrustedHopsEgress, newConfig.proxyXffNumTrustedHopsEgress, func() { old.proxyXffNumTrustedHopsEgress = newConfig.proxyXffNumTrustedHopsEgress })
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	s.logger.Info("Envoy: Applying updated proxy configuration to existing listeners",
+		logfields.Changes, changes,
+	)
+
+	for name, listenerConf := range s.listenerConfs {
+		listenerConfig := listenerConf()
+		if option.Config.EnableBPFTProxy {
+			// Keep SO_REUSEPORT disabled, as in addListener.
+			listenerConfig.EnableReusePort = &wrapperspb.BoolValue{Value: false}
+		}
+		if err := listenerConfig.Validate(); err != nil {
+			s.logger.Warn("Envoy: Regenerated listener failed validation, keeping the previous configuration",
+				logfields.Listener, name,
+				logfields.Error, err,
+			)
+			continue
+		}
+		// The cache skips the version bump for listeners whose regenerated
+		// configuration is unchanged.
+		s.listenerMutator.Upsert(ListenerTypeURL, name, listenerConfig, []string{"127.0.0.1"}, nil, nil)
+	}
+	return nil
+}
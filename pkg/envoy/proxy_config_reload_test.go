@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package envoy
+
+import (
+	"testing"
+
+	envoy_config_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_config_http "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cilium/cilium/pkg/dynamicconfig"
+	"github.com/cilium/cilium/pkg/policy"
+)
+
+func TestProxyConfigReload(t *testing.T) {
+	s := testXdsServer(t)
+	s.config.httpRequestTimeout = 100
+	s.initializeXdsConfigs()
+	s.listenerCount = make(map[string]uint)
+	s.listenerConfs = make(map[string]func() *envoy_config_listener.Listener)
+
+	require.NoError(t, s.AddListener("test-listener", policy.ParserTypeHTTP, 10001, false, false, false, nil, nil))
+
+	requestTimeout := func(t *testing.T) int64 {
+		t.Helper()
+		msg, err := s.listenerCache.Lookup(ListenerTypeURL, "test-listener")
+		require.NoError(t, err)
+		require.NotNil(t, msg)
+		hcm := &envoy_config_http.HttpConnectionManager{}
+		require.NoError(t, msg.(*envoy_config_listener.Listener).FilterChains[0].Filters[1].GetTypedConfig().UnmarshalTo(hcm))
+		return hcm.GetRouteConfig().GetVirtualHosts()[0].GetRoutes()[0].GetRoute().GetTimeout().GetSeconds()
+	}
+	require.Equal(t, int64(100), requestTimeout(t))
+
+	// Flipping the request timeout regenerates and upserts the listener in
+	// place, so the cached proto carries the new timeout.
+	newConfig := s.snapshotConfig()
+	newConfig.httpRequestTimeout = 42
+	require.NoError(t, s.updateConfig(newConfig))
+	require.Equal(t, 42, s.config.httpRequestTimeout)
+	require.Equal(t, int64(42), requestTimeout(t))
+
+	// An unchanged configuration is a no-op.
+	require.NoError(t, s.updateConfig(s.snapshotConfig()))
+
+	// Fields tied to process-level resources are explicitly rejected.
+	newConfig = s.snapshotConfig()
+	newConfig.proxyGID = 4321
+	require.ErrorContains(t, s.updateConfig(newConfig), "proxy GID")
+
+	newConfig = s.snapshotConfig()
+	newConfig.envoySocketDir = "/elsewhere"
+	require.ErrorContains(t, s.updateConfig(newConfig), "socket directory")
+
+	newConfig = s.snapshotConfig()
+	newConfig.xdsSocketMode = "abstract"
+	require.ErrorContains(t, s.updateConfig(newConfig), "socket mode")
+
+	// A rejected change leaves the cached listener untouched.
+	require.Equal(t, int64(42), requestTimeout(t))
+}
+
+func TestProxyConfigOverrides(t *testing.T) {
+	s := testXdsServer(t)
+	s.config.httpRequestTimeout = 100
+
+	keys := map[string]dynamicconfig.DynamicConfig{
+		"http-request-timeout":               {Value: "42"},
+		"proxy-xff-num-trusted-hops-ingress": {Value: "2"},
+		"unrelated-flag":                     {Value: "true"},
+	}
+	config := s.snapshotConfig()
+	require.True(t, s.applyProxyConfigOverrides(&config, keys))
+	require.Equal(t, 42, config.httpRequestTimeout)
+	require.Equal(t, uint32(2), config.proxyXffNumTrustedHopsIngress)
+
+	// Values that do not parse are ignored.
+	config = s.snapshotConfig()
+	require.False(t, s.applyProxyConfigOverrides(&config, map[string]dynamicconfig.DynamicConfig{
+		"http-request-timeout": {Value: "not-a-number"},
+		"http-retry-count":     {Value: "-1"},
+	}))
+	require.Equal(t, 100, config.httpRequestTimeout)
+}
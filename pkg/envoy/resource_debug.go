@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package envoy
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/cilium/cilium/pkg/completion"
+	"github.com/cilium/cilium/pkg/envoy/xds"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+)
+
+// debugDumpKeepVersions is the number of dumped versions kept per resource
+// before the oldest dump files are rotated away.
+const debugDumpKeepVersions = 5
+
+// debugDumpQueueSize bounds the number of dump events waiting to be written.
+// Events beyond this are dropped so that the upsert path never blocks on the
+// filesystem.
+const debugDumpQueueSize = 128
+
+type debugDumpEvent struct {
+	typeURL      string
+	resourceName string
+	resource     proto.Message
+}
+
+// debugDumper writes every resource pushed through the xDS mutators as a
+// timestamped protojson file into a debug directory, keeping the last
+// debugDumpKeepVersions versions per resource. Writing happens asynchronously
+// in a separate goroutine; events are dropped rather than blocking the
+// caller when the writer cannot keep up.
+type debugDumper struct {
+	logger *slog.Logger
+	dir    string
+	keep   int
+	events chan debugDumpEvent
+}
+
+func newDebugDumper(logger *slog.Logger, dir string, keep int) *debugDumper {
+	d := &debugDumper{
+		logger: logger,
+		dir:    dir,
+		keep:   keep,
+		events: make(chan debugDumpEvent, debugDumpQueueSize),
+	}
+	go d.run()
+	return d
+}
+
+// wrap returns a mutator that dumps every upserted resource before
+// delegating to 'mutator'.
+func (d *debugDumper) wrap(mutator xds.AckingResourceMutator) xds.AckingResourceMutator {
+	return &dumpingMutator{AckingResourceMutator: mutator, dumper: d}
+}
+
+// dump queues the given resource for writing, without ever blocking.
+func (d *debugDumper) dump(typeURL, resourceName string, resource proto.Message) {
+	select {
+	case d.events <- debugDumpEvent{typeURL: typeURL, resourceName: resourceName, resource: proto.Clone(resource)}:
+	default:
+		d.logger.Debug("Envoy: Dropping config debug dump, writer queue is full",
+			logfields.XDSTypeURL, typeURL,
+			logfields.XDSResourceName, resourceName,
+		)
+	}
+}
+
+func (d *debugDumper) run() {
+	for ev := range d.events {
+		d.write(ev)
+	}
+}
+
+func (d *debugDumper) write(ev debugDumpEvent) {
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		d.logger.Warn("Envoy: Failed to create config debug directory",
+			logfields.Path, d.dir,
+			logfields.Error, err,
+		)
+		return
+	}
+
+	data, err := protojson.MarshalOptions{Multiline: true}.Marshal(ev.resource)
+	if err != nil {
+		d.logger.Warn("Envoy: Failed to marshal resource for config debug dump",
+			logfields.XDSTypeURL, ev.typeURL,
+			logfields.XDSResourceName, ev.resourceName,
+			logfields.Error, err,
+		)
+		return
+	}
+
+	prefix := dumpFilePrefix(ev.typeURL, ev.resourceName)
+	path := filepath.Join(d.dir, fmt.Sprintf("%s%d.json", prefix, time.Now().UnixNano()))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		d.logger.Warn("Envoy: Failed to write config debug dump",
+			logfields.Path, path,
+			logfields.Error, err,
+		)
+		return
+	}
+
+	d.rotate(prefix)
+}
+
+// rotate removes the oldest dump files of a resource so that at most 'keep'
+// versions remain. The timestamped file names sort oldest first.
+func (d *debugDumper) rotate(prefix string) {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+	var matches []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), prefix) {
+			matches = append(matches, entry.Name())
+		}
+	}
+	sort.Strings(matches)
+	for _, name := range matches[:max(0, len(matches)-d.keep)] {
+		_ = os.Remove(filepath.Join(d.dir, name))
+	}
+}
+
+// dumpFilePrefix returns the file name prefix for a resource, with the type
+// URL reduced to the bare type name and characters unfit for file names
+// replaced.
+func dumpFilePrefix(typeURL, resourceName string) string {
+	typeName := typeURL[strings.LastIndexByte(typeURL, '.')+1:]
+	sanitized := strings.ReplaceAll(resourceName, string(os.PathSeparator), "_")
+	return fmt.Sprintf("%s-%s-", typeName, sanitized)
+}
+
+// dumpingMutator dumps every upserted resource to the debug directory before
+// handing it to the wrapped mutator.
+type dumpingMutator struct {
+	xds.AckingResourceMutator
+	dumper *debugDumper
+}
+
+func (m *dumpingMutator) Upsert(typeURL string, resourceName string, resource proto.Message, nodeIDs []string, wg *completion.WaitGroup, callback func(error)) xds.AckingResourceMutatorRevertFunc {
+	m.dumper.dump(typeURL, resourceName, resource)
+	return m.AckingResourceMutator.Upsert(typeURL, resourceName, resource, nodeIDs, wg, callback)
+}
+
+func (m *dumpingMutator) UpsertAll(typeURL string, upsertedResources map[string]proto.Message, nodeIDs []string, wg *completion.WaitGroup, callback func(error)) xds.AckingResourceMutatorRevertFunc {
+	for name, resource := range upsertedResources {
+		m.dumper.dump(typeURL, name, resource)
+	}
+	return m.AckingResourceMutator.UpsertAll(typeURL, upsertedResources, nodeIDs, wg, callback)
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package envoy
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cilium/hive/hivetest"
+	envoy_config_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cilium/cilium/pkg/time"
+)
+
+func TestDebugDumperRotation(t *testing.T) {
+	dir := t.TempDir()
+	d := &debugDumper{
+		logger: hivetest.Logger(t),
+		dir:    dir,
+		keep:   2,
+	}
+
+	for range 5 {
+		d.write(debugDumpEvent{
+			typeURL:      ListenerTypeURL,
+			resourceName: "listener/1",
+			resource:     &envoy_config_listener.Listener{Name: "listener1"},
+		})
+	}
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		require.True(t, strings.HasPrefix(entry.Name(), "Listener-listener_1-"), entry.Name())
+		data, err := os.ReadFile(dir + "/" + entry.Name())
+		require.NoError(t, err)
+		require.Contains(t, string(data), "listener1")
+	}
+
+	// Dumps of other resources are rotated independently.
+	d.write(debugDumpEvent{
+		typeURL:      ListenerTypeURL,
+		resourceName: "listener/2",
+		resource:     &envoy_config_listener.Listener{Name: "listener2"},
+	})
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+}
+
+func TestDebugDumpOptIn(t *testing.T) {
+	s := testXdsServer(t)
+
+	// Without a debug directory configured, the plain acking mutators are
+	// used and nothing is written.
+	s.initializeXdsConfigs()
+	_, ok := s.listenerMutator.(*dumpingMutator)
+	require.False(t, ok)
+
+	// With a debug directory configured, the mutators dump every upserted
+	// resource asynchronously.
+	s.config.debugDumpDir = t.TempDir()
+	s.initializeXdsConfigs()
+	_, ok = s.listenerMutator.(*dumpingMutator)
+	require.True(t, ok)
+
+	s.listenerMutator.Upsert(ListenerTypeURL, "test-listener", &envoy_config_listener.Listener{Name: "test-listener"}, []string{"127.0.0.1"}, nil, nil)
+	require.Eventually(t, func() bool {
+		entries, err := os.ReadDir(s.config.debugDumpDir)
+		return err == nil && len(entries) == 1
+	}, time.Second, 10*time.Millisecond)
+}
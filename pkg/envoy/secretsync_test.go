@@ -12,6 +12,7 @@ import (
 	cilium "github.com/cilium/proxy/go/cilium/api"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/cilium/cilium/pkg/completion"
 	"github.com/cilium/cilium/pkg/envoy/xds"
@@ -435,7 +436,7 @@ func (r *fakeXdsServer) UpsertEnvoyResources(ctx context.Context, resources Reso
 	return nil
 }
 
-func (*fakeXdsServer) AddListener(name string, kind policy.L7ParserType, port uint16, isIngress bool, mayUseOriginalSourceAddr bool, wg *completion.WaitGroup, cb func(err error)) error {
+func (*fakeXdsServer) AddListener(name string, kind policy.L7ParserType, port uint16, isIngress bool, mayUseOriginalSourceAddr bool, failFast bool, wg *completion.WaitGroup, cb func(err error)) error {
 	panic("unimplemented")
 }
 
@@ -451,6 +452,18 @@ func (*fakeXdsServer) GetNetworkPolicies(resourceNames []string) (map[string]*ci
 	panic("unimplemented")
 }
 
+func (*fakeXdsServer) GetNetworkPoliciesWithVersion(resourceNames []string) (map[string]*cilium.NetworkPolicy, uint64, error) {
+	panic("unimplemented")
+}
+
+func (*fakeXdsServer) ProxyReadiness() map[string]bool {
+	panic("unimplemented")
+}
+
+func (*fakeXdsServer) AddBootstrapExtension(pb proto.Message) {
+	panic("unimplemented")
+}
+
 func (*fakeXdsServer) RemoveAllNetworkPolicies() {
 	panic("unimplemented")
 }
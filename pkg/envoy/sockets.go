@@ -5,11 +5,29 @@ package envoy
 
 import "path/filepath"
 
+// Modes for the xDS listener socket. The default filesystem mode binds a unix
+// domain socket under the Envoy sockets directory; the alternative modes avoid
+// sharing a filesystem path between the agent and the Envoy pod.
+const (
+	// xdsSocketModePath binds the xDS listener to a unix domain socket on
+	// the filesystem (default).
+	xdsSocketModePath = "path"
+	// xdsSocketModeAbstract binds the xDS listener to a socket in the
+	// abstract namespace (leading NUL byte, rendered with a leading '@').
+	xdsSocketModeAbstract = "abstract"
+	// xdsSocketModeInherited serves xDS on a listener file descriptor
+	// inherited from the parent process following the LISTEN_FDS socket
+	// activation convention.
+	xdsSocketModeInherited = "inherited"
+)
+
 func GetSocketDir(runDir string) string {
 	return filepath.Join(runDir, "envoy", "sockets")
 }
 
-func getAccessLogSocketPath(socketDir string) string {
+// GetAccessLogSocketPath returns the path of the L7 access log socket in the
+// given Envoy sockets directory.
+func GetAccessLogSocketPath(socketDir string) string {
 	return filepath.Join(socketDir, "access_log.sock")
 }
 
@@ -17,6 +35,21 @@ func getXDSSocketPath(socketDir string) string {
 	return filepath.Join(socketDir, "xds.sock")
 }
 
+// getXDSSocketAddress returns the address of the xDS channel between the agent
+// and Envoy for the given socket mode. In abstract mode the address lives in
+// the abstract socket namespace, marked with a leading '@' as understood by
+// both the net package and Envoy pipe paths. In inherited mode the listening
+// socket is passed in by a supervisor, but it is expected to be bound to the
+// same abstract address so that the bootstrap can point Envoy at it.
+func getXDSSocketAddress(socketDir, socketMode string) string {
+	switch socketMode {
+	case xdsSocketModeAbstract, xdsSocketModeInherited:
+		return "@" + getXDSSocketPath(socketDir)
+	default:
+		return getXDSSocketPath(socketDir)
+	}
+}
+
 func getAdminSocketPath(socketDir string) string {
 	return filepath.Join(socketDir, "admin.sock")
 }
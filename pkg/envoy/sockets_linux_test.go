@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+//go:build linux
+
+package envoy
+
+import (
+	"net"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cilium/hive/hivetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSocketListenerAbstract(t *testing.T) {
+	// Derive the abstract address from a temporary directory to avoid
+	// colliding with other tests; abstract addresses are process-wide.
+	socketDir := GetSocketDir(t.TempDir())
+
+	s := &xdsServer{
+		logger:     hivetest.Logger(t),
+		socketPath: getXDSSocketAddress(socketDir, xdsSocketModeAbstract),
+		config: xdsServerConfig{
+			xdsSocketMode: xdsSocketModeAbstract,
+		},
+	}
+
+	listener, err := s.newSocketListener()
+	require.NoError(t, err)
+	defer listener.Close()
+
+	// An abstract socket must not appear on the filesystem.
+	_, err = os.Stat(strings.TrimPrefix(s.socketPath, "@"))
+	require.True(t, os.IsNotExist(err))
+
+	conn, err := net.Dial("unix", s.socketPath)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+}
@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package envoy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetXDSSocketAddress(t *testing.T) {
+	socketDir := GetSocketDir("/var/run/cilium")
+
+	// Default and path mode use a plain filesystem path.
+	require.Equal(t, "/var/run/cilium/envoy/sockets/xds.sock", getXDSSocketAddress(socketDir, ""))
+	require.Equal(t, "/var/run/cilium/envoy/sockets/xds.sock", getXDSSocketAddress(socketDir, xdsSocketModePath))
+
+	// Abstract and inherited modes use an abstract namespace address.
+	require.Equal(t, "@/var/run/cilium/envoy/sockets/xds.sock", getXDSSocketAddress(socketDir, xdsSocketModeAbstract))
+	require.Equal(t, "@/var/run/cilium/envoy/sockets/xds.sock", getXDSSocketAddress(socketDir, xdsSocketModeInherited))
+}
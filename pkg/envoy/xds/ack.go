@@ -4,6 +4,7 @@
 package xds
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -14,6 +15,7 @@ import (
 	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging"
 	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/time"
 )
 
 // ProxyError wraps the error and the detail received from the proxy in to a new type
@@ -27,6 +29,10 @@ func (pe *ProxyError) Error() string {
 	return pe.Err.Error() + ": " + pe.Detail
 }
 
+func (pe *ProxyError) Unwrap() error {
+	return pe.Err
+}
+
 var ErrNackReceived = errors.New("NACK received")
 
 // ResourceVersionAckObserver defines the HandleResourceVersionAck method
@@ -64,6 +70,18 @@ func (rl AckingResourceMutatorRevertFuncList) Revert(wg *completion.WaitGroup) {
 	}
 }
 
+// RevertWithTimeout reverts the listed functions in reverse order, like
+// Revert, and waits until the reverts have been ACKed by the Envoy nodes, or
+// the given timeout has expired, whichever comes first.
+func (rl AckingResourceMutatorRevertFuncList) RevertWithTimeout(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	wg := completion.NewWaitGroup(ctx)
+	rl.Revert(wg)
+	return wg.Wait()
+}
+
 // AckingResourceMutator is a variant of ResourceMutator which calls back a
 // Completion when a resource update is ACKed by a set of Envoy nodes.
 type AckingResourceMutator interface {
@@ -76,6 +94,15 @@ type AckingResourceMutator interface {
 	// method call.
 	Upsert(typeURL string, resourceName string, resource proto.Message, nodeIDs []string, wg *completion.WaitGroup, callback func(error)) AckingResourceMutatorRevertFunc
 
+	// UpsertAll inserts or updates all the given resources of a single type
+	// as one transaction, so that the clients observe the whole set in a
+	// single version bump.
+	// The completion is called back when the new version is ACKed by the
+	// Envoy nodes which IDs are given in nodeIDs.
+	// A call to the returned revert function reverts the effects of this
+	// method call.
+	UpsertAll(typeURL string, upsertedResources map[string]proto.Message, nodeIDs []string, wg *completion.WaitGroup, callback func(error)) AckingResourceMutatorRevertFunc
+
 	// UseCurrent inserts a completion that allows the caller to wait for the current
 	// version of the given typeURL to be ACKed.
 	UseCurrent(typeURL string, nodeIDs []string, wg *completion.WaitGroup)
@@ -90,6 +117,28 @@ type AckingResourceMutator interface {
 	// A call to the returned revert function reverts the effects of this
 	// method call.
 	Delete(typeURL string, resourceName string, nodeIDs []string, wg *completion.WaitGroup, callback func(error)) AckingResourceMutatorRevertFunc
+
+	// GetResourceStatus returns the status of the last NACK received for the
+	// named resource, if its latest version has not been ACKed since.
+	GetResourceStatus(typeURL string, resourceName string) (ResourceNackStatus, bool)
+}
+
+// ResourceNackStatus describes the last NACK received for a resource. It is
+// kept until the node ACKs the rejected or a more recent version of the
+// resource, so a cached resource that was never accepted by the proxy can be
+// told apart from an applied one after the fact.
+type ResourceNackStatus struct {
+	// Version is the cache version that was rejected.
+	Version uint64
+
+	// Detail is the human-readable rejection reason reported by the client.
+	Detail string
+}
+
+// nackKey identifies the resource a NACK status is recorded for.
+type nackKey struct {
+	typeURL      string
+	resourceName string
 }
 
 // AckingResourceMutatorWrapper is an AckingResourceMutator which wraps a
@@ -116,6 +165,10 @@ type AckingResourceMutatorWrapper struct {
 	// pendingCompletions is the list of updates that are pending completion.
 	pendingCompletions map[*completion.Completion]*pendingCompletion
 
+	// nackedResources records the last NACK per resource, cleared when the
+	// rejected or a more recent version of the resource is ACKed.
+	nackedResources map[nackKey]ResourceNackStatus
+
 	// restoring controls waiting for acks. When 'true' updates do not wait for acks from the xDS client,
 	// as xDS caches are pre-populated before passing any resources to xDS clients.
 	restoring bool
@@ -131,6 +184,11 @@ type pendingCompletion struct {
 	// typeURL is the type URL of the resources to be ACKed.
 	typeURL string
 
+	// resourceName is the name of the resource to be ACKed. Empty for
+	// completions waiting for any ACK of a version (e.g. deletes and
+	// reverts), where no single resource can be named.
+	resourceName string
+
 	// remainingNodesResources maps each pending node ID to pending resource
 	// name.
 	remainingNodesResources map[string]map[string]struct{}
@@ -144,6 +202,7 @@ func NewAckingResourceMutatorWrapper(logger *slog.Logger, mutator ResourceMutato
 		mutator:            mutator,
 		ackedVersions:      make(map[string]uint64),
 		pendingCompletions: make(map[*completion.Completion]*pendingCompletion),
+		nackedResources:    make(map[nackKey]ResourceNackStatus),
 		metrics:            metrics,
 	}
 }
@@ -251,6 +310,7 @@ func (m *AckingResourceMutatorWrapper) Upsert(typeURL string, resourceName strin
 		comp := &pendingCompletion{
 			version:                 m.version,
 			typeURL:                 typeURL,
+			resourceName:            resourceName,
 			remainingNodesResources: make(map[string]map[string]struct{}, len(nodeIDs)),
 		}
 		for _, nodeID := range nodeIDs {
@@ -281,6 +341,81 @@ func (m *AckingResourceMutatorWrapper) Upsert(typeURL string, resourceName strin
 	}
 }
 
+// UpsertAll commits all the given resources to the cache as one transaction
+// and adds a single completion waiting for the resulting version to be ACKed
+// for every resource in the set.
+func (m *AckingResourceMutatorWrapper) UpsertAll(typeURL string, upsertedResources map[string]proto.Message, nodeIDs []string, wg *completion.WaitGroup, callback func(error)) AckingResourceMutatorRevertFunc {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	wait := wg != nil
+
+	if m.restoring {
+		// Do not wait for acks when restoring state
+		m.logger.Debug("UpsertAll: Restoring, skipping wait for ACK",
+			logfields.XDSTypeURL, typeURL,
+		)
+
+		wait = false
+	}
+
+	var updated bool
+	var revert ResourceMutatorRevertFunc
+	m.version, updated, revert = m.mutator.TX(typeURL, upsertedResources, nil)
+
+	if !updated {
+		if wait {
+			m.useCurrent(typeURL, nodeIDs, wg, callback)
+		} else if callback != nil {
+			callback(nil)
+		}
+		return func(completion *completion.Completion) {}
+	}
+
+	if wait {
+		c := wg.AddCompletionWithCallback(callback)
+		if _, found := m.pendingCompletions[c]; found {
+			s := fmt.Sprintf("attempt to reuse completion to upsert xDS resources: %v", c)
+			logging.Fatal(m.logger, s,
+				logfields.XDSTypeURL, typeURL,
+			)
+		}
+
+		comp := &pendingCompletion{
+			version:                 m.version,
+			typeURL:                 typeURL,
+			remainingNodesResources: make(map[string]map[string]struct{}, len(nodeIDs)),
+		}
+		for _, nodeID := range nodeIDs {
+			comp.remainingNodesResources[nodeID] = make(map[string]struct{}, len(upsertedResources))
+			for name := range upsertedResources {
+				comp.remainingNodesResources[nodeID][name] = struct{}{}
+			}
+		}
+		m.pendingCompletions[c] = comp
+	} else if callback != nil {
+		callback(nil)
+	}
+
+	// Returned revert function locks again, so it can NOT be called from 'callback' directly,
+	// as 'callback' is called with the lock already held.
+	return func(completion *completion.Completion) {
+		m.locker.Lock()
+		defer m.locker.Unlock()
+
+		if revert != nil {
+			m.version, _ = revert()
+
+			if completion != nil {
+				// The revert is a transaction over the same resource names, so
+				// as a best effort just wait for any ACK for the reverted
+				// version and type URL, like for a Delete.
+				m.addVersionCompletion(typeURL, m.version, nodeIDs, completion)
+			}
+		}
+	}
+}
+
 func (m *AckingResourceMutatorWrapper) useCurrent(typeURL string, nodeIDs []string, wg *completion.WaitGroup, callback func(error)) {
 	if !m.currentVersionAcked(nodeIDs) {
 		// Add a completion object for 'version' so that the caller may wait for the N/ACK
@@ -370,6 +505,17 @@ func (m *AckingResourceMutatorWrapper) Delete(typeURL string, resourceName strin
 	}
 }
 
+// GetResourceStatus returns the status of the last NACK received for the
+// named resource. Returns false if the node has since ACKed the rejected or a
+// more recent version of the resource, or if the resource was never NACKed.
+func (m *AckingResourceMutatorWrapper) GetResourceStatus(typeURL string, resourceName string) (ResourceNackStatus, bool) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	status, found := m.nackedResources[nackKey{typeURL: typeURL, resourceName: resourceName}]
+	return status, found
+}
+
 // 'ackVersion' is the last version that was acked. 'nackVersion', if greater than 'ackVersion', is the last version that was NACKed.
 func (m *AckingResourceMutatorWrapper) HandleResourceVersionAck(ackVersion uint64, nackVersion uint64, nodeIP string, resourceNames []string, typeURL string, detail string) {
 	scopedLogger := m.logger.With(
@@ -390,6 +536,15 @@ func (m *AckingResourceMutatorWrapper) HandleResourceVersionAck(ackVersion uint6
 		m.ackedVersions[nodeIP] = ackVersion
 	}
 
+	// Clear recorded NACKs for resources the node has now ACKed at or past
+	// the version that was rejected.
+	for _, name := range resourceNames {
+		key := nackKey{typeURL: typeURL, resourceName: name}
+		if status, found := m.nackedResources[key]; found && status.Version <= ackVersion {
+			delete(m.nackedResources, key)
+		}
+	}
+
 	remainingCompletions := make(map[*completion.Completion]*pendingCompletion, len(m.pendingCompletions))
 
 	for comp, pending := range m.pendingCompletions {
@@ -423,8 +578,26 @@ func (m *AckingResourceMutatorWrapper) HandleResourceVersionAck(ackVersion uint6
 							comp.Complete(nil)
 						} else {
 							m.metrics.IncreaseNACK(typeURL)
-							scopedLogger.Warn(fmt.Sprintf("completing NACK: %v", pending))
-							comp.Complete(&ProxyError{Err: ErrNackReceived, Detail: detail})
+							scopedLogger.Warn(fmt.Sprintf("completing NACK: %v", pending),
+								logfields.XDSResourceName, pending.resourceName,
+							)
+							err := error(&ProxyError{Err: ErrNackReceived, Detail: detail})
+							if pending.resourceName != "" {
+								// Name the violating resource so that consumers
+								// do not need to correlate logs to find it.
+								err = fmt.Errorf("NACK for %s/%s: %w", typeURL, pending.resourceName, err)
+
+								// Remember the NACK until a later version of the
+								// resource is ACKed.
+								key := nackKey{typeURL: typeURL, resourceName: pending.resourceName}
+								if status, found := m.nackedResources[key]; !found || status.Version < pending.version {
+									m.nackedResources[key] = ResourceNackStatus{
+										Version: pending.version,
+										Detail:  detail,
+									}
+								}
+							}
+							comp.Complete(err)
 						}
 						continue
 					}
@@ -5,6 +5,7 @@ package xds
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"testing"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/cilium/hive/hivetest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/cilium/cilium/pkg/completion"
 	"github.com/cilium/cilium/pkg/logging/logfields"
@@ -307,7 +309,9 @@ func TestUpsertMoreRecentVersionNack(t *testing.T) {
 	// IsCompleted is true only for completions without error
 	require.Condition(t, isNotCompletedComparison(comp))
 	require.Error(t, comp.Err())
-	require.EqualValues(t, &ProxyError{Err: ErrNackReceived, Detail: "Detail"}, comp.Err())
+	require.ErrorIs(t, comp.Err(), ErrNackReceived)
+	require.ErrorContains(t, comp.Err(), fmt.Sprintf("NACK for %s/%s", typeURL, resources[0].Name))
+	require.ErrorContains(t, comp.Err(), "Detail")
 	require.Equal(t, 0, metrics.nack[typeURL])
 	require.Equal(t, 1, metrics.ack[typeURL])
 }
@@ -539,3 +543,176 @@ func TestRevertDelete(t *testing.T) {
 	require.Equal(t, 0, metrics.nack[typeURL])
 	require.Equal(t, 0, metrics.ack[typeURL])
 }
+
+func TestRevertWithTimeoutAcked(t *testing.T) {
+	logger := hivetest.Logger(t)
+	typeURL := "type.googleapis.com/envoy.config.v3.DummyConfiguration"
+	metrics := newMockMetrics()
+
+	cache := NewCache(logger)
+	acker := NewAckingResourceMutatorWrapper(logger, cache, metrics)
+
+	// Create versions 2 and 3 with resources 0 and 2.
+	var revertFuncs AckingResourceMutatorRevertFuncList
+	revertFuncs = append(revertFuncs, acker.Upsert(typeURL, resources[0].Name, resources[0], []string{node0}, nil, nil))
+	revertFuncs = append(revertFuncs, acker.Upsert(typeURL, resources[2].Name, resources[2], []string{node0}, nil, nil))
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- revertFuncs.RevertWithTimeout(context.Background(), 5*time.Second)
+	}()
+
+	// Wait until both reverts have been applied. The revert functions hold the
+	// acker's lock until their completions are registered, so once the cache
+	// reflects the reverts, the ACK below is guaranteed to be observed.
+	require.Eventually(t, func() bool {
+		res0, err := cache.Lookup(typeURL, resources[0].Name)
+		if err != nil {
+			return false
+		}
+		res2, err := cache.Lookup(typeURL, resources[2].Name)
+		return err == nil && res0 == nil && res2 == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The reverts created versions 4 and 5; acking version 5 completes both.
+	acker.HandleResourceVersionAck(5, 5, node0, nil, typeURL, "")
+
+	err := <-errCh
+	require.NoError(t, err)
+}
+
+func TestRevertWithTimeoutExpired(t *testing.T) {
+	logger := hivetest.Logger(t)
+	typeURL := "type.googleapis.com/envoy.config.v3.DummyConfiguration"
+	metrics := newMockMetrics()
+
+	cache := NewCache(logger)
+	acker := NewAckingResourceMutatorWrapper(logger, cache, metrics)
+
+	// Create version 2 with resource 0.
+	var revertFuncs AckingResourceMutatorRevertFuncList
+	revertFuncs = append(revertFuncs, acker.Upsert(typeURL, resources[0].Name, resources[0], []string{node0}, nil, nil))
+
+	// With no ACK forthcoming, the revert times out.
+	err := revertFuncs.RevertWithTimeout(context.Background(), MaxCompletionDuration)
+	require.Error(t, err)
+
+	// The revert has still been applied to the cache.
+	res, err := cache.Lookup(typeURL, resources[0].Name)
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
+
+func TestResourceStatusNackThenAck(t *testing.T) {
+	logger := hivetest.Logger(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	typeURL := "type.googleapis.com/envoy.config.v3.DummyConfiguration"
+	wg := completion.NewWaitGroup(ctx)
+	metrics := newMockMetrics()
+
+	cache := NewCache(logger)
+	acker := NewAckingResourceMutatorWrapper(logger, cache, metrics)
+
+	// Nothing is recorded for an unknown resource.
+	_, found := acker.GetResourceStatus(typeURL, resources[0].Name)
+	require.False(t, found)
+
+	// Create version 2 with resource 0 and NACK it.
+	callback, comp := newCompCallback(logger)
+	acker.Upsert(typeURL, resources[0].Name, resources[0], []string{node0}, wg, callback)
+	acker.HandleResourceVersionAck(1, 2, node0, []string{resources[0].Name}, typeURL, "rejected")
+	require.Condition(t, isNotCompletedComparison(comp))
+
+	status, found := acker.GetResourceStatus(typeURL, resources[0].Name)
+	require.True(t, found)
+	require.Equal(t, uint64(2), status.Version)
+	require.Equal(t, "rejected", status.Detail)
+
+	// A NACK for another resource does not disturb the recorded status.
+	_, found = acker.GetResourceStatus(typeURL, resources[1].Name)
+	require.False(t, found)
+
+	// An ACK of an older version does not clear the status.
+	acker.HandleResourceVersionAck(1, 1, node0, []string{resources[0].Name}, typeURL, "")
+	_, found = acker.GetResourceStatus(typeURL, resources[0].Name)
+	require.True(t, found)
+
+	// An ACK of the rejected version clears the status.
+	acker.HandleResourceVersionAck(2, 2, node0, []string{resources[0].Name}, typeURL, "")
+	_, found = acker.GetResourceStatus(typeURL, resources[0].Name)
+	require.False(t, found)
+}
+
+func TestResourceStatusConcurrentUpdates(t *testing.T) {
+	logger := hivetest.Logger(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	typeURL := "type.googleapis.com/envoy.config.v3.DummyConfiguration"
+	wg := completion.NewWaitGroup(ctx)
+	metrics := newMockMetrics()
+
+	cache := NewCache(logger)
+	acker := NewAckingResourceMutatorWrapper(logger, cache, metrics)
+
+	// Two updates of the same resource are in flight as versions 2 and 3.
+	callback1, comp1 := newCompCallback(logger)
+	acker.Upsert(typeURL, resources[0].Name, resources[0], []string{node0}, wg, callback1)
+	callback2, comp2 := newCompCallback(logger)
+	acker.Upsert(typeURL, resources[0].Name, resources[2], []string{node0}, wg, callback2)
+
+	// A NACK covering both pending versions records the most recent one,
+	// regardless of the order the completions happen to be processed in.
+	acker.HandleResourceVersionAck(1, 3, node0, []string{resources[0].Name}, typeURL, "detail")
+	require.Condition(t, isNotCompletedComparison(comp1))
+	require.Condition(t, isNotCompletedComparison(comp2))
+
+	status, found := acker.GetResourceStatus(typeURL, resources[0].Name)
+	require.True(t, found)
+	require.Equal(t, uint64(3), status.Version)
+
+	// ACK of the rejected version 2 alone does not clear version 3's status.
+	acker.HandleResourceVersionAck(2, 2, node0, []string{resources[0].Name}, typeURL, "")
+	_, found = acker.GetResourceStatus(typeURL, resources[0].Name)
+	require.True(t, found)
+
+	// ACK of a version past the rejected one clears the status.
+	acker.HandleResourceVersionAck(4, 4, node0, []string{resources[0].Name}, typeURL, "")
+	_, found = acker.GetResourceStatus(typeURL, resources[0].Name)
+	require.False(t, found)
+}
+
+func TestUpsertAll(t *testing.T) {
+	logger := hivetest.Logger(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	typeURL := "type.googleapis.com/envoy.config.v3.DummyConfiguration"
+	wg := completion.NewWaitGroup(ctx)
+	metrics := newMockMetrics()
+
+	// Empty cache is the version 1
+	cache := NewCache(logger)
+	acker := NewAckingResourceMutatorWrapper(logger, cache, metrics)
+
+	// Create version 2 with resources 0 and 1 in a single transaction.
+	callback, comp := newCompCallback(logger)
+	acker.UpsertAll(typeURL, map[string]proto.Message{
+		resources[0].Name: resources[0],
+		resources[1].Name: resources[1],
+	}, []string{node0}, wg, callback)
+
+	res, err := cache.GetResources(typeURL, 0, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), res.Version)
+	require.Len(t, res.Resources, 2)
+
+	// ACK of only one of the resources does not complete the update.
+	acker.HandleResourceVersionAck(2, 2, node0, []string{resources[0].Name}, typeURL, "")
+	require.Condition(t, isNotCompletedComparison(comp))
+
+	// ACK of the remaining resource completes the update.
+	acker.HandleResourceVersionAck(2, 2, node0, []string{resources[1].Name}, typeURL, "")
+	require.Condition(t, completedComparison(comp))
+	require.Equal(t, 1, metrics.nack[typeURL])
+	require.Equal(t, 0, metrics.ack[typeURL])
+}
@@ -5,8 +5,10 @@ package xds
 
 import (
 	"log/slog"
+	"maps"
 	"slices"
 
+	lru "github.com/hashicorp/golang-lru/v2"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/cilium/cilium/pkg/logging/logfields"
@@ -29,6 +31,45 @@ type Cache struct {
 	// valid version numbers start at 1, which is the version of a cache
 	// before any modifications have been made
 	version uint64
+
+	// maxEntries bounds the number of resources held in the cache when
+	// positive. See WithMaxEntries.
+	maxEntries int
+
+	// lru tracks the order in which the cached resources were last inserted,
+	// updated or requested, to pick a victim to evict once the cache grows
+	// beyond maxEntries. It stores no values; resources remains the
+	// authoritative store. Only set when maxEntries is positive.
+	lru *lru.Cache[cacheKey, struct{}]
+
+	// entriesByType is the number of cached resources per resource type URL.
+	entriesByType map[string]int
+
+	// metrics, if set via WithMetrics, receives the per-type entry counts
+	// whenever they change.
+	metrics Metrics
+}
+
+// CacheOption modifies the behavior of a Cache at creation time.
+type CacheOption func(*Cache)
+
+// WithMaxEntries bounds the cache to n resources, evicting the least
+// recently used resource whenever an insertion would exceed the limit.
+// It must only be set on caches of resource types that are not ACK-tracked
+// (routes, clusters, endpoints and secrets): evicting a listener would leave
+// the ACK bookkeeping of its pending updates dangling.
+func WithMaxEntries(n int) CacheOption {
+	return func(c *Cache) {
+		c.maxEntries = n
+	}
+}
+
+// WithMetrics sets the sink through which the cache reports its per-type
+// entry counts whenever they change.
+func WithMetrics(m Metrics) CacheOption {
+	return func(c *Cache) {
+		c.metrics = m
+	}
 }
 
 // cacheKey uniquely identifies a resource.
@@ -52,13 +93,69 @@ type cacheValue struct {
 }
 
 // NewCache creates a new, empty cache with 0 as its current version.
-func NewCache(logger *slog.Logger) *Cache {
-	return &Cache{
+func NewCache(logger *slog.Logger, opts ...CacheOption) *Cache {
+	c := &Cache{
 		logger:                       logger,
 		BaseObservableResourceSource: NewBaseObservableResourceSource(),
 		resources:                    make(map[cacheKey]cacheValue),
+		entriesByType:                make(map[string]int),
 		version:                      1,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.maxEntries > 0 {
+		// lru.New only returns an error for non-positive sizes.
+		c.lru, _ = lru.New[cacheKey, struct{}](c.maxEntries)
+	}
+	return c
+}
+
+// markUsedLocked records the use of the given key for eviction purposes and
+// evicts the least recently used resource if inserting the key would grow the
+// cache beyond its configured maximum size. The cache mutex must be held for
+// writing.
+func (c *Cache) markUsedLocked(k cacheKey) {
+	if c.lru == nil {
+		return
+	}
+	if c.lru.Len() >= c.maxEntries && !c.lru.Contains(k) {
+		if victim, _, ok := c.lru.GetOldest(); ok {
+			c.lru.Remove(victim)
+			delete(c.resources, victim)
+			c.adjustEntryCountLocked(victim.typeURL, -1)
+			c.logger.Warn(
+				"Cache size limit reached, evicting least recently used resource",
+				logfields.XDSTypeURL, victim.typeURL,
+				logfields.XDSResourceName, victim.resourceName,
+			)
+		}
+	}
+	c.lru.Add(k, struct{}{})
+}
+
+// adjustEntryCountLocked updates the entry count of the given resource type
+// by delta and reports the new count through the metrics sink, if any. The
+// cache mutex must be held for writing.
+func (c *Cache) adjustEntryCountLocked(typeURL string, delta int) {
+	count := c.entriesByType[typeURL] + delta
+	if count > 0 {
+		c.entriesByType[typeURL] = count
+	} else {
+		delete(c.entriesByType, typeURL)
+		count = 0
+	}
+	if c.metrics != nil {
+		c.metrics.SetCacheEntries(typeURL, count)
+	}
+}
+
+// NumEntriesByType returns the number of resources currently in the cache,
+// per resource type URL.
+func (c *Cache) NumEntriesByType() map[string]int {
+	c.locker.RLock()
+	defer c.locker.RUnlock()
+	return maps.Clone(c.entriesByType)
 }
 
 // TX inserts/updates a set of resources, then deletes a set of resources, then
@@ -122,10 +219,12 @@ func (c *Cache) TX(typeURL string, upsertedResources map[string]proto.Message, d
 					revertDeletedNames = make([]string, 0, len(upsertedResources))
 				}
 				revertDeletedNames = append(revertDeletedNames, name)
+				c.adjustEntryCountLocked(typeURL, 1)
 			}
 			cacheIsUpdated = true
 			v.resource = value
 			c.resources[k] = v
+			c.markUsedLocked(k)
 		}
 	}
 
@@ -145,6 +244,10 @@ func (c *Cache) TX(typeURL string, upsertedResources map[string]proto.Message, d
 
 			cacheIsUpdated = true
 			delete(c.resources, k)
+			c.adjustEntryCountLocked(typeURL, -1)
+			if c.lru != nil {
+				c.lru.Remove(k)
+			}
 		}
 	}
 
@@ -190,6 +293,7 @@ func (c *Cache) Clear(typeURL string) (version uint64, updated bool) {
 
 	scopedLog.Debug("preparing new cache transaction: deleting all entries")
 
+	deleted := 0
 	for k := range c.resources {
 		if k.typeURL == typeURL {
 			scopedLog.Debug(
@@ -197,9 +301,16 @@ func (c *Cache) Clear(typeURL string) (version uint64, updated bool) {
 				logfields.XDSResourceName, k.resourceName,
 			)
 			cacheIsUpdated = true
+			deleted++
 			delete(c.resources, k)
+			if c.lru != nil {
+				c.lru.Remove(k)
+			}
 		}
 	}
+	if deleted > 0 {
+		c.adjustEntryCountLocked(typeURL, -deleted)
+	}
 
 	if cacheIsUpdated {
 		scopedLog.Debug("committing cache transaction and notifying of new version")
@@ -272,6 +383,12 @@ func (c *Cache) GetResources(typeURL string, lastVersion uint64, nodeIP string,
 		k.resourceName = name
 		v, found := c.resources[k]
 		if found {
+			if c.lru != nil {
+				// Mark the requested resource as recently used. The LRU list
+				// is internally synchronized, so mutating it under the read
+				// lock is safe.
+				c.lru.Get(k)
+			}
 			scopedLog.Debug(
 				"resource found, last modified in version",
 				logfields.LastModifiedVersion, v.lastModifiedVersion,
@@ -306,6 +423,40 @@ func (c *Cache) GetResources(typeURL string, lastVersion uint64, nodeIP string,
 	return res, nil
 }
 
+// Touch marks the given resources as modified in a new version without
+// changing their contents, forcing them to be pushed again to all clients
+// watching them. Resource names not present in the cache are ignored.
+// Returns the cache version and whether any resource was touched.
+func (c *Cache) Touch(typeURL string, resourceNames []string) (version uint64, updated bool) {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	newVersion := c.version + 1
+	k := cacheKey{typeURL: typeURL}
+	touched := false
+	for _, name := range resourceNames {
+		k.resourceName = name
+		if v, found := c.resources[k]; found {
+			c.logger.Debug(
+				"touching resource in cache",
+				logfields.XDSTypeURL, typeURL,
+				logfields.XDSResourceName, name,
+			)
+			v.lastModifiedVersion = newVersion
+			c.resources[k] = v
+			c.markUsedLocked(k)
+			touched = true
+		}
+	}
+
+	if touched {
+		c.version = newVersion
+		c.NotifyNewResourceVersionRLocked(typeURL, c.version)
+	}
+
+	return c.version, touched
+}
+
 func (c *Cache) EnsureVersion(typeURL string, version uint64) {
 	c.locker.Lock()
 	defer c.locker.Unlock()
@@ -11,6 +11,131 @@ import (
 	"github.com/google/go-cmp/cmp"
 )
 
+func TestTouch(t *testing.T) {
+	logger := hivetest.Logger(t)
+	c := NewCache(logger)
+	c.resources[cacheKey{typeURL: "a", resourceName: "a1"}] = cacheValue{lastModifiedVersion: 1}
+	c.resources[cacheKey{typeURL: "a", resourceName: "a2"}] = cacheValue{lastModifiedVersion: 1}
+	c.version = 1
+
+	// Touching only unknown resources leaves the version unchanged.
+	version, updated := c.Touch("a", []string{"c1"})
+	if updated || version != 1 {
+		t.Fatalf("Touch of unknown resource: got (%v, %v), want (1, false)", version, updated)
+	}
+
+	version, updated = c.Touch("a", []string{"a1", "c1"})
+	if !updated || version != 2 {
+		t.Fatalf("Touch: got (%v, %v), want (2, true)", version, updated)
+	}
+	if got := c.resources[cacheKey{typeURL: "a", resourceName: "a1"}].lastModifiedVersion; got != 2 {
+		t.Fatalf("touched resource last modified in version %v, want 2", got)
+	}
+	// Resources not touched keep their last modified version.
+	if got := c.resources[cacheKey{typeURL: "a", resourceName: "a2"}].lastModifiedVersion; got != 1 {
+		t.Fatalf("untouched resource last modified in version %v, want 1", got)
+	}
+}
+
+func TestCacheWithMaxEntries(t *testing.T) {
+	logger := hivetest.Logger(t)
+	c := NewCache(logger, WithMaxEntries(2))
+	typeURL := "type.googleapis.com/envoy.config.route.v3.RouteConfiguration"
+
+	contains := func(name string) bool {
+		_, found := c.resources[cacheKey{typeURL: typeURL, resourceName: name}]
+		return found
+	}
+
+	c.Upsert(typeURL, "r0", resources[0])
+	c.Upsert(typeURL, "r1", resources[1])
+	if len(c.resources) != 2 {
+		t.Fatalf("cache holds %v resources, want 2", len(c.resources))
+	}
+
+	// Request r0 so that r1 becomes the least recently used entry.
+	if res, _ := c.GetResources(typeURL, 0, "", []string{"r0"}); res == nil || len(res.Resources) != 1 {
+		t.Fatalf("GetResources(r0): got %v, want 1 resource", res)
+	}
+
+	// Inserting a third resource evicts r1.
+	c.Upsert(typeURL, "r2", resources[2])
+	if len(c.resources) != 2 {
+		t.Fatalf("cache holds %v resources after eviction, want 2", len(c.resources))
+	}
+	if contains("r1") {
+		t.Fatalf("r1 still cached, want it evicted as the least recently used entry")
+	}
+	if !contains("r0") || !contains("r2") {
+		t.Fatalf("r0 and r2 must remain cached")
+	}
+
+	// Updating an already cached resource does not evict anything.
+	c.Upsert(typeURL, "r2", resources[0])
+	if !contains("r0") || !contains("r2") {
+		t.Fatalf("update of a cached resource must not cause an eviction")
+	}
+
+	// Deleting a resource makes room for a new one without an eviction.
+	c.Delete(typeURL, "r0")
+	c.Upsert(typeURL, "r3", resources[1])
+	if !contains("r2") || !contains("r3") {
+		t.Fatalf("insertion after a deletion must not cause an eviction")
+	}
+}
+
+func TestNumEntriesByType(t *testing.T) {
+	logger := hivetest.Logger(t)
+	m := newMockMetrics()
+	c := NewCache(logger, WithMetrics(m))
+
+	if got := c.NumEntriesByType(); len(got) != 0 {
+		t.Fatalf("empty cache reports entries: %v", got)
+	}
+
+	c.Upsert("a", "a1", resources[0])
+	c.Upsert("a", "a2", resources[1])
+	c.Upsert("b", "b1", resources[2])
+
+	want := map[string]int{"a": 2, "b": 1}
+	if diff := cmp.Diff(c.NumEntriesByType(), want); diff != "" {
+		t.Fatalf("entry counts mismatch (-got/+want): %v", diff)
+	}
+	if diff := cmp.Diff(m.cacheEntries, want); diff != "" {
+		t.Fatalf("reported entry counts mismatch (-got/+want): %v", diff)
+	}
+
+	// Updating an existing resource leaves the counts unchanged.
+	c.Upsert("a", "a1", resources[2])
+	if diff := cmp.Diff(c.NumEntriesByType(), want); diff != "" {
+		t.Fatalf("entry counts mismatch after update (-got/+want): %v", diff)
+	}
+
+	c.Delete("a", "a1")
+	want = map[string]int{"a": 1, "b": 1}
+	if diff := cmp.Diff(c.NumEntriesByType(), want); diff != "" {
+		t.Fatalf("entry counts mismatch after deletion (-got/+want): %v", diff)
+	}
+
+	// Clearing a type removes its entry and reports a zero count.
+	c.Clear("a")
+	if diff := cmp.Diff(c.NumEntriesByType(), map[string]int{"b": 1}); diff != "" {
+		t.Fatalf("entry counts mismatch after clear (-got/+want): %v", diff)
+	}
+	if got := m.cacheEntries["a"]; got != 0 {
+		t.Fatalf("reported %v entries of cleared type, want 0", got)
+	}
+
+	// Evictions by the entry limit are accounted for as well.
+	bounded := NewCache(logger, WithMaxEntries(2), WithMetrics(m))
+	bounded.Upsert("a", "a1", resources[0])
+	bounded.Upsert("a", "a2", resources[1])
+	bounded.Upsert("a", "a3", resources[2])
+	if diff := cmp.Diff(bounded.NumEntriesByType(), map[string]int{"a": 2}); diff != "" {
+		t.Fatalf("entry counts mismatch after eviction (-got/+want): %v", diff)
+	}
+}
+
 func TestGetResource(t *testing.T) {
 	logger := hivetest.Logger(t)
 	c := NewCache(logger)
@@ -4,21 +4,69 @@
 package xds
 
 import (
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/cilium/cilium/pkg/metrics"
 	"github.com/cilium/cilium/pkg/metrics/metric"
 )
 
 const (
-	subsystem       = "xds"
-	typeURLLabel    = "type_url"
-	statusLabel     = "status"
-	statusACKValue  = "ack"
-	statusNACKValue = "nack"
+	subsystem         = "xds"
+	envoySubsystem    = "envoy"
+	typeURLLabel      = "type_url"
+	statusLabel       = "status"
+	statusACKValue    = "ack"
+	statusNACKValue   = "nack"
+	resourceLabel     = "resource_name"
+	resourceTypeLabel = "resource_type"
+	resultLabel       = "result"
+	operationLabel    = "operation"
+	endpointIDLabel   = "endpoint_id"
+	directionLabel    = "direction"
+	kindLabel         = "kind"
+
+	kindPortPoliciesValue     = "port_policies"
+	kindRulesValue            = "rules"
+	kindRemoteIdentitiesValue = "remote_identities"
+	kindL7RulesValue          = "l7_rules"
+
+	resultAckedValue   = "acked"
+	resultNackedValue  = "nacked"
+	resultTimeoutValue = "timeout"
+	resultErrorValue   = "error"
 )
 
 type Metrics interface {
 	IncreaseNACK(string)
 	IncreaseACK(string)
+	ObserveResourceSize(string, int)
+	IncreaseValidationError(string, string)
+	IncreaseCoalescedUpdate(string)
+	ObservePolicyUpdateSyncDuration(float64)
+	ObservePolicyAckDuration(string, float64)
+	ObserveResourcesUpdateDuration(string, string, float64)
+	SetNetworkPolicyCounts(endpointID, direction string, portPolicies, rules, remoteIdentities, l7Rules int64)
+	DeleteNetworkPolicyCounts(endpointID string)
+	SetProxyListeners(count int)
+	SetCacheEntries(typeURL string, count int)
+}
+
+// ResultFromError maps the error passed to an ACK callback to the result
+// label value used by the duration histograms.
+func ResultFromError(err error) string {
+	switch {
+	case err == nil:
+		return resultAckedValue
+	case errors.Is(err, ErrNackReceived):
+		return resultNackedValue
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return resultTimeoutValue
+	default:
+		return resultErrorValue
+	}
 }
 
 var _ Metrics = (*XDSMetrics)(nil)
@@ -26,6 +74,41 @@ var _ Metrics = (*XDSMetrics)(nil)
 type XDSMetrics struct {
 	// EventCount is the number of ACK and NACK responses from envoy.
 	EventCount metric.Vec[metric.Counter]
+
+	// ResourceSize is the serialized size of the resources pushed to envoy.
+	ResourceSize metric.Vec[metric.Observer]
+
+	// ValidationErrorCount is the number of generated resources that failed
+	// proto validation and were never pushed to envoy.
+	ValidationErrorCount metric.Vec[metric.Counter]
+
+	// CoalescedUpdateCount is the number of resource updates that were
+	// collapsed into a later push instead of being pushed individually.
+	CoalescedUpdateCount metric.Vec[metric.Counter]
+
+	// PolicyUpdateSyncDuration is the duration of the synchronous part of a
+	// network policy update: translation, validation and cache upsert.
+	PolicyUpdateSyncDuration metric.Histogram
+
+	// PolicyAckDuration is the time between pushing a network policy update
+	// and its acknowledgement by Envoy.
+	PolicyAckDuration metric.Vec[metric.Observer]
+
+	// ResourcesUpdateDuration is the total duration of upserting or updating
+	// Envoy resources, including waiting for acknowledgements.
+	ResourcesUpdateDuration metric.Vec[metric.Observer]
+
+	// NetworkPolicyCount is the per-endpoint, per-direction number of
+	// elements of each kind in the network policy pushed to Envoy.
+	NetworkPolicyCount metric.DeletableVec[metric.Gauge]
+
+	// ProxyListeners is the current number of proxy redirection listeners
+	// configured in Envoy.
+	ProxyListeners metric.Gauge
+
+	// CacheEntries is the number of entries in the xDS caches, per resource
+	// type.
+	CacheEntries metric.Vec[metric.Gauge]
 }
 
 func NewXDSMetric() *XDSMetrics {
@@ -36,6 +119,61 @@ func NewXDSMetric() *XDSMetrics {
 			Name:      "events_count",
 			Help:      "The number of ACK/NACK event responses from Envoy",
 		}, []string{typeURLLabel, statusLabel}),
+		ResourceSize: metric.NewHistogramVec(metric.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "resource_size_bytes",
+			Help:      "The serialized size in bytes of the resources pushed to Envoy",
+			Buckets:   prometheus.ExponentialBuckets(1024, 4, 10),
+		}, []string{typeURLLabel}),
+		ValidationErrorCount: metric.NewCounterVec(metric.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "validation_errors_count",
+			Help:      "The number of generated resources that failed proto validation",
+		}, []string{typeURLLabel, resourceLabel}),
+		CoalescedUpdateCount: metric.NewCounterVec(metric.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "coalesced_updates_count",
+			Help:      "The number of resource updates collapsed into a later push",
+		}, []string{typeURLLabel}),
+		PolicyUpdateSyncDuration: metric.NewHistogram(metric.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "policy_update_sync_duration_seconds",
+			Help:      "Duration of the synchronous part of a network policy update",
+		}),
+		PolicyAckDuration: metric.NewHistogramVec(metric.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "policy_ack_duration_seconds",
+			Help:      "Time between pushing a network policy update and its acknowledgement by Envoy",
+		}, []string{resultLabel}),
+		ResourcesUpdateDuration: metric.NewHistogramVec(metric.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "resources_update_duration_seconds",
+			Help:      "Total duration of upserting or updating Envoy resources",
+		}, []string{operationLabel, resultLabel}),
+		NetworkPolicyCount: metric.NewGaugeVec(metric.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "network_policy_count",
+			Help:      "The number of elements of each kind in the network policy pushed to Envoy, per endpoint and traffic direction",
+		}, []string{endpointIDLabel, directionLabel, kindLabel}),
+		ProxyListeners: metric.NewGauge(metric.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: envoySubsystem,
+			Name:      "proxy_listeners_total",
+			Help:      "The current number of proxy redirection listeners configured in Envoy",
+		}),
+		CacheEntries: metric.NewGaugeVec(metric.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "cache_entries",
+			Help:      "The number of entries in the xDS caches, per resource type",
+		}, []string{resourceTypeLabel}),
 	}
 }
 
@@ -46,3 +184,46 @@ func (x *XDSMetrics) IncreaseNACK(typeURL string) {
 func (x *XDSMetrics) IncreaseACK(typeURL string) {
 	x.EventCount.WithLabelValues(typeURL, statusACKValue).Inc()
 }
+
+func (x *XDSMetrics) ObserveResourceSize(typeURL string, sizeBytes int) {
+	x.ResourceSize.WithLabelValues(typeURL).Observe(float64(sizeBytes))
+}
+
+func (x *XDSMetrics) IncreaseValidationError(typeURL, resourceName string) {
+	x.ValidationErrorCount.WithLabelValues(typeURL, resourceName).Inc()
+}
+
+func (x *XDSMetrics) IncreaseCoalescedUpdate(typeURL string) {
+	x.CoalescedUpdateCount.WithLabelValues(typeURL).Inc()
+}
+
+func (x *XDSMetrics) ObservePolicyUpdateSyncDuration(seconds float64) {
+	x.PolicyUpdateSyncDuration.Observe(seconds)
+}
+
+func (x *XDSMetrics) ObservePolicyAckDuration(result string, seconds float64) {
+	x.PolicyAckDuration.WithLabelValues(result).Observe(seconds)
+}
+
+func (x *XDSMetrics) ObserveResourcesUpdateDuration(operation, result string, seconds float64) {
+	x.ResourcesUpdateDuration.WithLabelValues(operation, result).Observe(seconds)
+}
+
+func (x *XDSMetrics) SetNetworkPolicyCounts(endpointID, direction string, portPolicies, rules, remoteIdentities, l7Rules int64) {
+	x.NetworkPolicyCount.WithLabelValues(endpointID, direction, kindPortPoliciesValue).Set(float64(portPolicies))
+	x.NetworkPolicyCount.WithLabelValues(endpointID, direction, kindRulesValue).Set(float64(rules))
+	x.NetworkPolicyCount.WithLabelValues(endpointID, direction, kindRemoteIdentitiesValue).Set(float64(remoteIdentities))
+	x.NetworkPolicyCount.WithLabelValues(endpointID, direction, kindL7RulesValue).Set(float64(l7Rules))
+}
+
+func (x *XDSMetrics) DeleteNetworkPolicyCounts(endpointID string) {
+	x.NetworkPolicyCount.DeletePartialMatch(prometheus.Labels{endpointIDLabel: endpointID})
+}
+
+func (x *XDSMetrics) SetProxyListeners(count int) {
+	x.ProxyListeners.Set(float64(count))
+}
+
+func (x *XDSMetrics) SetCacheEntries(typeURL string, count int) {
+	x.CacheEntries.WithLabelValues(typeURL).Set(float64(count))
+}
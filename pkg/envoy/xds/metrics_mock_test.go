@@ -4,8 +4,15 @@
 package xds
 
 type mockMetrics struct {
-	ack  map[string]int
-	nack map[string]int
+	ack          map[string]int
+	nack         map[string]int
+	coalesced    map[string]int
+	policySync   int
+	policyAck    map[string]int
+	resourceSync map[string]int
+
+	proxyListeners int
+	cacheEntries   map[string]int
 }
 
 func (m *mockMetrics) IncreaseNACK(typeURL string) {
@@ -16,9 +23,46 @@ func (m *mockMetrics) IncreaseACK(typeURL string) {
 	m.nack[typeURL]++
 }
 
+func (m *mockMetrics) ObserveResourceSize(typeURL string, sizeBytes int) {}
+
+func (m *mockMetrics) IncreaseValidationError(typeURL, resourceName string) {}
+
+func (m *mockMetrics) IncreaseCoalescedUpdate(typeURL string) {
+	m.coalesced[typeURL]++
+}
+
+func (m *mockMetrics) ObservePolicyUpdateSyncDuration(seconds float64) {
+	m.policySync++
+}
+
+func (m *mockMetrics) ObservePolicyAckDuration(result string, seconds float64) {
+	m.policyAck[result]++
+}
+
+func (m *mockMetrics) ObserveResourcesUpdateDuration(operation, result string, seconds float64) {
+	m.resourceSync[operation+"/"+result]++
+}
+
+func (m *mockMetrics) SetNetworkPolicyCounts(endpointID, direction string, portPolicies, rules, remoteIdentities, l7Rules int64) {
+}
+
+func (m *mockMetrics) DeleteNetworkPolicyCounts(endpointID string) {}
+
+func (m *mockMetrics) SetProxyListeners(count int) {
+	m.proxyListeners = count
+}
+
+func (m *mockMetrics) SetCacheEntries(typeURL string, count int) {
+	m.cacheEntries[typeURL] = count
+}
+
 func newMockMetrics() *mockMetrics {
 	return &mockMetrics{
-		ack:  map[string]int{},
-		nack: map[string]int{},
+		ack:          map[string]int{},
+		nack:         map[string]int{},
+		coalesced:    map[string]int{},
+		policyAck:    map[string]int{},
+		resourceSync: map[string]int{},
+		cacheEntries: map[string]int{},
 	}
 }
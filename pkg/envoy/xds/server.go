@@ -19,6 +19,7 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/cilium/cilium/pkg/endpointstate"
+	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging/logfields"
 	"github.com/cilium/cilium/pkg/promise"
 )
@@ -53,6 +54,11 @@ var (
 	// occurs while waiting for new versions of resources.
 	ErrResourceWatch = errors.New("resource watch failed")
 
+	// ErrProxyNotReady is the error returned by operations invoked with
+	// fail-fast semantics before a client has subscribed to the resource
+	// type on an xDS stream.
+	ErrProxyNotReady = errors.New("proxy not ready: no xDS stream subscribed to the resource type")
+
 	// grpcCanceled is the string prefix of any gRPC error related
 	// to the stream being canceled. Ignore the description, as it
 	// is derived from the client and may vary, while the code is
@@ -78,6 +84,14 @@ type Server struct {
 	// It is incremented atomically when starting the handling of a new stream.
 	lastStreamID atomic.Uint64
 
+	// readyMutex protects readyTypes.
+	readyMutex lock.RWMutex
+
+	// readyTypes tracks for each supported type URL whether a client has
+	// subscribed to the resource type on an xDS stream since the server was
+	// created.
+	readyTypes map[string]bool
+
 	metrics Metrics
 }
 
@@ -99,7 +113,9 @@ type ResourceTypeConfiguration struct {
 func NewServer(logger *slog.Logger, resourceTypes map[string]*ResourceTypeConfiguration, restorerPromise promise.Promise[endpointstate.Restorer], metrics Metrics) *Server {
 	watchers := make(map[string]*ResourceWatcher, len(resourceTypes))
 	ackObservers := make(map[string]ResourceVersionAckObserver, len(resourceTypes))
+	readyTypes := make(map[string]bool, len(resourceTypes))
 	for typeURL, resType := range resourceTypes {
+		readyTypes[typeURL] = false
 		w := NewResourceWatcher(logger, typeURL, resType.Source)
 		resType.Source.AddResourceVersionObserver(w)
 		watchers[typeURL] = w
@@ -114,7 +130,7 @@ func NewServer(logger *slog.Logger, resourceTypes map[string]*ResourceTypeConfig
 
 	// TODO: Unregister the watchers when stopping the server.
 
-	return &Server{logger: logger, watchers: watchers, ackObservers: ackObservers, metrics: metrics}
+	return &Server{logger: logger, watchers: watchers, ackObservers: ackObservers, readyTypes: readyTypes, metrics: metrics}
 }
 
 func (s *Server) RestoreCompleted() {
@@ -123,6 +139,35 @@ func (s *Server) RestoreCompleted() {
 	}
 }
 
+// markTypeReady records that a client has subscribed to the given resource
+// type on an xDS stream.
+func (s *Server) markTypeReady(typeURL string) {
+	s.readyMutex.Lock()
+	s.readyTypes[typeURL] = true
+	s.readyMutex.Unlock()
+}
+
+// TypeReady returns true if a client has subscribed to the given resource
+// type on an xDS stream since the server was created.
+func (s *Server) TypeReady(typeURL string) bool {
+	s.readyMutex.RLock()
+	defer s.readyMutex.RUnlock()
+	return s.readyTypes[typeURL]
+}
+
+// Readiness returns for each supported type URL whether a client has
+// subscribed to the resource type on an xDS stream since the server was
+// created.
+func (s *Server) Readiness() map[string]bool {
+	s.readyMutex.RLock()
+	defer s.readyMutex.RUnlock()
+	readiness := make(map[string]bool, len(s.readyTypes))
+	for typeURL, ready := range s.readyTypes {
+		readiness[typeURL] = ready
+	}
+	return readiness
+}
+
 func getXDSRequestFields(req *envoy_service_discovery.DiscoveryRequest) []any {
 	return []any{
 		logfields.XDSAckedVersion, req.GetVersionInfo(),
@@ -349,6 +394,8 @@ func (s *Server) processRequestStream(ctx context.Context, streamLog *slog.Logge
 			state := &typeStates[index]
 			watcher := s.watchers[typeURL]
 
+			s.markTypeReady(typeURL)
+
 			if lastReceivedVersion > 0 {
 				// Non-zero lastReceivedVersion indicates that we have already sent
 				// a response to the client and client saw response.
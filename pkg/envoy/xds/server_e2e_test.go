@@ -211,6 +211,68 @@ func TestRequestAllResources(t *testing.T) {
 	}
 }
 
+func TestTypeReadiness(t *testing.T) {
+	logger := hivetest.Logger(t)
+	typeURL := "type.googleapis.com/envoy.config.v3.DummyConfiguration"
+	metrics := newMockMetrics()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestTimeout)
+	defer cancel()
+
+	cache := NewCache(logger)
+	mutator := NewAckingResourceMutatorWrapper(logger, cache, metrics)
+
+	streamCtx, closeStream := context.WithCancel(ctx)
+	stream := NewMockStream(streamCtx, 1, 1, StreamTimeout, StreamTimeout)
+	defer stream.Close()
+
+	server := NewServer(logger, map[string]*ResourceTypeConfiguration{typeURL: {Source: cache, AckObserver: mutator}}, nil, metrics)
+
+	// No client has connected yet, so the resource type is not ready.
+	require.False(t, server.TypeReady(typeURL))
+	require.Equal(t, map[string]bool{typeURL: false}, server.Readiness())
+
+	streamDone := make(chan struct{})
+
+	// Run the server's stream handler concurrently.
+	go func() {
+		defer close(streamDone)
+		err := server.HandleRequestStream(ctx, stream, AnyTypeURL)
+		require.NoError(t, err)
+	}()
+
+	// Request all resources.
+	err := stream.SendRequest(&envoy_service_discovery.DiscoveryRequest{
+		TypeUrl:       typeURL,
+		VersionInfo:   "",
+		Node:          nodes[node0],
+		ResourceNames: nil,
+		ResponseNonce: "",
+	})
+	require.NoError(t, err)
+
+	// Expecting an empty response.
+	resp, err := stream.RecvResponse()
+	require.NoError(t, err)
+	require.Condition(t, responseCheck(resp, "1", nil, false, typeURL))
+
+	// The client has subscribed to the resource type, so it is now ready.
+	require.True(t, server.TypeReady(typeURL))
+	require.Equal(t, map[string]bool{typeURL: true}, server.Readiness())
+
+	// Unsupported resource types are never ready.
+	require.False(t, server.TypeReady("type.googleapis.com/envoy.config.v3.UnknownConfiguration"))
+
+	// Close the stream.
+	closeStream()
+
+	select {
+	case <-ctx.Done():
+		t.Errorf("HandleRequestStream(%v, %v, %v) took too long to return after stream was closed", "ctx", "stream", AnyTypeURL)
+	case <-streamDone:
+	}
+}
+
 func TestAck(t *testing.T) {
 	logger := hivetest.Logger(t)
 	typeURL := "type.googleapis.com/envoy.config.v3.DummyConfiguration"
@@ -854,7 +916,8 @@ func TestNAck(t *testing.T) {
 
 	// Version 2 was NACKed by the last request, so comp1 must NOT be completedInTime ever.
 	require.Condition(t, isNotCompletedComparison(comp1))
-	require.EqualValues(t, &ProxyError{Err: ErrNackReceived, Detail: "NACKNACK"}, comp1.Err())
+	require.ErrorIs(t, comp1.Err(), ErrNackReceived)
+	require.ErrorContains(t, comp1.Err(), "NACKNACK")
 
 	// Expecting a response with both resources.
 	// Note that the stream should not have a message that repeats the previous one!
@@ -984,7 +1047,7 @@ func TestNAckFromTheStart(t *testing.T) {
 
 	// Version 2 did not have a callback, so the completion was completedInTime with an error
 	require.Error(t, comp1.Err())
-	require.EqualValues(t, &ProxyError{Err: ErrNackReceived}, comp1.Err())
+	require.ErrorIs(t, comp1.Err(), ErrNackReceived)
 
 	// NACK canceled the WaitGroup, create new one
 	wg = completion.NewWaitGroup(ctx)
@@ -66,6 +66,17 @@ type ResourceMutator interface {
 	// method call.
 	Upsert(typeURL string, resourceName string, resource proto.Message) (version uint64, updated bool, revert ResourceMutatorRevertFunc)
 
+	// TX inserts or updates the given resources and then deletes the named
+	// resources as one transaction.
+	// If the set is modified at all, the set's version number is incremented
+	// atomically exactly once and the returned updated value is true.
+	// Otherwise, the version number is not modified and the returned updated
+	// value is false.
+	// The returned version value is the set's version after update.
+	// A call to the returned revert function reverts the effects of this
+	// method call.
+	TX(typeURL string, upsertedResources map[string]proto.Message, deletedNames []string) (version uint64, updated bool, revert ResourceMutatorRevertFunc)
+
 	// Delete deletes a resource from this set by name.
 	// If the set is modified (the resource is actually deleted), the set's
 	// version number is incremented atomically and the returned updated value
@@ -5,15 +5,18 @@ package envoy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"maps"
 	"net"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
+	"syscall"
 
 	cilium "github.com/cilium/proxy/go/cilium/api"
 	"github.com/cilium/proxy/pkg/policy/api/kafka"
@@ -23,14 +26,24 @@ import (
 	envoy_config_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	envoy_config_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoy_config_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_extensions_compression_gzip_compressor_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/gzip/compressor/v3"
+	envoy_extensions_filters_http_compressor_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/compressor/v3"
 	envoy_extensions_filters_http_router_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
 	envoy_upstream_codec "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/upstream_codec/v3"
 	envoy_extensions_listener_tls_inspector_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/tls_inspector/v3"
 	envoy_config_http "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	envoy_mongo_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/mongo_proxy/v3"
 	envoy_config_tcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	envoy_retry_previous_priorities "github.com/envoyproxy/go-control-plane/envoy/extensions/retry/priority/previous_priorities/v3"
+	envoy_internal_upstream "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/internal_upstream/v3"
+	envoy_raw_buffer "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/raw_buffer/v3"
 	envoy_config_tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoy_type_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	envoy_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -75,6 +88,11 @@ var (
 const (
 	CiliumXDSClusterName = "xds-grpc-cilium"
 
+	// OriginalDstEgressClusterName is the default name of the shared
+	// ORIGINAL_DST egress cluster that CiliumEnvoyConfig routes may reference
+	// without defining it themselves.
+	OriginalDstEgressClusterName = "egress-cluster-original-dst"
+
 	adminClusterName      = "/envoy-admin"
 	egressClusterName     = "egress-cluster"
 	egressTLSClusterName  = "egress-cluster-tls"
@@ -86,8 +104,11 @@ const (
 
 // XDSServer provides a high-lever interface to manage resources published using the xDS gRPC API.
 type XDSServer interface {
-	// AddListener adds a listener to a running Envoy proxy.
-	AddListener(name string, kind policy.L7ParserType, port uint16, isIngress bool, mayUseOriginalSourceAddr bool, wg *completion.WaitGroup, cb func(err error)) error
+	// AddListener adds a listener to a running Envoy proxy. With failFast
+	// set, xds.ErrProxyNotReady is returned immediately if Envoy has not
+	// subscribed to listeners on an xDS stream yet; without it the update
+	// waits in the cache until Envoy connects, as usual.
+	AddListener(name string, kind policy.L7ParserType, port uint16, isIngress bool, mayUseOriginalSourceAddr bool, failFast bool, wg *completion.WaitGroup, cb func(err error)) error
 	// AddAdminListener adds an Admin API listener to Envoy.
 	AddAdminListener(port uint16, wg *completion.WaitGroup)
 	// AddMetricsListener adds a prometheus metrics listener to Envoy.
@@ -112,6 +133,11 @@ type XDSServer interface {
 	//
 	// Only used for testing
 	GetNetworkPolicies(resourceNames []string) (map[string]*cilium.NetworkPolicy, error)
+	// GetNetworkPoliciesWithVersion works like GetNetworkPolicies, but also
+	// returns the cache version at which the snapshot was taken. The version
+	// can be compared to the last ACKed version to tell whether Envoy has
+	// received the returned policies.
+	GetNetworkPoliciesWithVersion(resourceNames []string) (map[string]*cilium.NetworkPolicy, uint64, error)
 	// UseCurrentNetworkPolicy waits for any pending update on NetworkPolicy to be acked.
 	UseCurrentNetworkPolicy(ep endpoint.EndpointUpdater, policy *policy.L4Policy, wg *completion.WaitGroup)
 	// UpdateNetworkPolicy adds or updates a network policy in the set published to L7 proxies.
@@ -125,12 +151,29 @@ type XDSServer interface {
 	// RemoveAllNetworkPolicies removes all network policies from the set published
 	// to L7 proxies.
 	RemoveAllNetworkPolicies()
+
+	// ProxyReadiness returns for each xDS resource type URL whether Envoy
+	// has subscribed to the resource type on an xDS stream.
+	ProxyReadiness() map[string]bool
+
+	// AddBootstrapExtension registers an extension, e.g. a custom transport
+	// socket factory supported by a custom Envoy build, to be serialized
+	// into the bootstrap configuration of the embedded Envoy. The bootstrap
+	// is only written when the proxy starts, so extensions must be
+	// registered before that.
+	AddBootstrapExtension(pb proto.Message)
 }
 
 type xdsServer struct {
 	logger *slog.Logger
 
-	// socketPath is the path to the gRPC UNIX domain socket.
+	// tracer emits spans for the resource push operations, so that Envoy
+	// policy update latency shows up in distributed traces. Never nil; a
+	// no-op tracer is used when tracing is not configured.
+	tracer trace.Tracer
+
+	// socketPath is the address of the gRPC UNIX domain socket. A leading
+	// '@' denotes an address in the abstract namespace.
 	socketPath string
 
 	// accessLogPath is the path to the L7 access logs
@@ -145,6 +188,11 @@ type xdsServer struct {
 	// Manages it's own locking
 	listenerMutator xds.AckingResourceMutator
 
+	// listenerCache holds the listener configurations pushed to Envoy proxies.
+	// Used to detect when the generated configuration of a reused listener has
+	// changed.
+	listenerCache *xds.Cache
+
 	// routeMutator publishes route updates to Envoy proxies.
 	// Manages it's own locking
 	routeMutator xds.AckingResourceMutator
@@ -167,6 +215,12 @@ type xdsServer struct {
 	// Value holds the number of redirects using the listener named by the key.
 	listenerCount map[string]uint
 
+	// listenerConfs holds the configuration generator of each listener added
+	// with addListener, keyed by listener name, so that the listeners can be
+	// regenerated and upserted in place when the proxy configuration changes.
+	// mutex must be held when accessing this.
+	listenerConfs map[string]func() *envoy_config_listener.Listener
+
 	// proxyListeners is the count of redirection proxy listeners in 'listeners'.
 	// When this is zero, cilium should not wait for NACKs/ACKs from envoy.
 	// This value is different from len(listeners) due to non-proxy listeners
@@ -184,6 +238,11 @@ type xdsServer struct {
 
 	resourceConfig map[string]*xds.ResourceTypeConfiguration
 
+	// streamServer handles the xDS streams and tracks per-resource-type
+	// readiness. Created together with the resource configurations, before
+	// the gRPC server starts serving it.
+	streamServer *xds.Server
+
 	// stopFunc contains the function which stops the xDS gRPC server.
 	stopFunc context.CancelFunc
 
@@ -197,6 +256,36 @@ type xdsServer struct {
 
 	l7RulesTranslator envoypolicy.EnvoyL7RulesTranslator
 	secretManager     certificatemanager.SecretManager
+
+	// coalescedPolicyUpdates holds the deferred network policy update per
+	// NPDS resource name while the coalescing window is open.
+	// mutex must be held when accessing this.
+	coalescedPolicyUpdates map[string]*coalescedPolicyUpdate
+
+	// secretToPolicies maps the name of each SDS secret referenced by a
+	// pushed network policy to the IDs of the endpoints whose policy
+	// references it. mutex must be held when accessing this.
+	secretToPolicies map[string][]uint64
+
+	// policyToSecrets is the inverse of secretToPolicies, used to drop stale
+	// references when a policy is updated or removed.
+	// mutex must be held when accessing this.
+	policyToSecrets map[uint64][]string
+
+	// rotationWatchedSecrets contains the names of the secrets for which a
+	// rotation callback has been registered with the secret manager.
+	// mutex must be held when accessing this.
+	rotationWatchedSecrets map[string]struct{}
+}
+
+// coalescedPolicyUpdate is a network policy upsert deferred until the
+// coalescing window closes, holding the latest generated policy and the
+// callbacks of all the calls collapsed into it.
+type coalescedPolicyUpdate struct {
+	timer         *time.Timer
+	networkPolicy *cilium.NetworkPolicy
+	nodeIDs       []string
+	callbacks     []func(error)
 }
 
 func toAny(pb proto.Message) *anypb.Any {
@@ -209,6 +298,7 @@ func toAny(pb proto.Message) *anypb.Any {
 
 type xdsServerConfig struct {
 	envoySocketDir                string
+	xdsSocketMode                 string
 	proxyGID                      int
 	httpRequestTimeout            int
 	httpIdleTimeout               int
@@ -221,22 +311,143 @@ type xdsServerConfig struct {
 	useSDS                        bool
 	proxyXffNumTrustedHopsIngress uint32
 	proxyXffNumTrustedHopsEgress  uint32
-	policyRestoreTimeout          time.Duration
-	metrics                       xds.Metrics
-	httpLingerConfig              int
+	// mayUseOriginalSourceAddrIngress and mayUseOriginalSourceAddrEgress gate
+	// the use of the original source address per listener direction, on top of
+	// the datapath support reported by the caller of AddListener.
+	mayUseOriginalSourceAddrIngress bool
+	mayUseOriginalSourceAddrEgress  bool
+	policyRestoreTimeout            time.Duration
+	metrics                         xds.Metrics
+	httpLingerConfig                int
+	// lingerConfigByParser overrides the SO_LINGER time of the bpf_metadata
+	// listener filter per L7 parser type, so that TCP-based parsers like
+	// Kafka can avoid RST storms on connection close. Parser types without
+	// an entry fall back to httpLingerConfig for HTTP and -1 (disabled) for
+	// all other types.
+	lingerConfigByParser map[policy.L7ParserType]int
+	networkPolicyMaxSize int
+	// includeEndpointLabels populates the endpoint's sorted identity labels
+	// in generated network policies, so that the cilium Envoy filter can
+	// enrich access logs without an ipcache lookback. Off by default as it
+	// grows the NPDS resource size.
+	includeEndpointLabels bool
+	l7lbOutlierDetection  outlierDetectionDefaults
+	// useInternalListeners creates redirect listeners as Envoy internal
+	// listeners instead of binding a TCP port on loopback (experimental).
+	useInternalListeners bool
+	// useAlpnChainSelection adds an HTTP filter chain matching on the ALPN
+	// protocols negotiated in the TLS handshake to listeners created for
+	// non-HTTP parsers, so that TLS connections carrying HTTP still get L7
+	// visibility.
+	useAlpnChainSelection bool
+	// useListenerStatPrefix namespaces the Envoy stats of the HTTP connection
+	// manager and TCP proxy filters by the listener name, instead of merging
+	// the stats of all listeners under the shared "proxy" and "tcp_proxy"
+	// prefixes.
+	useListenerStatPrefix bool
+	// compressMetrics serves the prometheus metrics listener responses
+	// gzip-compressed, reducing the scraping bandwidth for deployments with
+	// many time series.
+	compressMetrics bool
+	// tcpProxyTunnelMode, when non-empty, tunnels the TCP payload proxied
+	// by the generated TCP filter chains through an HTTP proxy.
+	// "pre_connect" establishes the tunnel with an HTTP/1.1 CONNECT
+	// request, "post_connect" uses a POST request instead, for proxies
+	// that do not accept CONNECT.
+	tcpProxyTunnelMode string
+	// tcpProxyTunnelHost is the host:port the tunnel is requested for when
+	// tcpProxyTunnelMode is set.
+	tcpProxyTunnelHost string
+	// tcpProxyTunnelCluster, when non-empty, is the cluster of the tunnel
+	// endpoint, configured independently from the payload cluster of each
+	// filter chain.
+	tcpProxyTunnelCluster string
+	// debugDumpDir, if non-empty, is the directory into which every resource
+	// pushed through the xDS mutators is dumped as a timestamped protojson
+	// file for debugging.
+	debugDumpDir string
+	// tlsMinVersion, if non-empty, is the minimum TLS protocol version for
+	// TLS connections terminated or originated by the proxy, given as an
+	// Envoy TlsProtocol enum value name (e.g. "TLSv1_2"). Policy rules may
+	// set a higher minimum for themselves.
+	tlsMinVersion string
+	// tlsCipherSuites, if non-empty, are the cipher suites allowed on TLS
+	// 1.0-1.2 connections terminated or originated by the proxy, for policy
+	// rules that do not list their own.
+	tlsCipherSuites []string
+	// useTransactionalUpserts commits the resources of each type URL in an
+	// UpsertEnvoyResources call as a single cache transaction, so that Envoy
+	// observes each type's new state in one DiscoveryResponse instead of one
+	// per resource.
+	useTransactionalUpserts bool
+	// policyCoalesceWindow, if positive, is the window during which repeated
+	// network policy updates for the same endpoint are collapsed into a
+	// single push using the latest policy, bounding the NPDS version churn
+	// during large-scale identity changes. Updates that wait for an ACK
+	// bypass the window. Zero disables coalescing.
+	policyCoalesceWindow time.Duration
+	// httpMaxRequestHeadersKb and httpMaxResponseHeadersKb bound the total
+	// size of the HTTP headers accepted in each direction, in kibibytes.
+	// Without a bound a client or upstream can exhaust proxy memory with
+	// oversized headers. Zero leaves the Envoy default in place (60 KiB for
+	// requests, unlimited for responses).
+	httpMaxRequestHeadersKb  uint32
+	httpMaxResponseHeadersKb uint32
+	// httpForwardTrailers makes the proxy forward HTTP/1.1 trailers, e.g.
+	// gRPC status trailers, instead of silently dropping them. HTTP/2
+	// forwards trailers regardless.
+	httpForwardTrailers bool
+	// mirrorClusterName, if non-empty, is the name of the shadow cluster to
+	// which HTTP requests handled by the generated proxy listeners are
+	// mirrored. The mirrored request is fire-and-forget and does not affect
+	// the response returned to the client.
+	mirrorClusterName string
+	// mirrorPercent is the percentage of requests mirrored to
+	// mirrorClusterName. Values above 100 are treated as 100.
+	mirrorPercent uint32
+	// httpRetryBufferLimitBytes, if positive, buffers up to this many bytes
+	// of each request so that retries can replay the request body. Without
+	// buffering, a retry fails as soon as part of a request body has been
+	// forwarded upstream, so only header-only requests benefit from the
+	// retry policy. Zero disables buffering.
+	httpRetryBufferLimitBytes uint32
+	// envoyBootstrapExtensions are additional bootstrap extensions
+	// serialized into the bootstrap configuration of the embedded Envoy,
+	// e.g. custom transport socket factories shipped by a custom Envoy
+	// build. Registered via AddBootstrapExtension.
+	envoyBootstrapExtensions []proto.Message
+}
+
+// outlierDetectionDefaults holds the parameters of the outlier detection
+// configuration applied to L7 LB clusters that don't define one.
+type outlierDetectionDefaults struct {
+	enabled            bool
+	consecutive5xx     uint32
+	interval           time.Duration
+	baseEjectionTime   time.Duration
+	maxEjectionPercent uint32
 }
 
 // newXDSServer creates a new xDS GRPC server.
-func newXDSServer(logger *slog.Logger, restorerPromise promise.Promise[endpointstate.Restorer], ipCache IPCacheEventSource, localEndpointStore *LocalEndpointStore, config xdsServerConfig, secretManager certificatemanager.SecretManager) *xdsServer {
+func newXDSServer(logger *slog.Logger, restorerPromise promise.Promise[endpointstate.Restorer], ipCache IPCacheEventSource, localEndpointStore *LocalEndpointStore, config xdsServerConfig, secretManager certificatemanager.SecretManager, tracer trace.Tracer) *xdsServer {
+	if tracer == nil {
+		tracer = noop.NewTracerProvider().Tracer("")
+	}
 	xdsServer := &xdsServer{
-		logger:             logger,
-		restorerPromise:    restorerPromise,
-		listenerCount:      make(map[string]uint),
-		ipCache:            ipCache,
-		localEndpointStore: localEndpointStore,
-
-		socketPath:    getXDSSocketPath(config.envoySocketDir),
-		accessLogPath: getAccessLogSocketPath(config.envoySocketDir),
+		logger:                 logger,
+		tracer:                 tracer,
+		restorerPromise:        restorerPromise,
+		listenerCount:          make(map[string]uint),
+		listenerConfs:          make(map[string]func() *envoy_config_listener.Listener),
+		ipCache:                ipCache,
+		localEndpointStore:     localEndpointStore,
+		coalescedPolicyUpdates: make(map[string]*coalescedPolicyUpdate),
+		secretToPolicies:       make(map[string][]uint64),
+		policyToSecrets:        make(map[uint64][]string),
+		rotationWatchedSecrets: make(map[string]struct{}),
+
+		socketPath:    getXDSSocketAddress(config.envoySocketDir, config.xdsSocketMode),
+		accessLogPath: GetAccessLogSocketPath(config.envoySocketDir),
 		config:        config,
 		secretManager: secretManager,
 	}
@@ -252,48 +463,52 @@ func (s *xdsServer) start() error {
 		return fmt.Errorf("failed to create socket listener: %w", err)
 	}
 
-	s.stopFunc = s.startXDSGRPCServer(socketListener, s.resourceConfig)
+	s.stopFunc = s.startXDSGRPCServer(socketListener)
+
+	if option.Config.Debug {
+		s.registerDebugHandlers()
+	}
 
 	return nil
 }
 
 func (s *xdsServer) initializeXdsConfigs() {
-	ldsCache := xds.NewCache(s.logger)
+	ldsCache := xds.NewCache(s.logger, xds.WithMetrics(s.config.metrics))
 	ldsMutator := xds.NewAckingResourceMutatorWrapper(s.logger, ldsCache, s.config.metrics)
 	ldsConfig := &xds.ResourceTypeConfiguration{
 		Source:      ldsCache,
 		AckObserver: ldsMutator,
 	}
 
-	rdsCache := xds.NewCache(s.logger)
+	rdsCache := xds.NewCache(s.logger, xds.WithMetrics(s.config.metrics))
 	rdsMutator := xds.NewAckingResourceMutatorWrapper(s.logger, rdsCache, s.config.metrics)
 	rdsConfig := &xds.ResourceTypeConfiguration{
 		Source:      rdsCache,
 		AckObserver: rdsMutator,
 	}
 
-	cdsCache := xds.NewCache(s.logger)
+	cdsCache := xds.NewCache(s.logger, xds.WithMetrics(s.config.metrics))
 	cdsMutator := xds.NewAckingResourceMutatorWrapper(s.logger, cdsCache, s.config.metrics)
 	cdsConfig := &xds.ResourceTypeConfiguration{
 		Source:      cdsCache,
 		AckObserver: cdsMutator,
 	}
 
-	edsCache := xds.NewCache(s.logger)
+	edsCache := xds.NewCache(s.logger, xds.WithMetrics(s.config.metrics))
 	edsMutator := xds.NewAckingResourceMutatorWrapper(s.logger, edsCache, s.config.metrics)
 	edsConfig := &xds.ResourceTypeConfiguration{
 		Source:      edsCache,
 		AckObserver: edsMutator,
 	}
 
-	sdsCache := xds.NewCache(s.logger)
+	sdsCache := xds.NewCache(s.logger, xds.WithMetrics(s.config.metrics))
 	sdsMutator := xds.NewAckingResourceMutatorWrapper(s.logger, sdsCache, s.config.metrics)
 	sdsConfig := &xds.ResourceTypeConfiguration{
 		Source:      sdsCache,
 		AckObserver: sdsMutator,
 	}
 
-	npdsCache := xds.NewCache(s.logger)
+	npdsCache := xds.NewCache(s.logger, xds.WithMetrics(s.config.metrics))
 	npdsMutator := xds.NewAckingResourceMutatorWrapper(s.logger, npdsCache, s.config.metrics)
 	npdsConfig := &xds.ResourceTypeConfiguration{
 		Source:      npdsCache,
@@ -307,6 +522,7 @@ func (s *xdsServer) initializeXdsConfigs() {
 	}
 
 	s.listenerMutator = ldsMutator
+	s.listenerCache = ldsCache
 	s.routeMutator = rdsMutator
 	s.clusterMutator = cdsMutator
 	s.endpointMutator = edsMutator
@@ -314,6 +530,19 @@ func (s *xdsServer) initializeXdsConfigs() {
 	s.networkPolicyCache = npdsCache
 	s.NetworkPolicyMutator = npdsMutator
 
+	if s.config.debugDumpDir != "" {
+		// Dump every pushed resource to the debug directory. The acking
+		// wrappers above stay registered as AckObservers; only the mutator
+		// handles used for pushing resources are wrapped.
+		dumper := newDebugDumper(s.logger, s.config.debugDumpDir, debugDumpKeepVersions)
+		s.listenerMutator = dumper.wrap(ldsMutator)
+		s.routeMutator = dumper.wrap(rdsMutator)
+		s.clusterMutator = dumper.wrap(cdsMutator)
+		s.endpointMutator = dumper.wrap(edsMutator)
+		s.secretMutator = dumper.wrap(sdsMutator)
+		s.NetworkPolicyMutator = dumper.wrap(npdsMutator)
+	}
+
 	s.resourceConfig = map[string]*xds.ResourceTypeConfiguration{
 		ListenerTypeURL:           ldsConfig,
 		RouteTypeURL:              rdsConfig,
@@ -323,12 +552,61 @@ func (s *xdsServer) initializeXdsConfigs() {
 		NetworkPolicyTypeURL:      npdsConfig,
 		NetworkPolicyHostsTypeURL: nphdsConfig,
 	}
+
+	// The stream server optionally pauses serving any resources until
+	// endpoints have been restored.
+	s.streamServer = xds.NewServer(s.logger, s.resourceConfig, s.restorerPromise, s.config.metrics)
+}
+
+// listenFdsStart is the number of the first file descriptor passed by a
+// LISTEN_FDS-style supervisor, right after stdin, stdout and stderr.
+const listenFdsStart = 3
+
+// newInheritedSocketListener recovers a listener from a file descriptor
+// inherited from the parent process following the LISTEN_FDS socket
+// activation convention. The supervisor owns the socket, so no file mode or
+// ownership changes are applied.
+func newInheritedSocketListener() (*net.UnixListener, error) {
+	nfds, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || nfds < 1 {
+		return nil, fmt.Errorf("no inherited xDS listen socket: LISTEN_FDS=%q", os.Getenv("LISTEN_FDS"))
+	}
+	file := os.NewFile(listenFdsStart, "xds-listener")
+	defer file.Close()
+
+	listener, err := net.FileListener(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use inherited xDS listen socket: %w", err)
+	}
+	socketListener, ok := listener.(*net.UnixListener)
+	if !ok {
+		listener.Close()
+		return nil, fmt.Errorf("inherited xDS listen socket is not a unix stream socket: %T", listener)
+	}
+	return socketListener, nil
 }
 
 func (s *xdsServer) newSocketListener() (*net.UnixListener, error) {
+	switch s.config.xdsSocketMode {
+	case xdsSocketModeInherited:
+		return newInheritedSocketListener()
+	case xdsSocketModeAbstract:
+		// Abstract sockets have no filesystem presence, so there is no
+		// stale socket to unlink and no file mode or group to adjust;
+		// access is limited by the (abstract socket) namespace shared
+		// with Envoy instead.
+		socketListener, err := net.ListenUnix("unix", &net.UnixAddr{Name: s.socketPath, Net: "unix"})
+		if err != nil {
+			return nil, fmt.Errorf("failed to open abstract xDS listen socket at %s: %w", s.socketPath, err)
+		}
+		return socketListener, nil
+	}
+
 	// Make sure sockets dir exists
 	socketsDir, _ := filepath.Split(s.socketPath)
-	os.MkdirAll(GetSocketDir(socketsDir), 0o777)
+	if err := os.MkdirAll(GetSocketDir(socketsDir), 0o777); err != nil {
+		return nil, fmt.Errorf("failed to create xDS socket directory %s: %w", GetSocketDir(socketsDir), err)
+	}
 
 	// Remove/Unlink the old unix domain socket, if any.
 	_ = os.Remove(s.socketPath)
@@ -349,6 +627,21 @@ func (s *xdsServer) newSocketListener() (*net.UnixListener, error) {
 			logfields.Error, err,
 		)
 	}
+	// Log the resulting permissions and ownership so that deployment issues
+	// can be diagnosed from the logs alone.
+	if fi, err := os.Stat(s.socketPath); err == nil {
+		attrs := []any{
+			logfields.Path, s.socketPath,
+			logfields.Mode, fi.Mode().Perm(),
+		}
+		if stat, ok := fi.Sys().(*syscall.Stat_t); ok {
+			attrs = append(attrs,
+				logfields.User, stat.Uid,
+				logfields.Group, stat.Gid,
+			)
+		}
+		s.logger.Debug("Envoy: xDS listen socket created", attrs...)
+	}
 	return socketListener, nil
 }
 
@@ -356,18 +649,24 @@ func (s *xdsServer) stop() {
 	if s.stopFunc != nil {
 		s.stopFunc()
 	}
-	if s.socketPath != "" {
+	// Only a filesystem socket created by us needs to be cleaned up; abstract
+	// sockets have no filesystem presence and an inherited listener is owned
+	// by the supervisor that passed it in.
+	if s.socketPath != "" && !strings.HasPrefix(s.socketPath, "@") && s.config.xdsSocketMode != xdsSocketModeInherited {
 		_ = os.Remove(s.socketPath)
 	}
 }
 
-func GetCiliumHttpFilter() *envoy_config_http.HttpFilter {
+// GetCiliumHttpFilter returns the Cilium L7 policy enforcement HTTP filter,
+// logging to the given access log socket and responding to denied requests
+// with the given 403 body.
+func GetCiliumHttpFilter(accessLogPath, denied403Body string) *envoy_config_http.HttpFilter {
 	return &envoy_config_http.HttpFilter{
 		Name: "cilium.l7policy",
 		ConfigType: &envoy_config_http.HttpFilter_TypedConfig{
 			TypedConfig: toAny(&cilium.L7Policy{
-				AccessLogPath:  getAccessLogSocketPath(GetSocketDir(option.Config.RunDir)),
-				Denied_403Body: option.Config.HTTP403Message,
+				AccessLogPath:  accessLogPath,
+				Denied_403Body: denied403Body,
 			}),
 		},
 	}
@@ -382,7 +681,13 @@ func GetUpstreamCodecFilter() *envoy_config_http.HttpFilter {
 	}
 }
 
-func (s *xdsServer) getHttpFilterChainProto(clusterName string, tls bool, isIngress bool) *envoy_config_listener.FilterChain {
+// getHttpFilterChainProto creates an HTTP filter chain for a policy
+// enforcement listener. Optional 'statPrefix' namespaces the Envoy stats of
+// the HTTP connection manager; when empty, the shared "proxy" prefix is used.
+func (s *xdsServer) getHttpFilterChainProto(clusterName string, statPrefix string, tls bool, isIngress bool) *envoy_config_listener.FilterChain {
+	if statPrefix == "" {
+		statPrefix = "proxy"
+	}
 	requestTimeout := int64(s.config.httpRequestTimeout)       // seconds
 	idleTimeout := int64(s.config.httpIdleTimeout)             // seconds
 	maxGRPCTimeout := int64(s.config.httpMaxGRPCTimeout)       // seconds
@@ -395,12 +700,12 @@ func (s *xdsServer) getHttpFilterChainProto(clusterName string, tls bool, isIngr
 	}
 
 	hcmConfig := &envoy_config_http.HttpConnectionManager{
-		StatPrefix:        "proxy",
+		StatPrefix:        statPrefix,
 		UseRemoteAddress:  &wrapperspb.BoolValue{Value: true},
 		SkipXffAppend:     true,
 		XffNumTrustedHops: xffNumTrustedHops,
 		HttpFilters: []*envoy_config_http.HttpFilter{
-			GetCiliumHttpFilter(),
+			GetCiliumHttpFilter(s.accessLogPath, option.Config.HTTP403Message),
 			{
 				Name: "envoy.filters.http.router",
 				ConfigType: &envoy_config_http.HttpFilter_TypedConfig{
@@ -463,12 +768,68 @@ func (s *xdsServer) getHttpFilterChainProto(clusterName string, tls bool, isIngr
 		},
 	}
 
+	if s.config.httpMaxRequestHeadersKb > 0 {
+		hcmConfig.MaxRequestHeadersKb = &wrapperspb.UInt32Value{Value: s.config.httpMaxRequestHeadersKb}
+	}
+
+	if s.config.httpMaxResponseHeadersKb > 0 {
+		hcmConfig.CommonHttpProtocolOptions = &envoy_config_core.HttpProtocolOptions{
+			MaxResponseHeadersKb: &wrapperspb.UInt32Value{Value: s.config.httpMaxResponseHeadersKb},
+		}
+	}
+
+	if s.config.httpForwardTrailers {
+		hcmConfig.HttpProtocolOptions = &envoy_config_core.Http1ProtocolOptions{
+			EnableTrailers: true,
+		}
+	}
+
 	if s.config.httpNormalizePath {
 		hcmConfig.NormalizePath = &wrapperspb.BoolValue{Value: true}
 		hcmConfig.MergeSlashes = true
 		hcmConfig.PathWithEscapedSlashesAction = envoy_config_http.HttpConnectionManager_UNESCAPE_AND_REDIRECT
 	}
 
+	// Mirror the configured share of requests to the shadow cluster. The
+	// shadow cluster must be present in the CDS cache, see
+	// getMirrorClusterResource.
+	if s.config.mirrorClusterName != "" {
+		mirrorPolicies := []*envoy_config_route.RouteAction_RequestMirrorPolicy{{
+			Cluster: s.config.mirrorClusterName,
+			RuntimeFraction: &envoy_config_core.RuntimeFractionalPercent{
+				DefaultValue: &envoy_type.FractionalPercent{
+					Numerator:   min(s.config.mirrorPercent, 100),
+					Denominator: envoy_type.FractionalPercent_HUNDRED,
+				},
+			},
+		}}
+		for _, route := range hcmConfig.GetRouteConfig().VirtualHosts[0].Routes {
+			route.GetRoute().RequestMirrorPolicies = mirrorPolicies
+		}
+	}
+
+	// Buffer the request body so that a retry can replay it; without
+	// buffering, retries fail as soon as part of the body has been
+	// forwarded upstream. While at it, prefer a different upstream host
+	// and priority on retry over replaying the request to the host that
+	// just failed it.
+	if s.config.httpRetryBufferLimitBytes > 0 {
+		for _, route := range hcmConfig.GetRouteConfig().VirtualHosts[0].Routes {
+			route.PerRequestBufferLimitBytes = &wrapperspb.UInt32Value{Value: s.config.httpRetryBufferLimitBytes}
+
+			retryPolicy := route.GetRoute().RetryPolicy
+			retryPolicy.RetryPriority = &envoy_config_route.RetryPolicy_RetryPriority{
+				Name: "envoy.retry_priorities.previous_priorities",
+				ConfigType: &envoy_config_route.RetryPolicy_RetryPriority_TypedConfig{
+					TypedConfig: toAny(&envoy_retry_previous_priorities.PreviousPrioritiesConfig{
+						UpdateFrequency: 2,
+					}),
+				},
+			}
+			retryPolicy.HostSelectionRetryMaxAttempts = 3
+		}
+	}
+
 	// Idle timeout can only be specified if non-zero
 	if idleTimeout > 0 {
 		hcmConfig.GetRouteConfig().VirtualHosts[0].Routes[1].GetRoute().IdleTimeout = &durationpb.Duration{Seconds: idleTimeout}
@@ -503,13 +864,156 @@ func (s *xdsServer) getHttpFilterChainProto(clusterName string, tls bool, isIngr
 	return chain
 }
 
+// sniClusterName converts a server name to the name of the egress cluster
+// serving it, following the pattern "egress-cluster-<sanitized-sni>".
+// Wildcard prefixes are spelled out and characters with no place in a cluster
+// name are replaced, so that e.g. "*.Example.com" becomes
+// "egress-cluster-wildcard.example.com".
+func sniClusterName(sni string) string {
+	sanitized := strings.ToLower(sni)
+	sanitized = strings.ReplaceAll(sanitized, "*", "wildcard")
+	sanitized = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		default:
+			return '-'
+		}
+	}, sanitized)
+	return egressClusterName + "-" + sanitized
+}
+
+// getSNIClusterResources creates the per-SNI egress clusters referenced by a
+// SNI-aware TCP filter chain. The clusters are modeled after the static egress
+// TLS cluster and must be upserted together with the listener referring to
+// them (e.g., via UpsertEnvoyResources).
+func getSNIClusterResources(serverNames []string) []*envoy_config_cluster.Cluster {
+	clusters := make([]*envoy_config_cluster.Cluster, 0, len(serverNames))
+	for _, sni := range serverNames {
+		clusters = append(clusters, &envoy_config_cluster.Cluster{
+			Name:                 sniClusterName(sni),
+			ClusterDiscoveryType: &envoy_config_cluster.Cluster_Type{Type: envoy_config_cluster.Cluster_ORIGINAL_DST},
+			ConnectTimeout:       &durationpb.Duration{Seconds: 2, Nanos: 0},
+			CleanupInterval:      &durationpb.Duration{Seconds: 2, Nanos: 500000000},
+			LbPolicy:             envoy_config_cluster.Cluster_CLUSTER_PROVIDED,
+			TransportSocket: &envoy_config_core.TransportSocket{
+				Name: "cilium.tls_wrapper",
+				ConfigType: &envoy_config_core.TransportSocket_TypedConfig{
+					TypedConfig: toAny(&cilium.UpstreamTlsWrapperContext{}),
+				},
+			},
+		})
+	}
+	return clusters
+}
+
+// GetOriginalDstEgressCluster returns the shared ORIGINAL_DST egress cluster
+// that forwards each connection to its original destination address. When
+// 'allowHeaderOverride' is true the destination may instead be supplied per
+// request via the 'x-envoy-original-dst-host' header, including an optional
+// port override. Header-based overrides let HTTP clients steer requests to
+// arbitrary backends, so they are off by default and must be explicitly
+// enabled.
+func GetOriginalDstEgressCluster(clusterName string, allowHeaderOverride bool) *envoy_config_cluster.Cluster {
+	cluster := &envoy_config_cluster.Cluster{
+		Name:                 clusterName,
+		ClusterDiscoveryType: &envoy_config_cluster.Cluster_Type{Type: envoy_config_cluster.Cluster_ORIGINAL_DST},
+		ConnectTimeout:       &durationpb.Duration{Seconds: 2, Nanos: 0},
+		CleanupInterval:      &durationpb.Duration{Seconds: 2, Nanos: 500000000},
+		LbPolicy:             envoy_config_cluster.Cluster_CLUSTER_PROVIDED,
+	}
+	if allowHeaderOverride {
+		cluster.LbConfig = &envoy_config_cluster.Cluster_OriginalDstLbConfig_{
+			OriginalDstLbConfig: &envoy_config_cluster.Cluster_OriginalDstLbConfig{
+				UseHttpHeader: true,
+			},
+		}
+	}
+	return cluster
+}
+
+// getMirrorClusterResource returns the shadow cluster the request mirror
+// policies of the generated HTTP filter chains point at. By default the
+// mirrored copy follows the original destination address; a different shadow
+// backend may be selected per request via the 'x-envoy-original-dst-host'
+// header, or by replacing the cluster definition under the same name, e.g.
+// through a CiliumEnvoyConfig. Like the per-SNI egress clusters, the cluster
+// must be registered in the xDS cache (via UpsertEnvoyResources) before a
+// listener refers to it.
+func getMirrorClusterResource(clusterName string) *envoy_config_cluster.Cluster {
+	return GetOriginalDstEgressCluster(clusterName, true)
+}
+
 // getTcpFilterChainProto creates a TCP filter chain with the Cilium network filter.
 // By default, the returned chain can be used with the Cilium Go extensions L7 parsers
 // in 'proxylib' directory in the Cilium repo.
 // When optional 'filterName' is given, it is configured as the first filter in the chain
 // and 'proxylib' is not configured. In this case the returned filter chain is only used
 // if the applicable network policy specifies 'filterName' as the L7 parser.
-func (s *xdsServer) getTcpFilterChainProto(clusterName string, filterName string, config *anypb.Any, tls bool) *envoy_config_listener.FilterChain {
+// Optional 'serverNames', typically translated from the ServerNames of a TLS SNI
+// policy rule, restrict the chain to connections with a matching SNI and spread
+// the upstream connections over the corresponding per-SNI egress clusters.
+// Optional 'statPrefix' namespaces the Envoy stats of the TCP proxy; when
+// empty, the shared "tcp_proxy" prefix is used.
+// getInternalListenerCluster returns a cluster whose single endpoint is the
+// internal listener named 'listenerName', connected through the
+// internal_upstream transport. It is the upstream counterpart of a listener
+// generated with useInternalListeners enabled.
+func getInternalListenerCluster(clusterName, listenerName string) *envoy_config_cluster.Cluster {
+	return &envoy_config_cluster.Cluster{
+		Name:                 clusterName,
+		ClusterDiscoveryType: &envoy_config_cluster.Cluster_Type{Type: envoy_config_cluster.Cluster_STATIC},
+		LoadAssignment: &envoy_config_endpoint.ClusterLoadAssignment{
+			ClusterName: clusterName,
+			Endpoints: []*envoy_config_endpoint.LocalityLbEndpoints{{
+				LbEndpoints: []*envoy_config_endpoint.LbEndpoint{{
+					HostIdentifier: &envoy_config_endpoint.LbEndpoint_Endpoint{
+						Endpoint: &envoy_config_endpoint.Endpoint{
+							Address: &envoy_config_core.Address{
+								Address: &envoy_config_core.Address_EnvoyInternalAddress{
+									EnvoyInternalAddress: &envoy_config_core.EnvoyInternalAddress{
+										AddressNameSpecifier: &envoy_config_core.EnvoyInternalAddress_ServerListenerName{
+											ServerListenerName: listenerName,
+										},
+									},
+								},
+							},
+						},
+					},
+				}},
+			}},
+		},
+		TransportSocket: &envoy_config_core.TransportSocket{
+			Name: "envoy.transport_sockets.internal_upstream",
+			ConfigType: &envoy_config_core.TransportSocket_TypedConfig{
+				TypedConfig: toAny(&envoy_internal_upstream.InternalUpstreamTransport{
+					TransportSocket: &envoy_config_core.TransportSocket{
+						Name: "envoy.transport_sockets.raw_buffer",
+						ConfigType: &envoy_config_core.TransportSocket_TypedConfig{
+							TypedConfig: toAny(&envoy_raw_buffer.RawBuffer{}),
+						},
+					},
+				}),
+			},
+		},
+	}
+}
+
+// Modes for tunneling the proxied TCP payload through an HTTP proxy. The
+// empty mode disables tunneling.
+const (
+	// tcpTunnelModePreConnect establishes the tunnel with an HTTP/1.1
+	// CONNECT request.
+	tcpTunnelModePreConnect = "pre_connect"
+	// tcpTunnelModePostConnect uses a POST request instead, for proxies
+	// that do not accept CONNECT.
+	tcpTunnelModePostConnect = "post_connect"
+)
+
+func (s *xdsServer) getTcpFilterChainProto(clusterName string, statPrefix string, filterName string, config *anypb.Any, tls bool, serverNames ...string) *envoy_config_listener.FilterChain {
+	if statPrefix == "" {
+		statPrefix = "tcp_proxy"
+	}
 	var filters []*envoy_config_listener.Filter
 
 	// 1. Add the filter 'filterName' to the beginning of the TCP chain with optional 'config', if needed.
@@ -548,15 +1052,47 @@ func (s *xdsServer) getTcpFilterChainProto(clusterName string, filterName string
 	})
 
 	// 3. Add the TCP proxy filter.
+	tcpProxy := &envoy_config_tcp.TcpProxy{
+		StatPrefix: statPrefix,
+		ClusterSpecifier: &envoy_config_tcp.TcpProxy_Cluster{
+			Cluster: clusterName,
+		},
+	}
+	if len(serverNames) > 0 {
+		// Route each server name to its own egress cluster. The filter chain
+		// match below limits this chain to the given SNIs, so each weighted
+		// cluster only ever sees connections for its server name.
+		weighted := make([]*envoy_config_tcp.TcpProxy_WeightedCluster_ClusterWeight, 0, len(serverNames))
+		for _, sni := range serverNames {
+			weighted = append(weighted, &envoy_config_tcp.TcpProxy_WeightedCluster_ClusterWeight{
+				Name:   sniClusterName(sni),
+				Weight: 1,
+			})
+		}
+		tcpProxy.ClusterSpecifier = &envoy_config_tcp.TcpProxy_WeightedClusters{
+			WeightedClusters: &envoy_config_tcp.TcpProxy_WeightedCluster{
+				Clusters: weighted,
+			},
+		}
+	}
+	if mode := s.config.tcpProxyTunnelMode; mode != "" {
+		// Tunnel the payload through an HTTP proxy. The upstream connection
+		// goes to the tunnel endpoint, which may live in its own cluster
+		// independent of the payload cluster.
+		tcpProxy.TunnelingConfig = &envoy_config_tcp.TcpProxy_TunnelingConfig{
+			Hostname: s.config.tcpProxyTunnelHost,
+			UsePost:  mode == tcpTunnelModePostConnect,
+		}
+		if tunnelCluster := s.config.tcpProxyTunnelCluster; tunnelCluster != "" {
+			tcpProxy.ClusterSpecifier = &envoy_config_tcp.TcpProxy_Cluster{
+				Cluster: tunnelCluster,
+			}
+		}
+	}
 	filters = append(filters, &envoy_config_listener.Filter{
 		Name: "envoy.filters.network.tcp_proxy",
 		ConfigType: &envoy_config_listener.Filter_TypedConfig{
-			TypedConfig: toAny(&envoy_config_tcp.TcpProxy{
-				StatPrefix: "tcp_proxy",
-				ClusterSpecifier: &envoy_config_tcp.TcpProxy_Cluster{
-					Cluster: clusterName,
-				},
-			}),
+			TypedConfig: toAny(tcpProxy),
 		},
 	})
 
@@ -567,6 +1103,7 @@ func (s *xdsServer) getTcpFilterChainProto(clusterName string, filterName string
 	if tls {
 		chain.FilterChainMatch = &envoy_config_listener.FilterChainMatch{
 			TransportProtocol: "tls",
+			ServerNames:       serverNames,
 		}
 		chain.TransportSocket = &envoy_config_core.TransportSocket{
 			Name: "cilium.tls_wrapper",
@@ -608,7 +1145,11 @@ func getPublicListenerAddress(port uint16, ipv4, ipv6 bool) *envoy_config_core.A
 	}
 }
 
-func GetLocalListenerAddresses(port uint16, ipv4, ipv6 bool) (*envoy_config_core.Address, []*envoy_config_listener.AdditionalAddress) {
+func GetLocalListenerAddresses(port uint16, ipv4, ipv6 bool) (*envoy_config_core.Address, []*envoy_config_listener.AdditionalAddress, error) {
+	if !ipv4 && !ipv6 {
+		return nil, nil, errors.New("local listener address requires at least one of IPv4 or IPv6 to be enabled")
+	}
+
 	addresses := []*envoy_config_core.Address_SocketAddress{}
 
 	if ipv4 {
@@ -643,7 +1184,7 @@ func GetLocalListenerAddresses(port uint16, ipv4, ipv6 bool) (*envoy_config_core
 
 	return &envoy_config_core.Address{
 		Address: addresses[0],
-	}, additionalAddress
+	}, additionalAddress, nil
 }
 
 func (s *xdsServer) AddAdminListener(port uint16, wg *completion.WaitGroup) {
@@ -694,7 +1235,13 @@ func (s *xdsServer) AddAdminListener(port uint16, wg *completion.WaitGroup) {
 			},
 		}
 
-		addr, additionalAddr := GetLocalListenerAddresses(port, option.Config.IPv4Enabled(), option.Config.IPv6Enabled())
+		addr, additionalAddr, err := GetLocalListenerAddresses(port, option.Config.IPv4Enabled(), option.Config.IPv6Enabled())
+		if err != nil {
+			s.logger.Error("Envoy: No local address for admin listener",
+				logfields.Error, err,
+			)
+			return nil
+		}
 		listenerConf := &envoy_config_listener.Listener{
 			Name:                adminListenerName,
 			Address:             addr,
@@ -757,16 +1304,34 @@ func (s *xdsServer) AddMetricsListener(port uint16, wg *completion.WaitGroup) {
 	)
 
 	s.addListener(metricsListenerName, func() *envoy_config_listener.Listener {
+		var httpFilters []*envoy_config_http.HttpFilter
+		if s.config.compressMetrics {
+			// The raw Prometheus text can be hundreds of KB for large
+			// clusters, but compresses very well.
+			httpFilters = append(httpFilters, &envoy_config_http.HttpFilter{
+				Name: "envoy.filters.http.compressor",
+				ConfigType: &envoy_config_http.HttpFilter_TypedConfig{
+					TypedConfig: toAny(&envoy_extensions_filters_http_compressor_v3.Compressor{
+						CompressorLibrary: &envoy_config_core.TypedExtensionConfig{
+							Name:        "envoy.compression.gzip.compressor",
+							TypedConfig: toAny(&envoy_extensions_compression_gzip_compressor_v3.Gzip{}),
+						},
+					}),
+				},
+			})
+		}
+		httpFilters = append(httpFilters, &envoy_config_http.HttpFilter{
+			Name: "envoy.filters.http.router",
+			ConfigType: &envoy_config_http.HttpFilter_TypedConfig{
+				TypedConfig: toAny(&envoy_extensions_filters_http_router_v3.Router{}),
+			},
+		})
+
 		hcmConfig := &envoy_config_http.HttpConnectionManager{
 			StatPrefix:       metricsListenerName,
 			UseRemoteAddress: &wrapperspb.BoolValue{Value: true},
 			SkipXffAppend:    true,
-			HttpFilters: []*envoy_config_http.HttpFilter{{
-				Name: "envoy.filters.http.router",
-				ConfigType: &envoy_config_http.HttpFilter_TypedConfig{
-					TypedConfig: toAny(&envoy_extensions_filters_http_router_v3.Router{}),
-				},
-			}},
+			HttpFilters:      httpFilters,
 			InternalAddressConfig: &envoy_config_http.HttpConnectionManager_InternalAddressConfig{
 				UnixSockets: false,
 				// only RFC1918 IP addresses will be considered internal
@@ -827,13 +1392,34 @@ func (s *xdsServer) AddMetricsListener(port uint16, wg *completion.WaitGroup) {
 	}, false)
 }
 
+// ErrListenerValidation is returned when a listener resource fails proto
+// validation and can therefore never be accepted by Envoy. Callers should
+// treat this error as permanent and not retry the operation.
+type ErrListenerValidation struct {
+	Listener string
+	Err      error
+}
+
+func (e *ErrListenerValidation) Error() string {
+	return fmt.Sprintf("Envoy: Could not validate Listener %s: %s", e.Listener, e.Err)
+}
+
+func (e *ErrListenerValidation) Unwrap() error {
+	return e.Err
+}
+
 // addListener either reuses an existing listener with 'name', or creates a new one.
-// 'listenerConf()' is only called if a new listener is being created.
+// 'listenerConf()' is always called, and the generated configuration is upserted
+// even when the listener is reused, so that changes such as a reallocated proxy
+// port baked into the bpf_metadata filter are reconciled.
 func (s *xdsServer) addListener(name string, listenerConf func() *envoy_config_listener.Listener, wg *completion.WaitGroup, cb func(err error), isProxyListener bool) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	listenerConfig := listenerConf()
+	if listenerConfig == nil {
+		return fmt.Errorf("no listener configuration generated for %q", name)
+	}
 	if option.Config.EnableBPFTProxy {
 		// Envoy since 1.20.0 uses SO_REUSEPORT on listeners by default.
 		// BPF TPROXY is currently not compatible with SO_REUSEPORT, so disable it.
@@ -841,23 +1427,49 @@ func (s *xdsServer) addListener(name string, listenerConf func() *envoy_config_l
 		listenerConfig.EnableReusePort = &wrapperspb.BoolValue{Value: false}
 	}
 	if err := listenerConfig.Validate(); err != nil {
-		return fmt.Errorf("Envoy: Could not validate Listener %s: %w", listenerConfig.String(), err)
+		s.logger.Debug("Envoy: Listener validation failed",
+			logfields.Listener, name,
+			logfields.Resource, listenerConfig,
+		)
+		if s.config.metrics != nil {
+			s.config.metrics.IncreaseValidationError(ListenerTypeURL, name)
+		}
+		return &ErrListenerValidation{Listener: name, Err: err}
 	}
 
 	count := s.listenerCount[name]
 	if count == 0 {
 		if isProxyListener {
 			s.proxyListeners++
+			s.updateProxyListenersMetric()
 		}
 		s.logger.Info("Envoy: Upserting new listener",
 			logfields.Listener, name,
 		)
+	} else if cached, err := s.listenerCache.Lookup(ListenerTypeURL, name); err == nil && cached != nil && !proto.Equal(cached, listenerConfig) {
+		// The generated configuration of a reused listener can change, e.g.,
+		// when the datapath allocates a different proxy port for the same
+		// named listener after a restart. The port is baked into the
+		// bpf_metadata filter as the ProxyId, so keeping the cached
+		// configuration would break policy matching by proxy ID. The upsert
+		// below pushes the new configuration instead of only bumping the
+		// reference count.
+		s.logger.Info("Envoy: Updating reused listener with changed configuration",
+			logfields.Listener, name,
+		)
 	}
 	count++
 	s.listenerCount[name] = count
+	s.listenerConfs[name] = listenerConf
 
 	s.listenerMutator.Upsert(ListenerTypeURL, name, listenerConfig, []string{"127.0.0.1"}, wg,
 		func(err error) {
+			if err != nil {
+				s.logger.Warn("Envoy: Listener update failed",
+					logfields.Listener, name,
+					logfields.Error, err,
+				)
+			}
 			if cb != nil {
 				cb(err)
 			}
@@ -960,6 +1572,18 @@ func getListenerFilter(isIngress bool, useOriginalSourceAddr bool, proxyPort uin
 	}
 }
 
+// useOriginalSourceAddr returns whether the bpf_metadata listener filter
+// should use the original source address, combining the datapath support
+// reported by the caller with the per-direction configuration. This allows
+// e.g. a service behind a load balancer for ingress to still initiate direct
+// egress connections with the original source address.
+func (s *xdsServer) useOriginalSourceAddr(isIngress bool, mayUseOriginalSourceAddr bool) bool {
+	if isIngress {
+		return mayUseOriginalSourceAddr && s.config.mayUseOriginalSourceAddrIngress
+	}
+	return mayUseOriginalSourceAddr && s.config.mayUseOriginalSourceAddrEgress
+}
+
 func (s *xdsServer) getListenerConf(name string, kind policy.L7ParserType, port uint16, isIngress bool, mayUseOriginalSourceAddr bool) *envoy_config_listener.Listener {
 	clusterName := egressClusterName
 	tlsClusterName := egressTLSClusterName
@@ -969,10 +1593,20 @@ func (s *xdsServer) getListenerConf(name string, kind policy.L7ParserType, port
 		tlsClusterName = ingressTLSClusterName
 	}
 
-	addr, additionalAddr := GetLocalListenerAddresses(port, option.Config.IPv4Enabled(), option.Config.IPv6Enabled())
-	lingerConfig := -1
-	if kind == policy.ParserTypeHTTP {
-		lingerConfig = s.config.httpLingerConfig
+	addr, additionalAddr, err := GetLocalListenerAddresses(port, option.Config.IPv4Enabled(), option.Config.IPv6Enabled())
+	if err != nil {
+		s.logger.Error("Envoy: No local address for listener",
+			logfields.Listener, name,
+			logfields.Error, err,
+		)
+		return nil
+	}
+	lingerConfig, ok := s.config.lingerConfigByParser[kind]
+	if !ok {
+		lingerConfig = -1
+		if kind == policy.ParserTypeHTTP {
+			lingerConfig = s.config.httpLingerConfig
+		}
 	}
 	listenerConf := &envoy_config_listener.Listener{
 		Name:                name,
@@ -987,35 +1621,63 @@ func (s *xdsServer) getListenerConf(name string, kind policy.L7ParserType, port
 					TypedConfig: toAny(&envoy_extensions_listener_tls_inspector_v3.TlsInspector{}),
 				},
 			},
-			getListenerFilter(isIngress, mayUseOriginalSourceAddr, port, lingerConfig),
+			getListenerFilter(isIngress, s.useOriginalSourceAddr(isIngress, mayUseOriginalSourceAddr), port, lingerConfig),
 		},
 	}
 
+	if s.config.useInternalListeners {
+		// Hand traffic to Envoy through an internal listener instead of
+		// binding a TCP port on loopback. Traffic reaches the listener via
+		// a cluster generated with getInternalListenerCluster.
+		listenerConf.Address = nil
+		listenerConf.AdditionalAddresses = nil
+		listenerConf.ListenerSpecifier = &envoy_config_listener.Listener_InternalListener{
+			InternalListener: &envoy_config_listener.Listener_InternalListenerConfig{},
+		}
+	}
+
+	// Namespace the Envoy stats by listener name if configured, so that the
+	// stats of concurrent listeners are not merged under the shared prefixes.
+	statPrefix := ""
+	if s.config.useListenerStatPrefix {
+		statPrefix = name
+	}
+
 	// Add filter chains
 	if kind == policy.ParserTypeHTTP {
-		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getHttpFilterChainProto(clusterName, false, isIngress))
+		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getHttpFilterChainProto(clusterName, statPrefix, false, isIngress))
 
 		// Add a TLS variant
-		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getHttpFilterChainProto(tlsClusterName, true, isIngress))
+		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getHttpFilterChainProto(tlsClusterName, statPrefix, true, isIngress))
 	} else {
 		// Default TCP chain, takes care of all parsers in proxylib
 		// The proxylib is deprecated and will be removed in the future
 		// https://github.com/cilium/cilium/issues/38224
 		s.logger.Warn("The support for Envoy Go Extensions (proxylib) has been deprecated due to lack of maintainers. If you are interested in helping to maintain, please reach out on GitHub or the official Cilium slack",
 			logfields.URL, "https://slack.cilium.io")
-		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getTcpFilterChainProto(clusterName, "", nil, false))
+		if s.config.useAlpnChainSelection {
+			// TLS connections that negotiated an HTTP protocol via ALPN are
+			// routed into an HTTP filter chain for L7 visibility, even though
+			// the policy only asked for a TCP parser. The ALPN match is more
+			// specific than the plain "tls" match of the TCP variant below,
+			// so the chains do not overlap.
+			httpChain := s.getHttpFilterChainProto(tlsClusterName, statPrefix, true, isIngress)
+			httpChain.FilterChainMatch.ApplicationProtocols = []string{"h2", "http/1.1"}
+			listenerConf.FilterChains = append(listenerConf.FilterChains, httpChain)
+		}
+		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getTcpFilterChainProto(clusterName, statPrefix, "", nil, false))
 
 		// Add a TLS variant
-		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getTcpFilterChainProto(tlsClusterName, "", nil, true))
+		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getTcpFilterChainProto(tlsClusterName, statPrefix, "", nil, true))
 
 		// Experimental TCP chain for MySQL 5.x
-		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getTcpFilterChainProto(clusterName,
+		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getTcpFilterChainProto(clusterName, statPrefix,
 			"envoy.filters.network.mysql_proxy", toAny(&envoy_mysql_proxy.MySQLProxy{
 				StatPrefix: "mysql",
 			}), false))
 
 		// Experimental TCP chain for MongoDB
-		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getTcpFilterChainProto(clusterName,
+		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getTcpFilterChainProto(clusterName, statPrefix,
 			"envoy.filters.network.mongo_proxy", toAny(&envoy_mongo_proxy.MongoProxy{
 				StatPrefix:          "mongo",
 				EmitDynamicMetadata: true,
@@ -1024,13 +1686,17 @@ func (s *xdsServer) getListenerConf(name string, kind policy.L7ParserType, port
 	return listenerConf
 }
 
-func (s *xdsServer) AddListener(name string, kind policy.L7ParserType, port uint16, isIngress bool, mayUseOriginalSourceAddr bool, wg *completion.WaitGroup, cb func(err error)) error {
+func (s *xdsServer) AddListener(name string, kind policy.L7ParserType, port uint16, isIngress bool, mayUseOriginalSourceAddr bool, failFast bool, wg *completion.WaitGroup, cb func(err error)) error {
 	s.logger.Debug("Envoy: AddListener",
 		logfields.L7ParserType, kind,
 		logfields.Listener, name,
 		logfields.MayUseOriginalSourceAddr, mayUseOriginalSourceAddr,
 	)
 
+	if failFast && !s.streamServer.TypeReady(ListenerTypeURL) {
+		return xds.ErrProxyNotReady
+	}
+
 	return s.addListener(name, func() *envoy_config_listener.Listener {
 		return s.getListenerConf(name, kind, port, isIngress, mayUseOriginalSourceAddr)
 	}, wg, cb, true)
@@ -1040,6 +1706,32 @@ func (s *xdsServer) RemoveListener(name string, wg *completion.WaitGroup) xds.Ac
 	return s.removeListener(name, wg, true)
 }
 
+func (s *xdsServer) ProxyReadiness() map[string]bool {
+	return s.streamServer.Readiness()
+}
+
+func (s *xdsServer) AddBootstrapExtension(pb proto.Message) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.config.envoyBootstrapExtensions = append(s.config.envoyBootstrapExtensions, pb)
+}
+
+// bootstrapExtensions returns the registered bootstrap extensions for
+// serialization into the bootstrap configuration.
+func (s *xdsServer) bootstrapExtensions() []proto.Message {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return slices.Clone(s.config.envoyBootstrapExtensions)
+}
+
+// updateProxyListenersMetric publishes the current count of proxy redirection
+// listeners. Must be called with s.mutex held.
+func (s *xdsServer) updateProxyListenersMetric() {
+	if s.config.metrics != nil {
+		s.config.metrics.SetProxyListeners(s.proxyListeners)
+	}
+}
+
 // removeListener removes an existing Envoy Listener.
 func (s *xdsServer) removeListener(name string, wg *completion.WaitGroup, isProxyListener bool) xds.AckingResourceMutatorRevertFunc {
 	s.logger.Debug("Envoy: RemoveListener",
@@ -1055,8 +1747,10 @@ func (s *xdsServer) removeListener(name string, wg *completion.WaitGroup, isProx
 		if count == 0 {
 			if isProxyListener {
 				s.proxyListeners--
+				s.updateProxyListenersMetric()
 			}
 			delete(s.listenerCount, name)
+			delete(s.listenerConfs, name)
 			s.logger.Info("Envoy: Deleting listener",
 				logfields.Listener, name,
 			)
@@ -1078,6 +1772,7 @@ func (s *xdsServer) removeListener(name string, wg *completion.WaitGroup, isProx
 			listenerRevertFunc(completion)
 			if isProxyListener {
 				s.proxyListeners++
+				s.updateProxyListenersMetric()
 			}
 		}
 		s.listenerCount[name] = s.listenerCount[name] + 1
@@ -1209,6 +1904,29 @@ var CiliumXDSConfigSource = &envoy_config_core.ConfigSource{
 	},
 }
 
+// applyTLSParameters copies the optional TLS version bounds and cipher suites
+// from a "policy" TLS context into a "cilium envoy" TLS context. The
+// parameters travel alongside both the SDS reference and the inline secret
+// values, as SDS secrets only carry the certificates.
+func applyTLSParameters(envoyTLS *cilium.TLSContext, tls *policy.TLSContext) *cilium.TLSContext {
+	envoyTLS.MinTlsVersion = tls.MinimumTLSVersion
+	envoyTLS.MaxTlsVersion = tls.MaximumTLSVersion
+	envoyTLS.CipherSuites = tls.CipherSuites
+	return envoyTLS
+}
+
+// applyTLSConfigDefaults fills in the configured minimum TLS version and
+// cipher suite allow list where the policy rule has not been more specific.
+func (s *xdsServer) applyTLSConfigDefaults(envoyTLS *cilium.TLSContext) *cilium.TLSContext {
+	if envoyTLS.MinTlsVersion == "" {
+		envoyTLS.MinTlsVersion = s.config.tlsMinVersion
+	}
+	if len(envoyTLS.CipherSuites) == 0 {
+		envoyTLS.CipherSuites = s.config.tlsCipherSuites
+	}
+	return envoyTLS
+}
+
 // toEnvoyOriginatingTLSContext converts a "policy" TLS context (i.e., from a CiliumNetworkPolicy or
 // CiliumClusterwideNetworkPolicy) for originating TLS (i.e., verifying TLS connections from *outside*) into a "cilium
 // envoy" TLS context (i.e., for the Cilium proxy plugin for Envoy).
@@ -1222,9 +1940,9 @@ func toEnvoyOriginatingTLSContext(tls *policy.TLSContext, policySecretsNamespace
 		// If values are not present in these fields, then we should be using SDS,
 		// and Secret should be populated.
 		if tls.Secret.String() != "/" {
-			return &cilium.TLSContext{
+			return applyTLSParameters(&cilium.TLSContext{
 				ValidationContextSdsSecret: namespacedNametoSyncedSDSSecretName(tls.Secret, policySecretsNamespace),
-			}
+			}, tls)
 		}
 		// This code _should_ be unreachable, because NetworkPolicy input validation does not allow
 		// the Secret fields to be empty, so panic.
@@ -1234,16 +1952,16 @@ func toEnvoyOriginatingTLSContext(tls *policy.TLSContext, policySecretsNamespace
 	// If we are not using a synchronized secret or are reading from file, useFullTLSContext
 	// matters.
 	if useFullTLSContext {
-		return &cilium.TLSContext{
+		return applyTLSParameters(&cilium.TLSContext{
 			CertificateChain: tls.CertificateChain,
 			PrivateKey:       tls.PrivateKey,
 			TrustedCa:        tls.TrustedCA,
-		}
+		}, tls)
 	}
 
-	return &cilium.TLSContext{
+	return applyTLSParameters(&cilium.TLSContext{
 		TrustedCa: tls.TrustedCA,
-	}
+	}, tls)
 }
 
 // toEnvoyTerminatingTLSContext converts a "policy" TLS context (i.e., from a CiliumNetworkPolicy or
@@ -1259,9 +1977,9 @@ func toEnvoyTerminatingTLSContext(tls *policy.TLSContext, policySecretsNamespace
 		// If the values have been read from Kubernetes, then we should be using SDS,
 		// and Secret should be populated.
 		if tls.Secret.String() != "/" {
-			return &cilium.TLSContext{
+			return applyTLSParameters(&cilium.TLSContext{
 				TlsSdsSecret: namespacedNametoSyncedSDSSecretName(tls.Secret, policySecretsNamespace),
-			}
+			}, tls)
 		}
 		// This code _should_ be unreachable, because NetworkPolicy input validation does not allow
 		// the Secret fields to be empty, so panic.
@@ -1271,17 +1989,17 @@ func toEnvoyTerminatingTLSContext(tls *policy.TLSContext, policySecretsNamespace
 	// If we are not using a synchronized secret or are reading from file, useFullTLSContext
 	// matters.
 	if useFullTLSContext {
-		return &cilium.TLSContext{
+		return applyTLSParameters(&cilium.TLSContext{
 			CertificateChain: tls.CertificateChain,
 			PrivateKey:       tls.PrivateKey,
 			TrustedCa:        tls.TrustedCA,
-		}
+		}, tls)
 	}
 
-	return &cilium.TLSContext{
+	return applyTLSParameters(&cilium.TLSContext{
 		CertificateChain: tls.CertificateChain,
 		PrivateKey:       tls.PrivateKey,
-	}
+	}, tls)
 }
 
 func namespacedNametoSyncedSDSSecretName(namespacedName types.NamespacedName, policySecretsNamespace string) string {
@@ -1338,15 +2056,29 @@ func (s *xdsServer) getPortNetworkPolicyRule(ep endpoint.EndpointUpdater, versio
 	// If secret synchronization is enabled, useFullTLSContext is unused, as SDS handling can handle Secrets with extra
 	// keys correctly.
 	if l7Rules.TerminatingTLS != nil {
-		r.DownstreamTlsContext = toEnvoyTerminatingTLSContext(l7Rules.TerminatingTLS, policySecretsNamespace, useSDS, useFullTLSContext)
+		r.DownstreamTlsContext = s.applyTLSConfigDefaults(toEnvoyTerminatingTLSContext(l7Rules.TerminatingTLS, policySecretsNamespace, useSDS, useFullTLSContext))
 	}
 	if l7Rules.OriginatingTLS != nil {
-		r.UpstreamTlsContext = toEnvoyOriginatingTLSContext(l7Rules.OriginatingTLS, policySecretsNamespace, useSDS, useFullTLSContext)
+		r.UpstreamTlsContext = s.applyTLSConfigDefaults(toEnvoyOriginatingTLSContext(l7Rules.OriginatingTLS, policySecretsNamespace, useSDS, useFullTLSContext))
 	}
 
 	if len(l7Rules.ServerNames) > 0 {
 		r.ServerNames = make([]string, 0, len(l7Rules.ServerNames))
 		for sni := range l7Rules.ServerNames {
+			// Entries prefixed with '~' are RE2 regular expressions and are
+			// passed through with the prefix, marking them for SafeRegex
+			// matching on the proxy side. They were validated when the policy
+			// was sanitized, but a pattern that no longer compiles must not
+			// reach the proxy, where it would fail the whole policy update.
+			if pattern, ok := strings.CutPrefix(sni, "~"); ok {
+				if _, err := regexp.Compile(pattern); err != nil {
+					s.logger.Warn("Skipping invalid server name regex in policy",
+						logfields.ServerNames, sni,
+						logfields.Error, err,
+					)
+					continue
+				}
+			}
 			r.ServerNames = append(r.ServerNames, sni)
 		}
 		slices.Sort(r.ServerNames)
@@ -1404,9 +2136,29 @@ func (s *xdsServer) getPortNetworkPolicyRule(ep endpoint.EndpointUpdater, versio
 
 // getWildcardNetworkPolicyRules returns the rules for port 0, which
 // will be considered after port-specific rules.
-func (s *xdsServer) getWildcardNetworkPolicyRules(version *versioned.VersionHandle, selectors policy.L7DataMap) (rules []*cilium.PortNetworkPolicyRule) {
+func (s *xdsServer) getWildcardNetworkPolicyRules(ep endpoint.EndpointUpdater, version *versioned.VersionHandle, selectors policy.L7DataMap, useFullTLSContext, useSDS bool, policySecretsNamespace string) (rules []*cilium.PortNetworkPolicyRule) {
+	// Selectors carrying an L7 redirect must not be merged into a plain
+	// allow rule, as that would drop the redirect requirement and widen the
+	// policy to an unconditional allow. Emit each of them as a separate rule
+	// instead, translated with its L7 content intact.
+	// Deny rules don't support L7, therefore for the deny case l7.IsRedirect()
+	// will always return false.
+	var redirectRules []*cilium.PortNetworkPolicyRule
+	plainSelectors := make(policy.L7DataMap, len(selectors))
+	for sel, l7 := range selectors {
+		if l7.IsRedirect() {
+			rule, _ := s.getPortNetworkPolicyRule(ep, version, sel, l7, useFullTLSContext, useSDS, policySecretsNamespace)
+			if rule != nil {
+				redirectRules = append(redirectRules, rule)
+			}
+			continue
+		}
+		plainSelectors[sel] = l7
+	}
+	selectors = plainSelectors
+
 	// selections are pre-sorted, so sorting is only needed if merging selections from multiple selectors
-	if len(selectors) == 1 {
+	if len(selectors) == 1 && len(redirectRules) == 0 {
 		for sel, l7 := range selectors {
 			if sel.IsWildcard() {
 				return append(rules, &cilium.PortNetworkPolicyRule{
@@ -1441,13 +2193,6 @@ func (s *xdsServer) getWildcardNetworkPolicyRules(version *versioned.VersionHand
 			}
 		}
 
-		if l7.IsRedirect() {
-			// Issue a warning if this port-0 rule is a redirect.
-			// Deny rules don't support L7 therefore for the deny case
-			// l7.IsRedirect() will always return false.
-			s.logger.Warn("L3-only rule for selector surprisingly requires proxy redirection!", logfields.Selector, sel)
-		}
-
 		selections := sel.GetSelections(version)
 		if len(selections) == 0 {
 			continue
@@ -1462,6 +2207,8 @@ func (s *xdsServer) getWildcardNetworkPolicyRules(version *versioned.VersionHand
 	}
 
 	if wildcardDenyFound {
+		// A wildcard deny short-circuits everything else on the port,
+		// including the redirect rules.
 		return append(rules, &cilium.PortNetworkPolicyRule{
 			Deny: true,
 		})
@@ -1497,12 +2244,40 @@ func (s *xdsServer) getWildcardNetworkPolicyRules(version *versioned.VersionHand
 		})
 	}
 
-	return rules
+	// Sort the redirect rules so that the output does not depend on the
+	// iteration order of the selector map.
+	return append(rules, envoypolicy.SortPortNetworkPolicyRules(redirectRules)...)
+}
+
+// getVisibilityOnlyNetworkPolicy returns an allow-all policy with explicit
+// per-port entries for the given visibility ports, so that traffic on those
+// ports is matched with per-port granularity even though nothing is denied.
+func getVisibilityOnlyNetworkPolicy(visibilityPorts []uint16) []*cilium.PortNetworkPolicy {
+	ports := slices.Clone(visibilityPorts)
+	slices.Sort(ports)
+	ports = slices.Compact(ports)
+
+	PerPortPolicies := make([]*cilium.PortNetworkPolicy, 0, len(ports)+1)
+	PerPortPolicies = append(PerPortPolicies, allowAllTCPPortNetworkPolicy)
+	for _, port := range ports {
+		PerPortPolicies = append(PerPortPolicies, &cilium.PortNetworkPolicy{
+			Port:     uint32(port),
+			Protocol: envoy_config_core.SocketAddress_TCP,
+		})
+	}
+	return envoypolicy.SortPortNetworkPolicies(PerPortPolicies)
 }
 
 func (s *xdsServer) getDirectionNetworkPolicy(ep endpoint.EndpointUpdater, l4Policy policy.L4PolicyMap, policyEnforced bool, useFullTLSContext, useSDS bool, dir string, policySecretsNamespace string) []*cilium.PortNetworkPolicy {
 	// TODO: integrate visibility with enforced policy
 	if !policyEnforced {
+		// Generate per-port allow rules for the ports with visibility
+		// redirects, so that the proxy's filter chain and access logging
+		// engage for them, while keeping the blanket allow for everything
+		// else.
+		if visibilityPorts := ep.GetVisibilityPorts(dir == "ingress"); len(visibilityPorts) > 0 {
+			return getVisibilityOnlyNetworkPolicy(visibilityPorts)
+		}
 		// Always allow all ports
 		return []*cilium.PortNetworkPolicy{allowAllTCPPortNetworkPolicy}
 	}
@@ -1523,7 +2298,7 @@ func (s *xdsServer) getDirectionNetworkPolicy(ep endpoint.EndpointUpdater, l4Pol
 			return
 		}
 
-		wildcardRules := s.getWildcardNetworkPolicyRules(version, l4.PerSelectorPolicies)
+		wildcardRules := s.getWildcardNetworkPolicyRules(ep, version, l4.PerSelectorPolicies, useFullTLSContext, useSDS, policySecretsNamespace)
 
 		for _, rule := range wildcardRules {
 			s.logger.Debug("Wildcard PortNetworkPolicyRule matching remote IDs",
@@ -1540,9 +2315,10 @@ func (s *xdsServer) getDirectionNetworkPolicy(ep endpoint.EndpointUpdater, l4Pol
 					// Got an deny-all rule, which short-circuits all of
 					// the other rules.
 					wildcardDenyAll = true
-				} else {
+				} else if rule.L7 == nil && rule.DownstreamTlsContext == nil && rule.UpstreamTlsContext == nil && len(rule.ServerNames) == 0 && rule.ProxyId == 0 {
 					// Got an allow-all rule, which can short-circuit all of
-					// the other rules.
+					// the other rules. Rules with L7 or redirect content have
+					// side effects and do not count as a plain allow-all.
 					wildcardAllowAll = true
 				}
 			}
@@ -1567,116 +2343,120 @@ func (s *xdsServer) getDirectionNetworkPolicy(ep endpoint.EndpointUpdater, l4Pol
 	addWildcardRules(l4Policy.ExactLookup("0", 0, u8proto.ANY.String()))
 	addWildcardRules(l4Policy.ExactLookup("0", 0, u8proto.TCP.String()))
 
-	if !wildcardDenyAll {
-		l4Policy.ForEach(func(l4 *policy.L4Filter) bool {
-			var protocol envoy_config_core.SocketAddress_Protocol
-			switch l4.U8Proto {
-			case u8proto.TCP, u8proto.ANY:
-				protocol = envoy_config_core.SocketAddress_TCP
-			default:
-				// Other protocol rules not sent to Envoy for now.
-				return true
-			}
+	if wildcardDenyAll {
+		// The wildcard deny-all overrides all the per-port policies, so
+		// there is no need to visit them at all.
+		return envoypolicy.SortPortNetworkPolicies(PerPortPolicies)
+	}
 
-			port := l4.Port
-			if port == 0 && l4.PortName != "" {
-				port = ep.GetNamedPort(l4.Ingress, l4.PortName, l4.U8Proto)
-			}
+	l4Policy.ForEach(func(l4 *policy.L4Filter) bool {
+		var protocol envoy_config_core.SocketAddress_Protocol
+		switch l4.U8Proto {
+		case u8proto.TCP, u8proto.ANY:
+			protocol = envoy_config_core.SocketAddress_TCP
+		default:
+			// Other protocol rules not sent to Envoy for now.
+			return true
+		}
 
-			// Skip if a named port can not be resolved (yet)
-			// wildcard port already taken care of above
-			if port == 0 {
-				return true
-			}
+		port := l4.Port
+		if port == 0 && l4.PortName != "" {
+			port = ep.GetNamedPort(l4.Ingress, l4.PortName, l4.U8Proto)
+		}
 
-			rules := make([]*cilium.PortNetworkPolicyRule, 0, len(l4.PerSelectorPolicies))
-
-			// Assume none of the rules have side-effects so that rule evaluation can
-			// be stopped as soon as the first allowing rule is found. 'canShortCircuit'
-			// is set to 'false' below if any rules with side effects are encountered,
-			// causing all the applicable rules to be evaluated instead.
-			// Also set to 'false' if any deny rules exist.
-			canShortCircuit := true
-			var allowAllRule *cilium.PortNetworkPolicyRule
-			var denyAllRule *cilium.PortNetworkPolicyRule
-
-			for sel, l7 := range l4.PerSelectorPolicies {
-				rule, cs := s.getPortNetworkPolicyRule(ep, version, sel, l7, useFullTLSContext, useSDS, policySecretsNamespace)
-				if rule != nil {
-					if !cs {
-						canShortCircuit = false
-					}
+		// Skip if a named port can not be resolved (yet)
+		// wildcard port already taken care of above
+		if port == 0 {
+			return true
+		}
 
-					s.logger.Debug("PortNetworkPolicyRule matching remote IDs",
-						logfields.EndpointID, ep.GetID(),
-						logfields.Version, version,
-						logfields.TrafficDirection, dir,
-						logfields.Port, port,
-						logfields.ProxyPort, rule.ProxyId,
-						logfields.PolicyID, rule.RemotePolicies,
-						logfields.ServerNames, rule.ServerNames,
-					)
+		rules := make([]*cilium.PortNetworkPolicyRule, 0, len(l4.PerSelectorPolicies))
 
-					if rule.Deny && len(rule.RemotePolicies) == 0 {
-						// Got an deny-all rule, which short-circuits all of
-						// the other rules on this port.
-						denyAllRule = rule
-						rules = []*cilium.PortNetworkPolicyRule{denyAllRule}
-						break
-					}
+		// Assume none of the rules have side-effects so that rule evaluation can
+		// be stopped as soon as the first allowing rule is found. 'canShortCircuit'
+		// is set to 'false' below if any rules with side effects are encountered,
+		// causing all the applicable rules to be evaluated instead.
+		// Also set to 'false' if any deny rules exist.
+		canShortCircuit := true
+		var allowAllRule *cilium.PortNetworkPolicyRule
+		var denyAllRule *cilium.PortNetworkPolicyRule
 
-					if len(rule.RemotePolicies) == 0 && rule.L7 == nil && rule.DownstreamTlsContext == nil && rule.UpstreamTlsContext == nil && len(rule.ServerNames) == 0 && rule.ProxyId == 0 {
-						// Got an allow-all rule, which can short-circuit all of
-						// the other rules on this port.
-						allowAllRule = rule
-					}
-					rules = append(rules, rule)
+		for sel, l7 := range l4.PerSelectorPolicies {
+			rule, cs := s.getPortNetworkPolicyRule(ep, version, sel, l7, useFullTLSContext, useSDS, policySecretsNamespace)
+			if rule != nil {
+				if !cs {
+					canShortCircuit = false
 				}
-			}
 
-			// No rule for this port matches any remote identity.
-			// In this case, just don't generate any PortNetworkPolicy for this
-			// port.
-			if len(rules) == 0 {
-				s.logger.Debug("Skipping PortNetworkPolicy due to no matching remote identities",
+				s.logger.Debug("PortNetworkPolicyRule matching remote IDs",
 					logfields.EndpointID, ep.GetID(),
+					logfields.Version, version,
 					logfields.TrafficDirection, dir,
 					logfields.Port, port,
+					logfields.ProxyPort, rule.ProxyId,
+					logfields.PolicyID, rule.RemotePolicies,
+					logfields.ServerNames, rule.ServerNames,
 				)
-				return true
-			}
 
-			// Short-circuit rules if a rule allows all and all other rules can be short-circuited
-			if denyAllRule == nil && canShortCircuit {
-				if wildcardAllowAll {
-					s.logger.Debug("Short circuiting HTTP rules due to wildcard allowing all and no other rules needing attention",
-						logfields.EndpointID, ep.GetID(),
-						logfields.TrafficDirection, dir,
-						logfields.Port, port,
-					)
-					return true
+				if rule.Deny && len(rule.RemotePolicies) == 0 {
+					// Got an deny-all rule, which short-circuits all of
+					// the other rules on this port.
+					denyAllRule = rule
+					rules = []*cilium.PortNetworkPolicyRule{denyAllRule}
+					break
 				}
-				if allowAllRule != nil {
-					s.logger.Debug("Short circuiting HTTP rules due to rule allowing all and no other rules needing attention",
-						logfields.EndpointID, ep.GetID(),
-						logfields.TrafficDirection, dir,
-						logfields.Port, port,
-					)
-					rules = nil
+
+				if len(rule.RemotePolicies) == 0 && rule.L7 == nil && rule.DownstreamTlsContext == nil && rule.UpstreamTlsContext == nil && len(rule.ServerNames) == 0 && rule.ProxyId == 0 {
+					// Got an allow-all rule, which can short-circuit all of
+					// the other rules on this port.
+					allowAllRule = rule
 				}
+				rules = append(rules, rule)
 			}
+		}
 
-			// NPDS supports port ranges.
-			PerPortPolicies = append(PerPortPolicies, &cilium.PortNetworkPolicy{
-				Port:     uint32(port),
-				EndPort:  uint32(l4.EndPort),
-				Protocol: protocol,
-				Rules:    envoypolicy.SortPortNetworkPolicyRules(rules),
-			})
+		// No rule for this port matches any remote identity.
+		// In this case, just don't generate any PortNetworkPolicy for this
+		// port.
+		if len(rules) == 0 {
+			s.logger.Debug("Skipping PortNetworkPolicy due to no matching remote identities",
+				logfields.EndpointID, ep.GetID(),
+				logfields.TrafficDirection, dir,
+				logfields.Port, port,
+			)
 			return true
+		}
+
+		// Short-circuit rules if a rule allows all and all other rules can be short-circuited
+		if denyAllRule == nil && canShortCircuit {
+			if wildcardAllowAll {
+				s.logger.Debug("Short circuiting HTTP rules due to wildcard allowing all and no other rules needing attention",
+					logfields.EndpointID, ep.GetID(),
+					logfields.TrafficDirection, dir,
+					logfields.Port, port,
+				)
+				return true
+			}
+			if allowAllRule != nil {
+				s.logger.Debug("Short circuiting HTTP rules due to rule allowing all and no other rules needing attention",
+					logfields.EndpointID, ep.GetID(),
+					logfields.TrafficDirection, dir,
+					logfields.Port, port,
+				)
+				rules = nil
+			}
+		}
+
+		// NPDS supports port ranges.
+		PerPortPolicies = append(PerPortPolicies, &cilium.PortNetworkPolicy{
+			Port:     uint32(port),
+			EndPort:  uint32(l4.EndPort),
+			Protocol: protocol,
+			Rules:    envoypolicy.SortPortNetworkPolicyRules(rules),
 		})
+		return true
+	})
 
-	}
 	if len(PerPortPolicies) == 0 || len(PerPortPolicies) == 0 && wildcardAllowAll {
 		return nil
 	}
@@ -1684,7 +2464,24 @@ func (s *xdsServer) getDirectionNetworkPolicy(ep endpoint.EndpointUpdater, l4Pol
 	return envoypolicy.SortPortNetworkPolicies(PerPortPolicies)
 }
 
-// getNetworkPolicy converts a network policy into a cilium.NetworkPolicy.
+// maxNetworkPolicyEndpointLabels bounds the number of endpoint labels
+// included in a generated NetworkPolicy, so that unusually label-heavy
+// endpoints cannot inflate every NPDS update.
+const maxNetworkPolicyEndpointLabels = 64
+
+// getEndpointLabels returns the endpoint's identity labels for inclusion in
+// the generated NetworkPolicy. The labels are sorted by the endpoint, so the
+// field contents only change when the labels themselves change and do not
+// cause spurious NPDS updates.
+func getEndpointLabels(ep endpoint.EndpointUpdater) []string {
+	lbls := ep.GetIdentityLabels().GetModel()
+	if len(lbls) > maxNetworkPolicyEndpointLabels {
+		lbls = lbls[:maxNetworkPolicyEndpointLabels]
+	}
+	return lbls
+}
+
+// getNetworkPolicy converts a network policy into a cilium.NetworkPolicy.
 func (s *xdsServer) getNetworkPolicy(ep endpoint.EndpointUpdater, names []string, l4Policy *policy.L4Policy,
 	ingressPolicyEnforced, egressPolicyEnforced, useFullTLSContext, useSDS bool, policySecretsNamespace string,
 ) *cilium.NetworkPolicy {
@@ -1693,6 +2490,9 @@ func (s *xdsServer) getNetworkPolicy(ep endpoint.EndpointUpdater, names []string
 		EndpointId:       ep.GetID(),
 		ConntrackMapName: "global",
 	}
+	if s.config.includeEndpointLabels {
+		p.EndpointLabels = getEndpointLabels(ep)
+	}
 
 	var ingressMap policy.L4PolicyMap
 	var egressMap policy.L4PolicyMap
@@ -1703,9 +2503,81 @@ func (s *xdsServer) getNetworkPolicy(ep endpoint.EndpointUpdater, names []string
 	p.IngressPerPortPolicies = s.getDirectionNetworkPolicy(ep, ingressMap, ingressPolicyEnforced, useFullTLSContext, useSDS, "ingress", policySecretsNamespace)
 	p.EgressPerPortPolicies = s.getDirectionNetworkPolicy(ep, egressMap, egressPolicyEnforced, useFullTLSContext, useSDS, "egress", policySecretsNamespace)
 
+	// Skip the size computation when nothing consumes it, as proto.Size
+	// populates the message's internal size cache.
+	if s.config.metrics != nil || s.config.networkPolicyMaxSize > 0 {
+		size := proto.Size(p)
+		if s.config.metrics != nil {
+			s.config.metrics.ObserveResourceSize(NetworkPolicyTypeURL, size)
+		}
+		if s.config.networkPolicyMaxSize > 0 && size > s.config.networkPolicyMaxSize {
+			s.degradeNetworkPolicy(p)
+			s.logger.Warn("Envoy: Generated NetworkPolicy exceeds the maximum resource size, "+
+				"collapsing the remote identities of the allow rules to wildcard",
+				logfields.EndpointID, ep.GetID(),
+				logfields.Size, size,
+				logfields.Limit, s.config.networkPolicyMaxSize,
+			)
+		}
+	}
+
+	// Count after any degradation, so that the counts reflect the policy
+	// actually pushed to Envoy.
+	ingressStats := countPortNetworkPolicies(p.IngressPerPortPolicies)
+	egressStats := countPortNetworkPolicies(p.EgressPerPortPolicies)
+	if s.config.metrics != nil {
+		endpointID := strconv.FormatUint(p.EndpointId, 10)
+		s.config.metrics.SetNetworkPolicyCounts(endpointID, "ingress", ingressStats.PortPolicies, ingressStats.Rules, ingressStats.RemoteIdentities, ingressStats.L7Rules)
+		s.config.metrics.SetNetworkPolicyCounts(endpointID, "egress", egressStats.PortPolicies, egressStats.Rules, egressStats.RemoteIdentities, egressStats.L7Rules)
+	}
+	ep.OnNetworkPolicyStatsUpdate(ingressStats, egressStats)
+
 	return p
 }
 
+// countPortNetworkPolicies summarizes one direction of a generated
+// NetworkPolicy by counting its per-port policies, rules, referenced remote
+// identities and L7 rules. These counts drive Envoy's per-policy memory usage
+// and are exposed for capacity planning.
+func countPortNetworkPolicies(policies []*cilium.PortNetworkPolicy) endpoint.NetworkPolicyStats {
+	stats := endpoint.NetworkPolicyStats{
+		PortPolicies: int64(len(policies)),
+	}
+	for _, pnp := range policies {
+		stats.Rules += int64(len(pnp.Rules))
+		for _, rule := range pnp.Rules {
+			stats.RemoteIdentities += int64(len(rule.RemotePolicies))
+			switch l7 := rule.L7.(type) {
+			case *cilium.PortNetworkPolicyRule_HttpRules:
+				stats.L7Rules += int64(len(l7.HttpRules.GetHttpRules()))
+			case *cilium.PortNetworkPolicyRule_KafkaRules:
+				stats.L7Rules += int64(len(l7.KafkaRules.GetKafkaRules()))
+			case *cilium.PortNetworkPolicyRule_L7Rules:
+				stats.L7Rules += int64(len(l7.L7Rules.GetL7AllowRules()) + len(l7.L7Rules.GetL7DenyRules()))
+			}
+		}
+	}
+	return stats
+}
+
+// degradeNetworkPolicy shrinks an oversized NetworkPolicy in a deterministic
+// way by collapsing the remote-identity lists of the allow rules to wildcard,
+// as these lists dominate the serialized size of policies computed from very
+// broad selectors. This degrades enforcement of the affected ports from L3+L4
+// to L4-only rather than failing the whole NPDS push. Deny rules are left
+// intact, as collapsing their remote-identity lists would widen their scope.
+func (s *xdsServer) degradeNetworkPolicy(p *cilium.NetworkPolicy) {
+	for _, policies := range [][]*cilium.PortNetworkPolicy{p.IngressPerPortPolicies, p.EgressPerPortPolicies} {
+		for _, pnp := range policies {
+			for _, rule := range pnp.Rules {
+				if !rule.Deny {
+					rule.RemotePolicies = nil
+				}
+			}
+		}
+	}
+}
+
 // return the Envoy proxy node IDs that need to ACK the policy.
 func getNodeIDs(ep endpoint.EndpointUpdater, policy *policy.L4Policy) []string {
 	nodeIDs := make([]string, 0, 1)
@@ -1741,7 +2613,11 @@ func (s *xdsServer) UseCurrentNetworkPolicy(ep endpoint.EndpointUpdater, policy
 
 func (s *xdsServer) UpdateNetworkPolicy(ep endpoint.EndpointUpdater, policy *policy.L4Policy,
 	ingressPolicyEnforced, egressPolicyEnforced bool, wg *completion.WaitGroup,
-) (error, func() error) {
+) (err error, revert func() error) {
+	_, span := s.tracer.Start(context.Background(), "UpdateNetworkPolicy",
+		trace.WithAttributes(attribute.Int64("endpoint_id", int64(ep.GetID()))))
+	defer func() { endSpan(span, err) }()
+
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
@@ -1760,10 +2636,11 @@ func (s *xdsServer) UpdateNetworkPolicy(ep endpoint.EndpointUpdater, policy *pol
 		return nil, func() error { return nil }
 	}
 
+	syncStart := time.Now()
 	networkPolicy := s.getNetworkPolicy(ep, ips, policy, ingressPolicyEnforced, egressPolicyEnforced, s.config.useFullTLSContext, s.config.useSDS, s.secretManager.GetSecretSyncNamespace())
 
 	// First, validate the policy
-	err := networkPolicy.Validate()
+	err = networkPolicy.Validate()
 	if err != nil {
 		return fmt.Errorf("error validating generated NetworkPolicy for %d/%s: %w", ep.GetID(), ep.GetPolicyNames(), err), nil
 	}
@@ -1774,6 +2651,7 @@ func (s *xdsServer) UpdateNetworkPolicy(ep endpoint.EndpointUpdater, policy *pol
 	if s.proxyListeners == 0 {
 		wg = nil
 	}
+	span.SetAttributes(attribute.Bool("waited_for_ack", wg != nil))
 
 	// When successful, push policy into the cache.
 	var callback func(error)
@@ -1785,10 +2663,66 @@ func (s *xdsServer) UpdateNetworkPolicy(ep endpoint.EndpointUpdater, policy *pol
 			}
 		}
 	}
+	// Record the time until Envoy acknowledges the update, but only when an
+	// acknowledgement is actually waited for.
+	if wg != nil && s.config.metrics != nil {
+		ackStart := time.Now()
+		ackCallback := callback
+		callback = func(err error) {
+			s.config.metrics.ObservePolicyAckDuration(xds.ResultFromError(err), time.Since(ackStart).Seconds())
+			if ackCallback != nil {
+				ackCallback(err)
+			}
+		}
+	}
+
 	epID := ep.GetID()
 	nodeIDs := getNodeIDs(ep, policy)
 	resourceName := strconv.FormatUint(epID, 10)
-	revertFunc := s.NetworkPolicyMutator.Upsert(NetworkPolicyTypeURL, resourceName, networkPolicy, nodeIDs, wg, callback)
+
+	s.updateSecretReferencesLocked(epID, networkPolicySDSSecrets(networkPolicy))
+
+	var revertFunc xds.AckingResourceMutatorRevertFunc
+	if s.config.policyCoalesceWindow > 0 && wg == nil {
+		// Nobody is waiting for an ACK, so the update may be deferred and
+		// collapsed with further updates for the same endpoint arriving
+		// within the coalescing window.
+		s.deferPolicyUpdateLocked(resourceName, networkPolicy, nodeIDs, callback)
+		revertFunc = func(*completion.Completion) {
+			// Nothing has been pushed yet; if the update is still pending,
+			// cancelling it is all that is needed. If the window has already
+			// closed the push is not reverted, like any other best-effort
+			// revert.
+			if pending, exists := s.coalescedPolicyUpdates[resourceName]; exists {
+				pending.timer.Stop()
+				delete(s.coalescedPolicyUpdates, resourceName)
+			}
+		}
+	} else {
+		// Any deferred update for this endpoint is superseded by this push;
+		// carry its callbacks over to this one.
+		if pending, exists := s.coalescedPolicyUpdates[resourceName]; exists {
+			pending.timer.Stop()
+			delete(s.coalescedPolicyUpdates, resourceName)
+			pendingCallbacks := pending.callbacks
+			finalCallback := callback
+			callback = func(err error) {
+				for _, cb := range pendingCallbacks {
+					cb(err)
+				}
+				if finalCallback != nil {
+					finalCallback(err)
+				}
+			}
+			if s.config.metrics != nil {
+				s.config.metrics.IncreaseCoalescedUpdate(NetworkPolicyTypeURL)
+			}
+		}
+		revertFunc = s.NetworkPolicyMutator.Upsert(NetworkPolicyTypeURL, resourceName, networkPolicy, nodeIDs, wg, callback)
+	}
+	if s.config.metrics != nil {
+		s.config.metrics.ObservePolicyUpdateSyncDuration(time.Since(syncStart).Seconds())
+	}
 	revertUpdatedNetworkPolicyEndpoints := make(map[string]endpoint.EndpointUpdater, len(ips))
 	for _, ip := range ips {
 		revertUpdatedNetworkPolicyEndpoints[ip] = s.localEndpointStore.getLocalEndpoint(ip)
@@ -1819,13 +2753,158 @@ func (s *xdsServer) UpdateNetworkPolicy(ep endpoint.EndpointUpdater, policy *pol
 	}
 }
 
+// deferPolicyUpdateLocked stores the given network policy as the pending
+// update for the resource. If an update for the resource is already pending
+// it is replaced and its callbacks are carried over to the final push,
+// otherwise the coalescing window timer is started.
+// Must be called with s.mutex held.
+func (s *xdsServer) deferPolicyUpdateLocked(resourceName string, networkPolicy *cilium.NetworkPolicy, nodeIDs []string, callback func(error)) {
+	if pending, exists := s.coalescedPolicyUpdates[resourceName]; exists {
+		pending.networkPolicy = networkPolicy
+		pending.nodeIDs = nodeIDs
+		if callback != nil {
+			pending.callbacks = append(pending.callbacks, callback)
+		}
+		if s.config.metrics != nil {
+			s.config.metrics.IncreaseCoalescedUpdate(NetworkPolicyTypeURL)
+		}
+		return
+	}
+
+	pending := &coalescedPolicyUpdate{
+		networkPolicy: networkPolicy,
+		nodeIDs:       nodeIDs,
+	}
+	if callback != nil {
+		pending.callbacks = []func(error){callback}
+	}
+	pending.timer = time.AfterFunc(s.config.policyCoalesceWindow, func() {
+		s.flushCoalescedPolicyUpdate(resourceName)
+	})
+	s.coalescedPolicyUpdates[resourceName] = pending
+}
+
+// flushCoalescedPolicyUpdate pushes the pending network policy update for the
+// resource, if any, once its coalescing window has closed.
+func (s *xdsServer) flushCoalescedPolicyUpdate(resourceName string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	pending, exists := s.coalescedPolicyUpdates[resourceName]
+	if !exists {
+		return
+	}
+	delete(s.coalescedPolicyUpdates, resourceName)
+
+	callbacks := pending.callbacks
+	s.NetworkPolicyMutator.Upsert(NetworkPolicyTypeURL, resourceName, pending.networkPolicy, pending.nodeIDs, nil, func(err error) {
+		for _, callback := range callbacks {
+			callback(err)
+		}
+	})
+}
+
+// networkPolicySDSSecrets returns the names of the SDS secrets referenced by
+// the TLS contexts of the given network policy, sorted and deduplicated.
+func networkPolicySDSSecrets(p *cilium.NetworkPolicy) []string {
+	var names []string
+	for _, policies := range [][]*cilium.PortNetworkPolicy{p.IngressPerPortPolicies, p.EgressPerPortPolicies} {
+		for _, pnp := range policies {
+			for _, rule := range pnp.Rules {
+				if tls := rule.DownstreamTlsContext; tls != nil && tls.TlsSdsSecret != "" {
+					names = append(names, tls.TlsSdsSecret)
+				}
+				if tls := rule.UpstreamTlsContext; tls != nil {
+					if tls.TlsSdsSecret != "" {
+						names = append(names, tls.TlsSdsSecret)
+					}
+					if tls.ValidationContextSdsSecret != "" {
+						names = append(names, tls.ValidationContextSdsSecret)
+					}
+				}
+			}
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// updateSecretReferencesLocked records which SDS secrets the network policy
+// of the given endpoint references, and registers a rotation callback with
+// the secret manager for every secret seen for the first time, so that the
+// affected policies can be re-pushed when a secret is rotated.
+// Must be called with s.mutex held.
+func (s *xdsServer) updateSecretReferencesLocked(epID uint64, secrets []string) {
+	old := s.policyToSecrets[epID]
+
+	for _, name := range old {
+		if slices.Contains(secrets, name) {
+			continue
+		}
+		refs := slices.DeleteFunc(s.secretToPolicies[name], func(id uint64) bool { return id == epID })
+		if len(refs) == 0 {
+			delete(s.secretToPolicies, name)
+		} else {
+			s.secretToPolicies[name] = refs
+		}
+	}
+
+	for _, name := range secrets {
+		if !slices.Contains(s.secretToPolicies[name], epID) {
+			s.secretToPolicies[name] = append(s.secretToPolicies[name], epID)
+		}
+		if _, watched := s.rotationWatchedSecrets[name]; !watched {
+			s.rotationWatchedSecrets[name] = struct{}{}
+			s.secretManager.OnSecretRotated(name, func() { s.onSecretRotated(name) })
+		}
+	}
+
+	if len(secrets) == 0 {
+		delete(s.policyToSecrets, epID)
+	} else {
+		s.policyToSecrets[epID] = secrets
+	}
+}
+
+// onSecretRotated re-pushes the network policies referencing the given SDS
+// secret by marking them as modified in the NPDS cache, so that Envoy picks
+// up the rotated secret without any listener being restarted.
+func (s *xdsServer) onSecretRotated(secretName string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	epIDs := s.secretToPolicies[secretName]
+	if len(epIDs) == 0 {
+		return
+	}
+
+	resourceNames := make([]string, 0, len(epIDs))
+	for _, epID := range epIDs {
+		resourceNames = append(resourceNames, strconv.FormatUint(epID, 10))
+	}
+
+	s.logger.Debug("Re-pushing network policies referencing rotated secret",
+		logfields.Secret, secretName,
+		logfields.Resources, len(resourceNames),
+	)
+	s.networkPolicyCache.Touch(NetworkPolicyTypeURL, resourceNames)
+}
+
 func (s *xdsServer) RemoveNetworkPolicy(ep endpoint.EndpointInfoSource) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	epID := ep.GetID()
 	resourceName := strconv.FormatUint(epID, 10)
+	if pending, exists := s.coalescedPolicyUpdates[resourceName]; exists {
+		pending.timer.Stop()
+		delete(s.coalescedPolicyUpdates, resourceName)
+	}
 	s.networkPolicyCache.Delete(NetworkPolicyTypeURL, resourceName)
+	s.updateSecretReferencesLocked(epID, nil)
+	if s.config.metrics != nil {
+		s.config.metrics.DeleteNetworkPolicyCounts(resourceName)
+	}
 
 	ip := ep.GetIPv6Address()
 	if ip != "" {
@@ -1844,9 +2923,14 @@ func (s *xdsServer) RemoveAllNetworkPolicies() {
 }
 
 func (s *xdsServer) GetNetworkPolicies(resourceNames []string) (map[string]*cilium.NetworkPolicy, error) {
+	networkPolicies, _, err := s.GetNetworkPoliciesWithVersion(resourceNames)
+	return networkPolicies, err
+}
+
+func (s *xdsServer) GetNetworkPoliciesWithVersion(resourceNames []string) (map[string]*cilium.NetworkPolicy, uint64, error) {
 	resources, err := s.networkPolicyCache.GetResources(NetworkPolicyTypeURL, 0, "", resourceNames)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	networkPolicies := make(map[string]*cilium.NetworkPolicy, len(resources.Resources))
 	for _, res := range resources.Resources {
@@ -1855,7 +2939,7 @@ func (s *xdsServer) GetNetworkPolicies(resourceNames []string) (map[string]*cili
 			networkPolicies[ip] = networkPolicy
 		}
 	}
-	return networkPolicies, nil
+	return networkPolicies, resources.Version, nil
 }
 
 // Resources contains all Envoy resources parsed from a CiliumEnvoyConfig CRD
@@ -1868,6 +2952,20 @@ type Resources struct {
 
 	// Callback functions that are called if the corresponding Listener change was successfully acked by Envoy
 	PortAllocationCallbacks map[string]func(context.Context) error `json:"-" yaml:"-"`
+
+	// L7LB is true when the resources originate from L7 load balancing
+	// service translation. Upsert-time defaults that only make sense for
+	// load-balanced upstream clusters, such as outlier detection, are
+	// applied to these resources only.
+	L7LB bool `json:"-" yaml:"-"`
+
+	// UseRemoteAddress, if set, overrides use_remote_address on the HTTP
+	// connection managers of the Listeners in this set. Meshes where Envoy
+	// is not at the edge need 'false' to take the original client address
+	// from x-forwarded-for instead of the connection source. When nil the
+	// listener configuration is kept as is; Cilium-generated connection
+	// managers default to 'true'.
+	UseRemoteAddress *bool `json:"-" yaml:"-"`
 }
 
 // ListenersAddedOrDeleted returns 'true' if a listener is added or removed when updating from 'old'
@@ -1902,7 +3000,183 @@ func (old *Resources) ListenersAddedOrDeleted(new *Resources) bool {
 	return false
 }
 
-func (s *xdsServer) UpsertEnvoyResources(ctx context.Context, resources Resources) error {
+// missingByName returns the resources in 'old' without a counterpart with
+// the same name in 'new'.
+func missingByName[T any](old, new []T, name func(T) string) []T {
+	names := make(map[string]struct{}, len(new))
+	for _, r := range new {
+		names[name(r)] = struct{}{}
+	}
+	var missing []T
+	for _, r := range old {
+		if _, ok := names[name(r)]; !ok {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// listenerPort returns the port the listener binds, or 0 if it has no socket
+// address.
+func listenerPort(listener *envoy_config_listener.Listener) uint32 {
+	if addr := listener.Address.GetSocketAddress(); addr != nil {
+		return addr.GetPortValue()
+	}
+	return 0
+}
+
+// EnvoyResourcesDiff computes the changeset applied when updating from 'old'
+// to 'new'. 'toDelete' holds the resources of 'old' without a counterpart
+// with the same name in 'new', plus the listeners whose port changed, which
+// have to be drained before a listener with the same name can bind the new
+// port. 'toAdd' holds all resources of 'new', as upserting an unchanged
+// resource is a no-op in the xDS cache. Neither set is modified.
+func EnvoyResourcesDiff(old, new Resources) (toAdd, toDelete Resources) {
+	toAdd = new
+
+	newListeners := make(map[string]*envoy_config_listener.Listener, len(new.Listeners))
+	for _, listener := range new.Listeners {
+		newListeners[listener.Name] = listener
+	}
+	for _, oldListener := range old.Listeners {
+		newListener, ok := newListeners[oldListener.Name]
+		if !ok {
+			toDelete.Listeners = append(toDelete.Listeners, oldListener)
+			continue
+		}
+		if addr := newListener.Address.GetSocketAddress(); addr != nil && addr.GetPortValue() != listenerPort(oldListener) {
+			toDelete.Listeners = append(toDelete.Listeners, oldListener)
+		}
+	}
+
+	toDelete.Routes = missingByName(old.Routes, new.Routes,
+		func(r *envoy_config_route.RouteConfiguration) string { return r.Name })
+	toDelete.Clusters = missingByName(old.Clusters, new.Clusters,
+		func(c *envoy_config_cluster.Cluster) string { return c.Name })
+	toDelete.Endpoints = missingByName(old.Endpoints, new.Endpoints,
+		func(e *envoy_config_endpoint.ClusterLoadAssignment) string { return e.ClusterName })
+	toDelete.Secrets = missingByName(old.Secrets, new.Secrets,
+		func(s *envoy_config_tls.Secret) string { return s.Name })
+
+	return toAdd, toDelete
+}
+
+// MergeListeners merges the listeners in 'new' into the receiver's listeners.
+// Filter chains of a new listener with the same name as an existing one are
+// appended to the existing listener instead of replacing it, so that multiple
+// CiliumEnvoyConfigs can contribute filter chains to a shared listener. Since
+// Envoy selects the filter chain with the most specific matching
+// FilterChainMatch regardless of the order in which the chains appear, a new
+// filter chain whose match is equal to that of an existing chain can not be
+// distinguished from it and is skipped, keeping the first contributed chain.
+// Listeners in 'new' without an existing counterpart are added as such.
+func (old *Resources) MergeListeners(new *Resources) {
+	// Typically the number of listeners in a CEC is small (e.g, one), so it should be OK to
+	// scan the slices like here
+	for _, nl := range new.Listeners {
+		found := false
+		for _, ol := range old.Listeners {
+			if ol.Name != nl.Name {
+				continue
+			}
+			found = true
+			for _, nfc := range nl.FilterChains {
+				duplicate := false
+				for _, ofc := range ol.FilterChains {
+					if proto.Equal(ofc.GetFilterChainMatch(), nfc.GetFilterChainMatch()) {
+						duplicate = true
+						break
+					}
+				}
+				if !duplicate {
+					ol.FilterChains = append(ol.FilterChains, nfc)
+				}
+			}
+			break
+		}
+		if !found {
+			old.Listeners = append(old.Listeners, nl)
+		}
+	}
+}
+
+// fillInOutlierDetection applies the configured outlier detection defaults to a
+// cluster created for L7 load balancing, so that backends persistently
+// returning 5xx responses are temporarily ejected from the load balancing set.
+// Clusters with explicit outlier detection configuration are left untouched.
+func (s *xdsServer) fillInOutlierDetection(cluster *envoy_config_cluster.Cluster) {
+	defaults := s.config.l7lbOutlierDetection
+	if !defaults.enabled || cluster.OutlierDetection != nil {
+		return
+	}
+
+	cluster.OutlierDetection = &envoy_config_cluster.OutlierDetection{
+		Consecutive_5Xx:    &wrapperspb.UInt32Value{Value: defaults.consecutive5xx},
+		Interval:           durationpb.New(defaults.interval),
+		BaseEjectionTime:   durationpb.New(defaults.baseEjectionTime),
+		MaxEjectionPercent: &wrapperspb.UInt32Value{Value: defaults.maxEjectionPercent},
+	}
+}
+
+// fillInUseRemoteAddress applies the resource set's UseRemoteAddress override
+// to every HTTP connection manager of the given listener. Does nothing when
+// no override is set.
+func fillInUseRemoteAddress(listener *envoy_config_listener.Listener, useRemoteAddress *bool) {
+	if useRemoteAddress == nil {
+		return
+	}
+	for _, fc := range listener.FilterChains {
+		for _, filter := range fc.Filters {
+			tc := filter.GetTypedConfig()
+			if tc == nil || tc.GetTypeUrl() != HttpConnectionManagerTypeURL {
+				continue
+			}
+			hcmConfig := &envoy_config_http.HttpConnectionManager{}
+			if err := tc.UnmarshalTo(hcmConfig); err != nil {
+				continue
+			}
+			hcmConfig.UseRemoteAddress = &wrapperspb.BoolValue{Value: *useRemoteAddress}
+			filter.ConfigType = &envoy_config_listener.Filter_TypedConfig{
+				TypedConfig: toAny(hcmConfig),
+			}
+		}
+	}
+}
+
+// resourceCountAttributes returns span attributes with the number of
+// resources of each type in 'resources', with 'prefix' prepended to the
+// attribute keys.
+func resourceCountAttributes(prefix string, resources Resources) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int(prefix+"listeners", len(resources.Listeners)),
+		attribute.Int(prefix+"routes", len(resources.Routes)),
+		attribute.Int(prefix+"clusters", len(resources.Clusters)),
+		attribute.Int(prefix+"endpoints", len(resources.Endpoints)),
+		attribute.Int(prefix+"secrets", len(resources.Secrets)),
+	}
+}
+
+// endSpan records 'err' on the span, if any, and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (s *xdsServer) UpsertEnvoyResources(ctx context.Context, resources Resources) (err error) {
+	ctx, span := s.tracer.Start(ctx, "UpsertEnvoyResources", trace.WithAttributes(
+		append(resourceCountAttributes("", resources),
+			// Only new listeners are reliably ACKed, see below.
+			attribute.Bool("waited_for_ack", len(resources.Listeners) > 0))...))
+	defer func() { endSpan(span, err) }()
+	if s.config.metrics != nil {
+		start := time.Now()
+		defer func() {
+			s.config.metrics.ObserveResourcesUpdateDuration("upsert", xds.ResultFromError(err), time.Since(start).Seconds())
+		}()
+	}
 	if option.Config.Debug {
 		msg := ""
 		sep := ""
@@ -1930,6 +3204,9 @@ func (s *xdsServer) UpsertEnvoyResources(ctx context.Context, resources Resource
 			logfields.Resource, msg,
 		)
 	}
+	if s.config.useTransactionalUpserts {
+		return s.upsertEnvoyResourcesTransactional(ctx, resources)
+	}
 	var wg *completion.WaitGroup
 	// Listener config may fail if it refers to a cluster that has not been added yet, so we
 	// must wait for Envoy to ACK cluster config before adding Listeners to be sure Listener
@@ -1961,6 +3238,9 @@ func (s *xdsServer) UpsertEnvoyResources(ctx context.Context, resources Resource
 		revertFuncs = append(revertFuncs, s.upsertEndpoint(r.ClusterName, r, nil))
 	}
 	for _, r := range resources.Clusters {
+		if resources.L7LB {
+			s.fillInOutlierDetection(r)
+		}
 		s.logger.Debug("Envoy upsertCluster",
 			logfields.ResourceName, r.Name,
 			logfields.Resource, r,
@@ -1998,14 +3278,28 @@ func (s *xdsServer) UpsertEnvoyResources(ctx context.Context, resources Resource
 		wg = completion.NewWaitGroup(ctx)
 	}
 	for _, r := range resources.Listeners {
+		fillInUseRemoteAddress(r, resources.UseRemoteAddress)
 		s.logger.Debug("Envoy upsertListener",
 			logfields.ResourceName, r.Name,
 			logfields.Resource, r,
 		)
+		if err := r.Validate(); err != nil {
+			if s.config.metrics != nil {
+				s.config.metrics.IncreaseValidationError(ListenerTypeURL, r.Name)
+			}
+			revertFuncs.Revert(nil)
+			return &ErrListenerValidation{Listener: r.Name, Err: err}
+		}
 		listenerName := r.Name
 		revertFuncs = append(revertFuncs, s.upsertListener(r.Name, r, wg,
 			// this callback is not called if there is no change
 			func(err error) {
+				if err != nil {
+					s.logger.Warn("Envoy: Listener update failed",
+						logfields.Listener, listenerName,
+						logfields.Error, err,
+					)
+				}
 				if err == nil && resources.PortAllocationCallbacks[listenerName] != nil {
 					if callbackErr := resources.PortAllocationCallbacks[listenerName](ctx); callbackErr != nil {
 						s.logger.Warn("Failure in port allocation callback",
@@ -2034,7 +3328,146 @@ func (s *xdsServer) UpsertEnvoyResources(ctx context.Context, resources Resource
 	return nil
 }
 
-func (s *xdsServer) UpdateEnvoyResources(ctx context.Context, old, new Resources) error {
+// upsertAll commits all the given resources of a single type URL to the
+// corresponding cache as one transaction.
+func (s *xdsServer) upsertAll(typeURL string, mutator xds.AckingResourceMutator, upserted map[string]proto.Message, wg *completion.WaitGroup, callback func(error)) xds.AckingResourceMutatorRevertFunc {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return mutator.UpsertAll(typeURL, upserted, []string{"127.0.0.1"}, wg, callback)
+}
+
+// upsertEnvoyResourcesTransactional commits the resources of each type URL as
+// a single cache transaction, so that Envoy observes each type's new state in
+// one DiscoveryResponse instead of one per resource. Cross-type atomicity is
+// not possible, as each xDS stream subscribes to a single type URL; instead
+// the cluster-before-listener ordering of the per-resource path is retained
+// so that listeners never refer to clusters Envoy has not accepted yet.
+func (s *xdsServer) upsertEnvoyResourcesTransactional(ctx context.Context, resources Resources) error {
+	var wg *completion.WaitGroup
+	// As in the per-resource path, wait for Envoy to ACK the cluster
+	// transaction before adding listeners referring to the new clusters.
+	if len(resources.Listeners) > 0 && len(resources.Clusters) > 0 {
+		wg = completion.NewWaitGroup(ctx)
+	}
+	var revertFuncs xds.AckingResourceMutatorRevertFuncList
+	// Do not wait for the additions of secrets, endpoints, clusters or routes
+	// as there are no guarantees that these additions will be acked if
+	// nothing refers to them, see UpsertEnvoyResources.
+	if len(resources.Secrets) > 0 {
+		upserted := make(map[string]proto.Message, len(resources.Secrets))
+		for _, r := range resources.Secrets {
+			upserted[r.Name] = r
+		}
+		revertFuncs = append(revertFuncs, s.upsertAll(SecretTypeURL, s.secretMutator, upserted, nil, nil))
+	}
+	if len(resources.Endpoints) > 0 {
+		upserted := make(map[string]proto.Message, len(resources.Endpoints))
+		for _, r := range resources.Endpoints {
+			upserted[r.ClusterName] = r
+		}
+		revertFuncs = append(revertFuncs, s.upsertAll(EndpointTypeURL, s.endpointMutator, upserted, nil, nil))
+	}
+	if len(resources.Clusters) > 0 {
+		upserted := make(map[string]proto.Message, len(resources.Clusters))
+		for _, r := range resources.Clusters {
+			if resources.L7LB {
+				s.fillInOutlierDetection(r)
+			}
+			upserted[r.Name] = r
+		}
+		revertFuncs = append(revertFuncs, s.upsertAll(ClusterTypeURL, s.clusterMutator, upserted, wg, nil))
+	}
+	if len(resources.Routes) > 0 {
+		upserted := make(map[string]proto.Message, len(resources.Routes))
+		for _, r := range resources.Routes {
+			upserted[r.Name] = r
+		}
+		revertFuncs = append(revertFuncs, s.upsertAll(RouteTypeURL, s.routeMutator, upserted, nil, nil))
+	}
+	// Wait before new Listeners are added if clusters were also added above.
+	if wg != nil {
+		start := time.Now()
+		s.logger.Debug("UpsertEnvoyResources: Waiting for cluster updates to complete...")
+		err := wg.Wait()
+		s.logger.Debug("UpsertEnvoyResources: Wait time for cluster updates",
+			logfields.Duration, time.Since(start),
+			logfields.Error, err,
+		)
+
+		// revert all changes in case of failure
+		if err != nil {
+			revertFuncs.Revert(nil)
+			s.logger.Debug("UpsertEnvoyResources: Finished reverting failed xDS transactions")
+			return err
+		}
+		wg = nil
+	}
+	// Wait only if new Listeners are added, as they will always be acked.
+	if len(resources.Listeners) > 0 {
+		wg = completion.NewWaitGroup(ctx)
+
+		upserted := make(map[string]proto.Message, len(resources.Listeners))
+		for _, r := range resources.Listeners {
+			fillInUseRemoteAddress(r, resources.UseRemoteAddress)
+			if err := r.Validate(); err != nil {
+				if s.config.metrics != nil {
+					s.config.metrics.IncreaseValidationError(ListenerTypeURL, r.Name)
+				}
+				revertFuncs.Revert(nil)
+				return &ErrListenerValidation{Listener: r.Name, Err: err}
+			}
+			upserted[r.Name] = r
+		}
+		revertFuncs = append(revertFuncs, s.upsertAll(ListenerTypeURL, s.listenerMutator, upserted, wg,
+			// this callback is not called if there is no change
+			func(err error) {
+				if err != nil {
+					s.logger.Warn("Envoy: Listener transaction failed",
+						logfields.Error, err,
+					)
+					return
+				}
+				for _, r := range resources.Listeners {
+					if resources.PortAllocationCallbacks[r.Name] != nil {
+						if callbackErr := resources.PortAllocationCallbacks[r.Name](ctx); callbackErr != nil {
+							s.logger.Warn("Failure in port allocation callback",
+								logfields.Error, callbackErr,
+							)
+						}
+					}
+				}
+			}))
+
+		start := time.Now()
+		s.logger.Debug("UpsertEnvoyResources: Waiting for proxy updates to complete...")
+		err := wg.Wait()
+		s.logger.Debug("UpsertEnvoyResources: Wait time for proxy updates",
+			logfields.Duration, time.Since(start),
+			logfields.Error, err,
+		)
+
+		// revert all changes in case of failure
+		if err != nil {
+			revertFuncs.Revert(nil)
+			s.logger.Debug("UpsertEnvoyResources: Finished reverting failed xDS transactions")
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *xdsServer) UpdateEnvoyResources(ctx context.Context, old, new Resources) (err error) {
+	ctx, span := s.tracer.Start(ctx, "UpdateEnvoyResources", trace.WithAttributes(
+		append(append(resourceCountAttributes("old.", old), resourceCountAttributes("new.", new)...),
+			// Only new listeners are reliably ACKed, see below.
+			attribute.Bool("waited_for_ack", len(new.Listeners) > 0))...))
+	defer func() { endSpan(span, err) }()
+	if s.config.metrics != nil {
+		start := time.Now()
+		defer func() {
+			s.config.metrics.ObserveResourcesUpdateDuration("update", xds.ResultFromError(err), time.Since(start).Seconds())
+		}()
+	}
 	waitForDelete := false
 	var wg *completion.WaitGroup
 	var revertFuncs xds.AckingResourceMutatorRevertFuncList
@@ -2044,39 +3477,34 @@ func (s *xdsServer) UpdateEnvoyResources(ctx context.Context, old, new Resources
 		wg = completion.NewWaitGroup(ctx)
 	}
 	// Delete old listeners not added in 'new' or if old and new listener have different ports
-	var deleteListeners []*envoy_config_listener.Listener
+	toAdd, toDelete := EnvoyResourcesDiff(old, new)
+
+	oldListenerPorts := make(map[string]uint32, len(old.Listeners))
 	for _, oldListener := range old.Listeners {
-		found := false
-		port := uint32(0)
-		if addr := oldListener.Address.GetSocketAddress(); addr != nil {
-			port = addr.GetPortValue()
-		}
-		for _, newListener := range new.Listeners {
-			if newListener.Name == oldListener.Name {
-				if addr := newListener.Address.GetSocketAddress(); addr != nil && addr.GetPortValue() != port {
-					s.logger.Debug("UpdateEnvoyResources: port changing",
-						logfields.Listener, newListener.Name,
-						logfields.ValueBefore, port,
-						logfields.ValueAfter, addr.GetPortValue(),
-					)
-					waitForDelete = true
-				} else {
-					// port is not changing, remove from new.PortAllocations to prevent acking an already acked port.
-					delete(new.PortAllocationCallbacks, newListener.Name)
-					found = true
-				}
-				break
-			}
+		oldListenerPorts[oldListener.Name] = listenerPort(oldListener)
+	}
+	for _, newListener := range toAdd.Listeners {
+		port, existed := oldListenerPorts[newListener.Name]
+		if !existed {
+			continue
 		}
-		if !found {
-			deleteListeners = append(deleteListeners, oldListener)
+		if addr := newListener.Address.GetSocketAddress(); addr != nil && addr.GetPortValue() != port {
+			s.logger.Debug("UpdateEnvoyResources: port changing",
+				logfields.Listener, newListener.Name,
+				logfields.ValueBefore, port,
+				logfields.ValueAfter, addr.GetPortValue(),
+			)
+			waitForDelete = true
+		} else {
+			// port is not changing, remove from new.PortAllocations to prevent acking an already acked port.
+			delete(new.PortAllocationCallbacks, newListener.Name)
 		}
 	}
 	s.logger.Debug("UpdateEnvoyResources: listeners",
-		logfields.ResourcesDeleted, len(deleteListeners),
-		logfields.ResourcesUpserted, len(new.Listeners),
+		logfields.ResourcesDeleted, len(toDelete.Listeners),
+		logfields.ResourcesUpserted, len(toAdd.Listeners),
 	)
-	for _, listener := range deleteListeners {
+	for _, listener := range toDelete.Listeners {
 		listenerName := listener.Name
 		revertFuncs = append(revertFuncs, s.deleteListener(listener.Name, wg,
 			func(err error) {
@@ -2098,86 +3526,38 @@ func (s *xdsServer) UpdateEnvoyResources(ctx context.Context, old, new Resources
 	// resources to begin with.
 
 	// Delete old routes not added in 'new'
-	var deleteRoutes []*envoy_config_route.RouteConfiguration
-	for _, oldRoute := range old.Routes {
-		found := false
-		for _, newRoute := range new.Routes {
-			if newRoute.Name == oldRoute.Name {
-				found = true
-			}
-		}
-		if !found {
-			deleteRoutes = append(deleteRoutes, oldRoute)
-		}
-	}
 	s.logger.Debug("UpdateEnvoyResources: routes",
-		logfields.ResourcesDeleted, len(deleteRoutes),
-		logfields.ResourcesUpserted, len(new.Routes),
+		logfields.ResourcesDeleted, len(toDelete.Routes),
+		logfields.ResourcesUpserted, len(toAdd.Routes),
 	)
-	for _, route := range deleteRoutes {
+	for _, route := range toDelete.Routes {
 		revertFuncs = append(revertFuncs, s.deleteRoute(route.Name, nil))
 	}
 
 	// Delete old clusters not added in 'new'
-	var deleteClusters []*envoy_config_cluster.Cluster
-	for _, oldCluster := range old.Clusters {
-		found := false
-		for _, newCluster := range new.Clusters {
-			if newCluster.Name == oldCluster.Name {
-				found = true
-			}
-		}
-		if !found {
-			deleteClusters = append(deleteClusters, oldCluster)
-		}
-	}
 	s.logger.Debug("UpdateEnvoyResources: clusters",
-		logfields.ResourcesDeleted, len(deleteClusters),
-		logfields.ResourcesUpserted, len(new.Clusters),
+		logfields.ResourcesDeleted, len(toDelete.Clusters),
+		logfields.ResourcesUpserted, len(toAdd.Clusters),
 	)
-	for _, cluster := range deleteClusters {
+	for _, cluster := range toDelete.Clusters {
 		revertFuncs = append(revertFuncs, s.deleteCluster(cluster.Name, nil))
 	}
 
 	// Delete old endpoints not added in 'new'
-	var deleteEndpoints []*envoy_config_endpoint.ClusterLoadAssignment
-	for _, oldEndpoint := range old.Endpoints {
-		found := false
-		for _, newEndpoint := range new.Endpoints {
-			if newEndpoint.ClusterName == oldEndpoint.ClusterName {
-				found = true
-			}
-		}
-		if !found {
-			deleteEndpoints = append(deleteEndpoints, oldEndpoint)
-		}
-	}
 	s.logger.Debug("UpdateEnvoyResources: endpoints",
-		logfields.ResourcesDeleted, len(deleteEndpoints),
-		logfields.ResourcesUpserted, len(new.Endpoints),
+		logfields.ResourcesDeleted, len(toDelete.Endpoints),
+		logfields.ResourcesUpserted, len(toAdd.Endpoints),
 	)
-	for _, endpoint := range deleteEndpoints {
+	for _, endpoint := range toDelete.Endpoints {
 		revertFuncs = append(revertFuncs, s.deleteEndpoint(endpoint.ClusterName, nil))
 	}
 
 	// Delete old secrets not added in 'new'
-	var deleteSecrets []*envoy_config_tls.Secret
-	for _, oldSecret := range old.Secrets {
-		found := false
-		for _, newSecret := range new.Secrets {
-			if newSecret.Name == oldSecret.Name {
-				found = true
-			}
-		}
-		if !found {
-			deleteSecrets = append(deleteSecrets, oldSecret)
-		}
-	}
 	s.logger.Debug("UpdateEnvoyResources: secrets",
-		logfields.ResourcesDeleted, len(deleteSecrets),
-		logfields.ResourcesUpserted, len(new.Secrets),
+		logfields.ResourcesDeleted, len(toDelete.Secrets),
+		logfields.ResourcesUpserted, len(toAdd.Secrets),
 	)
-	for _, secret := range deleteSecrets {
+	for _, secret := range toDelete.Secrets {
 		revertFuncs = append(revertFuncs, s.deleteSecret(secret.Name, nil))
 	}
 
@@ -2199,22 +3579,22 @@ func (s *xdsServer) UpdateEnvoyResources(ctx context.Context, old, new Resources
 	}
 
 	// Add new Secrets
-	for _, r := range new.Secrets {
+	for _, r := range toAdd.Secrets {
 		revertFuncs = append(revertFuncs, s.upsertSecret(r.Name, r, nil))
 	}
 	// Add new Endpoints
-	for _, r := range new.Endpoints {
+	for _, r := range toAdd.Endpoints {
 		revertFuncs = append(revertFuncs, s.upsertEndpoint(r.ClusterName, r, nil))
 	}
 	// Add new Clusters
-	for _, r := range new.Clusters {
+	for _, r := range toAdd.Clusters {
 		revertFuncs = append(revertFuncs, s.upsertCluster(r.Name, r, wg))
 	}
 	// Add new Routes
-	for _, r := range new.Routes {
+	for _, r := range toAdd.Routes {
 		revertFuncs = append(revertFuncs, s.upsertRoute(r.Name, r, nil))
 	}
-	if wg != nil && len(new.Clusters) > 0 {
+	if wg != nil && len(toAdd.Clusters) > 0 {
 		start := time.Now()
 		s.logger.Debug("UpdateEnvoyResources: Waiting for cluster updates to complete...")
 		err := wg.Wait()
@@ -2230,11 +3610,25 @@ func (s *xdsServer) UpdateEnvoyResources(ctx context.Context, old, new Resources
 		wg = completion.NewWaitGroup(ctx)
 	}
 	// Add new Listeners
-	for _, r := range new.Listeners {
+	for _, r := range toAdd.Listeners {
+		fillInUseRemoteAddress(r, new.UseRemoteAddress)
+		if err := r.Validate(); err != nil {
+			if s.config.metrics != nil {
+				s.config.metrics.IncreaseValidationError(ListenerTypeURL, r.Name)
+			}
+			revertFuncs.Revert(nil)
+			return &ErrListenerValidation{Listener: r.Name, Err: err}
+		}
 		listenerName := r.Name
 		revertFuncs = append(revertFuncs, s.upsertListener(r.Name, r, wg,
 			// this callback is not called if there is no change
 			func(err error) {
+				if err != nil {
+					s.logger.Warn("Envoy: Listener update failed",
+						logfields.Listener, listenerName,
+						logfields.Error, err,
+					)
+				}
 				if err == nil && new.PortAllocationCallbacks[listenerName] != nil {
 					if callbackErr := new.PortAllocationCallbacks[listenerName](ctx); callbackErr != nil {
 						s.logger.Warn("Failure in port allocation callback",
@@ -8,6 +8,8 @@ import (
 	"log/slog"
 	"sync"
 
+	"google.golang.org/protobuf/proto"
+
 	"github.com/cilium/cilium/pkg/completion"
 	"github.com/cilium/cilium/pkg/logging/logfields"
 	"github.com/cilium/cilium/pkg/policy"
@@ -30,20 +32,22 @@ type onDemandXdsStarter struct {
 	maxConnectionDuration    time.Duration
 	idleTimeout              time.Duration
 	maxConcurrentRetries     uint32
+	xdsSocketMode            string
+	tlsUpstreamOverride      upstreamTLSOverrideConfig
 
 	envoyOnce sync.Once
 }
 
 var _ XDSServer = &onDemandXdsStarter{}
 
-func (o *onDemandXdsStarter) AddListener(name string, kind policy.L7ParserType, port uint16, isIngress bool, mayUseOriginalSourceAddr bool, wg *completion.WaitGroup, cb func(err error)) error {
+func (o *onDemandXdsStarter) AddListener(name string, kind policy.L7ParserType, port uint16, isIngress bool, mayUseOriginalSourceAddr bool, failFast bool, wg *completion.WaitGroup, cb func(err error)) error {
 	if err := o.startEmbeddedEnvoy(nil); err != nil {
 		o.logger.Error("Envoy: Failed to start embedded Envoy proxy on demand",
 			logfields.Error, err,
 		)
 	}
 
-	return o.XDSServer.AddListener(name, kind, port, isIngress, mayUseOriginalSourceAddr, wg, cb)
+	return o.XDSServer.AddListener(name, kind, port, isIngress, mayUseOriginalSourceAddr, failFast, wg, cb)
 }
 
 func (o *onDemandXdsStarter) UpsertEnvoyResources(ctx context.Context, resources Resources) error {
@@ -66,6 +70,15 @@ func (o *onDemandXdsStarter) UpdateEnvoyResources(ctx context.Context, old, new
 	return o.XDSServer.UpdateEnvoyResources(ctx, old, new)
 }
 
+// bootstrapExtensions returns the bootstrap extensions registered on the
+// wrapped xDS server, if it keeps track of them.
+func (o *onDemandXdsStarter) bootstrapExtensions() []proto.Message {
+	if s, ok := o.XDSServer.(*xdsServer); ok {
+		return s.bootstrapExtensions()
+	}
+	return nil
+}
+
 func (o *onDemandXdsStarter) startEmbeddedEnvoy(wg *completion.WaitGroup) error {
 	var startErr error
 
@@ -82,6 +95,9 @@ func (o *onDemandXdsStarter) startEmbeddedEnvoy(wg *completion.WaitGroup) error
 			maxConnectionDuration:    o.maxConnectionDuration,
 			idleTimeout:              o.idleTimeout,
 			maxConcurrentRetries:     o.maxConcurrentRetries,
+			xdsSocketMode:            o.xdsSocketMode,
+			tlsUpstreamOverride:      o.tlsUpstreamOverride,
+			bootstrapExtensions:      o.bootstrapExtensions(),
 		})
 
 		// Add Prometheus listener if the port is (properly) configured
@@ -4,29 +4,46 @@
 package envoy
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/cilium/hive/hivetest"
 	cilium "github.com/cilium/proxy/go/cilium/api"
 	"github.com/cilium/proxy/pkg/policy/api/kafka"
+	envoy_config_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoy_config_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	envoy_config_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoy_config_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_config_http "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoy_config_tcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	envoy_config_tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoy_type_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	"k8s.io/apimachinery/pkg/types"
 
+	"github.com/cilium/cilium/pkg/completion"
 	"github.com/cilium/cilium/pkg/container/versioned"
+	"github.com/cilium/cilium/pkg/crypto/certificatemanager"
 	envoypolicy "github.com/cilium/cilium/pkg/envoy/policy"
+	"github.com/cilium/cilium/pkg/envoy/xds"
 	"github.com/cilium/cilium/pkg/identity"
 	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging"
 	"github.com/cilium/cilium/pkg/policy"
 	"github.com/cilium/cilium/pkg/policy/api"
 	"github.com/cilium/cilium/pkg/proxy/endpoint"
 	"github.com/cilium/cilium/pkg/proxy/endpoint/test"
 	testpolicy "github.com/cilium/cilium/pkg/testutils/policy"
+	"github.com/cilium/cilium/pkg/time"
 )
 
 var (
@@ -405,6 +422,36 @@ var ExpectedPerPortPoliciesSNI = []*cilium.PortNetworkPolicy{
 	},
 }
 
+// L4PolicyMapSNIRegex enforces SNI with a regex server name next to an exact
+// one. The unparseable regex must be dropped from the generated policy.
+var L4PolicyMapSNIRegex = policy.NewL4PolicyMapWithValues(map[string]*policy.L4Filter{
+	"443/TCP": {
+		Port:     443,
+		Protocol: api.ProtoTCP,
+		PerSelectorPolicies: policy.L7DataMap{
+			wildcardCachedSelector: &policy.PerSelectorPolicy{
+				ServerNames: policy.NewStringSet([]string{
+					`~.*\.internal\.corp`,
+					`~*.invalid`,
+					"ab.cd.com",
+				}),
+			},
+		},
+	},
+})
+
+var ExpectedPerPortPoliciesSNIRegex = []*cilium.PortNetworkPolicy{
+	{
+		Port:     443,
+		Protocol: envoy_config_core.SocketAddress_TCP,
+		Rules: []*cilium.PortNetworkPolicyRule{
+			{
+				ServerNames: []string{"ab.cd.com", `~.*\.internal\.corp`},
+			},
+		},
+	},
+}
+
 var ExpectedPerPortPolicies1 = []*cilium.PortNetworkPolicy{
 	{
 		Port:     8080,
@@ -548,7 +595,7 @@ func Test_getWildcardNetworkPolicyRules(t *testing.T) {
 
 	xds := testXdsServer(t)
 
-	obtained := xds.getWildcardNetworkPolicyRules(version, perSelectorPoliciesWithWildcard)
+	obtained := xds.getWildcardNetworkPolicyRules(ep, version, perSelectorPoliciesWithWildcard, false, false, "")
 	require.Equal(t, []*cilium.PortNetworkPolicyRule{{}}, obtained)
 
 	// both cachedSelector2 and cachedSelector2 select identity 1001, but duplicates must have been removed
@@ -558,13 +605,35 @@ func Test_getWildcardNetworkPolicyRules(t *testing.T) {
 		cachedRequiresV2Selector1: nil,
 	}
 
-	obtained = xds.getWildcardNetworkPolicyRules(version, perSelectorPolicies)
+	obtained = xds.getWildcardNetworkPolicyRules(ep, version, perSelectorPolicies, false, false, "")
 	require.Equal(t, []*cilium.PortNetworkPolicyRule{{
 		Deny:           true,
 		RemotePolicies: []uint32{1001, 1002},
 	}, {
 		RemotePolicies: []uint32{1001, 1002, 1003},
 	}}, obtained)
+
+	// A selector with an L7 redirect must not be merged into the plain allow
+	// rule, as the merged rule would allow the traffic without the proxy
+	// redirection. It is emitted as a separate rule with its L7 content.
+	perSelectorPoliciesL7 := policy.L7DataMap{
+		cachedSelector2: nil,
+		cachedSelector1: L7Rules12,
+	}
+
+	obtained = xds.getWildcardNetworkPolicyRules(ep, version, perSelectorPoliciesL7, false, false, "")
+	require.Equal(t, []*cilium.PortNetworkPolicyRule{{
+		RemotePolicies: []uint32{1001, 1003},
+	}, ExpectedPortNetworkPolicyRule12}, obtained)
+
+	// The same holds when the plain selector is the wildcard.
+	perSelectorPoliciesWildcardL7 := policy.L7DataMap{
+		wildcardCachedSelector: nil,
+		cachedSelector1:        L7Rules12,
+	}
+
+	obtained = xds.getWildcardNetworkPolicyRules(ep, version, perSelectorPoliciesWildcardL7, false, false, "")
+	require.Equal(t, []*cilium.PortNetworkPolicyRule{{}, ExpectedPortNetworkPolicyRule12}, obtained)
 }
 
 func TestGetPortNetworkPolicyRule(t *testing.T) {
@@ -617,6 +686,45 @@ func TestGetDirectionNetworkPolicy(t *testing.T) {
 	// L4-only with SNI
 	obtained = xds.getDirectionNetworkPolicy(ep, L4PolicyMapSNI, true, false, false, "ingress", "")
 	require.Equal(t, ExpectedPerPortPoliciesSNI, obtained)
+
+	// L4-only with regex SNI
+	obtained = xds.getDirectionNetworkPolicy(ep, L4PolicyMapSNIRegex, true, false, false, "ingress", "")
+	require.Equal(t, ExpectedPerPortPoliciesSNIRegex, obtained)
+}
+
+// BenchmarkGetDirectionNetworkPolicyWildcardDeny exercises the wildcard
+// deny-all short circuit: the per-port rules never make it into the generated
+// policy, so translating them would be wasted work.
+func BenchmarkGetDirectionNetworkPolicyWildcardDeny(b *testing.B) {
+	xds := testXdsServer(b)
+
+	filters := map[string]*policy.L4Filter{
+		"0/TCP": {
+			Port:     0,
+			Protocol: api.ProtoTCP,
+			PerSelectorPolicies: policy.L7DataMap{
+				wildcardCachedSelector: &policy.PerSelectorPolicy{IsDeny: true},
+			},
+		},
+	}
+	for i := range 1000 {
+		port := uint16(1000 + i)
+		filters[fmt.Sprintf("%d/TCP", port)] = &policy.L4Filter{
+			Port:     port,
+			Protocol: api.ProtoTCP,
+			PerSelectorPolicies: policy.L7DataMap{
+				cachedSelector1: L7Rules12,
+			},
+		}
+	}
+	l4Map := policy.NewL4PolicyMapWithValues(filters)
+
+	for b.Loop() {
+		obtained := xds.getDirectionNetworkPolicy(ep, l4Map, true, false, false, "ingress", "")
+		if len(obtained) != 1 {
+			b.Fatalf("expected only the wildcard deny-all policy, got %d entries", len(obtained))
+		}
+	}
 }
 
 func TestGetNetworkPolicy(t *testing.T) {
@@ -632,6 +740,102 @@ func TestGetNetworkPolicy(t *testing.T) {
 	require.Equal(t, expected, obtained)
 }
 
+func TestGetNetworkPolicyEndpointLabels(t *testing.T) {
+	xds := testXdsServer(t)
+	mock := &test.ProxyUpdaterMock{
+		Id:             1000,
+		Ipv4:           IPv4Addr,
+		VersionHandle:  versioned.Latest(),
+		IdentityLabels: labels.ParseLabelArray("k8s:app=foo", "k8s:io.kubernetes.pod.namespace=bar"),
+	}
+
+	// Labels are not included by default.
+	obtained := xds.getNetworkPolicy(mock, []string{IPv4Addr}, L4Policy1, true, true, false, false, "")
+	require.Nil(t, obtained.EndpointLabels)
+
+	// Opting in includes the sorted identity labels.
+	xds.config.includeEndpointLabels = true
+	obtained = xds.getNetworkPolicy(mock, []string{IPv4Addr}, L4Policy1, true, true, false, false, "")
+	require.Equal(t, mock.IdentityLabels.GetModel(), obtained.EndpointLabels)
+	require.NoError(t, obtained.Validate())
+
+	// A label update propagates into the next generated policy.
+	mock.IdentityLabels = labels.ParseLabelArray("k8s:app=baz")
+	obtained = xds.getNetworkPolicy(mock, []string{IPv4Addr}, L4Policy1, true, true, false, false, "")
+	require.Equal(t, []string{"k8s:app=baz"}, obtained.EndpointLabels)
+
+	// The number of included labels is bounded.
+	var many []string
+	for i := range maxNetworkPolicyEndpointLabels + 10 {
+		many = append(many, fmt.Sprintf("k8s:label%04d=value", i))
+	}
+	mock.IdentityLabels = labels.ParseLabelArray(many...)
+	obtained = xds.getNetworkPolicy(mock, []string{IPv4Addr}, L4Policy1, true, true, false, false, "")
+	require.Len(t, obtained.EndpointLabels, maxNetworkPolicyEndpointLabels)
+}
+
+func TestNetworkPolicyCounts(t *testing.T) {
+	policies := []*cilium.PortNetworkPolicy{
+		{
+			Port:     80,
+			Protocol: envoy_config_core.SocketAddress_TCP,
+			Rules: []*cilium.PortNetworkPolicyRule{
+				{
+					RemotePolicies: []uint32{1001, 1002},
+					L7: &cilium.PortNetworkPolicyRule_HttpRules{
+						HttpRules: &cilium.HttpNetworkPolicyRules{
+							HttpRules: []*cilium.HttpNetworkPolicyRule{{}, {}, {}},
+						},
+					},
+				},
+				{
+					RemotePolicies: []uint32{1003},
+					L7: &cilium.PortNetworkPolicyRule_L7Rules{
+						L7Rules: &cilium.L7NetworkPolicyRules{
+							L7AllowRules: []*cilium.L7NetworkPolicyRule{{}},
+							L7DenyRules:  []*cilium.L7NetworkPolicyRule{{}},
+						},
+					},
+				},
+			},
+		},
+		{
+			Port:     9092,
+			Protocol: envoy_config_core.SocketAddress_TCP,
+			Rules: []*cilium.PortNetworkPolicyRule{
+				{
+					RemotePolicies: []uint32{1001},
+					L7: &cilium.PortNetworkPolicyRule_KafkaRules{
+						KafkaRules: &cilium.KafkaNetworkPolicyRules{
+							KafkaRules: []*cilium.KafkaNetworkPolicyRule{{}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	stats := countPortNetworkPolicies(policies)
+	require.Equal(t, endpoint.NetworkPolicyStats{PortPolicies: 2, Rules: 3, RemoteIdentities: 4, L7Rules: 6}, stats)
+	require.Equal(t, endpoint.NetworkPolicyStats{}, countPortNetworkPolicies(nil))
+}
+
+func TestGetNetworkPolicyStatsUpdate(t *testing.T) {
+	xds := testXdsServer(t)
+	mock := &test.ProxyUpdaterMock{
+		Id:            1000,
+		Ipv4:          IPv4Addr,
+		VersionHandle: versioned.Latest(),
+	}
+	obtained := xds.getNetworkPolicy(mock, []string{IPv4Addr}, L4Policy1, true, true, false, false, "")
+
+	// The counts pushed to the endpoint match the generated policy.
+	require.Equal(t, countPortNetworkPolicies(obtained.IngressPerPortPolicies), mock.IngressPolicyStats)
+	require.Equal(t, countPortNetworkPolicies(obtained.EgressPerPortPolicies), mock.EgressPolicyStats)
+	require.NotZero(t, mock.IngressPolicyStats.PortPolicies)
+	require.NotZero(t, mock.IngressPolicyStats.Rules)
+}
+
 func TestGetNetworkPolicyWildcard(t *testing.T) {
 	xds := testXdsServer(t)
 	obtained := xds.getNetworkPolicy(ep, []string{IPv4Addr}, L4Policy2, true, true, false, false, "")
@@ -684,6 +888,22 @@ func TestGetNetworkPolicyNil(t *testing.T) {
 	require.Equal(t, expected, obtained)
 }
 
+func TestGetNetworkPolicyDegraded(t *testing.T) {
+	xds := testXdsServer(t)
+	// Any non-empty policy exceeds a one byte limit
+	xds.config.networkPolicyMaxSize = 1
+	obtained := xds.getNetworkPolicy(ep, []string{IPv4Addr}, L4Policy1, true, true, false, false, "")
+	for _, policies := range [][]*cilium.PortNetworkPolicy{obtained.IngressPerPortPolicies, obtained.EgressPerPortPolicies} {
+		require.NotEmpty(t, policies)
+		for _, pnp := range policies {
+			for _, rule := range pnp.Rules {
+				require.Empty(t, rule.RemotePolicies)
+			}
+		}
+	}
+	require.NoError(t, obtained.Validate())
+}
+
 func TestGetNetworkPolicyIngressNotEnforced(t *testing.T) {
 	xds := testXdsServer(t)
 	obtained := xds.getNetworkPolicy(ep, []string{IPv4Addr}, L4Policy2, false, true, false, false, "")
@@ -710,6 +930,37 @@ func TestGetNetworkPolicyEgressNotEnforced(t *testing.T) {
 	require.Equal(t, expected, obtained)
 }
 
+func TestGetNetworkPolicyVisibilityNotEnforced(t *testing.T) {
+	xds := testXdsServer(t)
+	visEp := &test.ProxyUpdaterMock{
+		Id:                     1000,
+		Ipv4:                   "10.0.0.1",
+		Ipv6:                   "f00d::1",
+		VersionHandle:          versioned.Latest(),
+		IngressVisibilityPorts: []uint16{80, 8080},
+	}
+
+	// Without visibility ports the non-enforced direction is a blanket allow.
+	obtained := xds.getNetworkPolicy(ep, []string{IPv4Addr}, nil, false, false, false, false, "")
+	require.Equal(t, allowAllPortNetworkPolicy, obtained.IngressPerPortPolicies)
+	require.Equal(t, allowAllPortNetworkPolicy, obtained.EgressPerPortPolicies)
+
+	// With ingress visibility ports the blanket allow is kept, but the
+	// visibility ports get their own allow entries so that the proxy matches
+	// them with per-port granularity.
+	obtained = xds.getNetworkPolicy(ep, []string{IPv4Addr}, nil, false, false, false, false, "")
+	obtainedVis := xds.getNetworkPolicy(visEp, []string{IPv4Addr}, nil, false, false, false, false, "")
+	require.NotEqual(t, obtained.IngressPerPortPolicies, obtainedVis.IngressPerPortPolicies)
+	require.Equal(t, envoypolicy.SortPortNetworkPolicies([]*cilium.PortNetworkPolicy{
+		allowAllTCPPortNetworkPolicy,
+		{Port: 80, Protocol: envoy_config_core.SocketAddress_TCP},
+		{Port: 8080, Protocol: envoy_config_core.SocketAddress_TCP},
+	}), obtainedVis.IngressPerPortPolicies)
+	// Egress has no visibility ports and keeps the plain blanket allow.
+	require.Equal(t, allowAllPortNetworkPolicy, obtainedVis.EgressPerPortPolicies)
+	require.NoError(t, obtainedVis.Validate())
+}
+
 var L4PolicyL7 = &policy.L4Policy{
 	Ingress: policy.L4DirectionPolicy{PortRules: policy.NewL4PolicyMapWithValues(map[string]*policy.L4Filter{
 		"9090/TCP": {
@@ -1703,7 +1954,17 @@ func Test_getLocalListenerAddresses(t *testing.T) {
 		args           args
 		want           *envoy_config_core.Address
 		wantAdditional []*envoy_config_listener.AdditionalAddress
+		wantErr        bool
 	}{
+		{
+			name: "neither IPv4 nor IPv6",
+			args: args{
+				port: 80,
+				ipv4: false,
+				ipv6: false,
+			},
+			wantErr: true,
+		},
 		{
 			name: "IPv4 only",
 			args: args{
@@ -1741,7 +2002,10 @@ func Test_getLocalListenerAddresses(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, gotAdditional := GetLocalListenerAddresses(tt.args.port, tt.args.ipv4, tt.args.ipv6)
+			got, gotAdditional, err := GetLocalListenerAddresses(tt.args.port, tt.args.ipv4, tt.args.ipv6)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getLocalListenerAddresses() err = %v, wantErr %v", err, tt.wantErr)
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("getLocalListenerAddresses() got = %v, want %v", got, tt.want)
 			}
@@ -1752,10 +2016,1016 @@ func Test_getLocalListenerAddresses(t *testing.T) {
 	}
 }
 
-func testXdsServer(t *testing.T) *xdsServer {
+func TestMergeListeners(t *testing.T) {
+	listener := func(name string, chains ...*envoy_config_listener.FilterChain) *envoy_config_listener.Listener {
+		return &envoy_config_listener.Listener{
+			Name:         name,
+			FilterChains: chains,
+		}
+	}
+	chain := func(transportProtocol string) *envoy_config_listener.FilterChain {
+		return &envoy_config_listener.FilterChain{
+			FilterChainMatch: &envoy_config_listener.FilterChainMatch{
+				TransportProtocol: transportProtocol,
+			},
+		}
+	}
+
+	old := Resources{
+		Listeners: []*envoy_config_listener.Listener{
+			listener("shared-listener", chain("raw_buffer")),
+		},
+	}
+
+	// Filter chains with a distinct match are merged into the existing listener,
+	// listeners with a new name are added as such.
+	old.MergeListeners(&Resources{
+		Listeners: []*envoy_config_listener.Listener{
+			listener("shared-listener", chain("tls")),
+			listener("other-listener", chain("raw_buffer")),
+		},
+	})
+	require.Len(t, old.Listeners, 2)
+	require.Equal(t, "shared-listener", old.Listeners[0].Name)
+	require.Len(t, old.Listeners[0].FilterChains, 2)
+	require.Equal(t, "raw_buffer", old.Listeners[0].FilterChains[0].FilterChainMatch.TransportProtocol)
+	require.Equal(t, "tls", old.Listeners[0].FilterChains[1].FilterChainMatch.TransportProtocol)
+	require.Equal(t, "other-listener", old.Listeners[1].Name)
+
+	// A filter chain with a match equal to an existing one is skipped.
+	old.MergeListeners(&Resources{
+		Listeners: []*envoy_config_listener.Listener{
+			listener("shared-listener", chain("tls")),
+		},
+	})
+	require.Len(t, old.Listeners, 2)
+	require.Len(t, old.Listeners[0].FilterChains, 2)
+}
+
+func TestFillInOutlierDetection(t *testing.T) {
+	server := testXdsServer(t)
+	server.config.l7lbOutlierDetection = outlierDetectionDefaults{
+		enabled:            true,
+		consecutive5xx:     5,
+		interval:           10 * time.Second,
+		baseEjectionTime:   30 * time.Second,
+		maxEjectionPercent: 10,
+	}
+
+	// Defaults are applied to clusters without outlier detection.
+	cluster := &envoy_config_cluster.Cluster{Name: "l7lb-cluster"}
+	server.fillInOutlierDetection(cluster)
+	require.NotNil(t, cluster.OutlierDetection)
+	require.Equal(t, uint32(5), cluster.OutlierDetection.Consecutive_5Xx.GetValue())
+	require.Equal(t, 10*time.Second, cluster.OutlierDetection.Interval.AsDuration())
+	require.Equal(t, 30*time.Second, cluster.OutlierDetection.BaseEjectionTime.AsDuration())
+	require.Equal(t, uint32(10), cluster.OutlierDetection.MaxEjectionPercent.GetValue())
+	require.NoError(t, cluster.Validate())
+
+	// Explicit outlier detection configuration is not overridden.
+	explicit := &envoy_config_cluster.OutlierDetection{
+		Consecutive_5Xx: &wrapperspb.UInt32Value{Value: 42},
+	}
+	cluster = &envoy_config_cluster.Cluster{
+		Name:             "explicit-cluster",
+		OutlierDetection: explicit,
+	}
+	server.fillInOutlierDetection(cluster)
+	require.Same(t, explicit, cluster.OutlierDetection)
+
+	// Nothing is applied when the defaults are disabled.
+	server.config.l7lbOutlierDetection.enabled = false
+	cluster = &envoy_config_cluster.Cluster{Name: "l7lb-cluster"}
+	server.fillInOutlierDetection(cluster)
+	require.Nil(t, cluster.OutlierDetection)
+}
+
+func TestSNITcpFilterChain(t *testing.T) {
+	require.Equal(t, "egress-cluster-www.example.com", sniClusterName("www.example.com"))
+	require.Equal(t, "egress-cluster-wildcard.example.com", sniClusterName("*.Example.com"))
+	require.Equal(t, "egress-cluster-foo-bar.example.com", sniClusterName("foo_bar.example.com"))
+
+	server := testXdsServer(t)
+	serverNames := []string{"one.example.com", "two.example.com"}
+
+	chain := server.getTcpFilterChainProto(egressClusterName, "", "", nil, true, serverNames...)
+	require.NoError(t, chain.Validate())
+	require.Equal(t, serverNames, chain.FilterChainMatch.ServerNames)
+	require.Equal(t, "tls", chain.FilterChainMatch.TransportProtocol)
+
+	// The TCP proxy selects the per-SNI cluster instead of the shared egress cluster.
+	tcpProxyFilter := chain.Filters[len(chain.Filters)-1]
+	require.Equal(t, "envoy.filters.network.tcp_proxy", tcpProxyFilter.Name)
+	message, err := tcpProxyFilter.GetTypedConfig().UnmarshalNew()
+	require.NoError(t, err)
+	tcpProxy, ok := message.(*envoy_config_tcp.TcpProxy)
+	require.True(t, ok)
+	weighted := tcpProxy.GetWeightedClusters().GetClusters()
+	require.Len(t, weighted, 2)
+	require.Equal(t, "egress-cluster-one.example.com", weighted[0].Name)
+	require.Equal(t, "egress-cluster-two.example.com", weighted[1].Name)
+
+	// The referenced clusters are created from the server names and validate.
+	clusters := getSNIClusterResources(serverNames)
+	require.Len(t, clusters, 2)
+	for i, cluster := range clusters {
+		require.Equal(t, weighted[i].Name, cluster.Name)
+		require.Equal(t, envoy_config_cluster.Cluster_ORIGINAL_DST, cluster.GetType())
+		require.NoError(t, cluster.Validate())
+	}
+
+	// Without server names the chain keeps routing to the shared cluster.
+	chain = server.getTcpFilterChainProto(egressClusterName, "", "", nil, true)
+	message, err = chain.Filters[len(chain.Filters)-1].GetTypedConfig().UnmarshalNew()
+	require.NoError(t, err)
+	require.Equal(t, egressClusterName, message.(*envoy_config_tcp.TcpProxy).GetCluster())
+}
+
+func TestInternalListenerGeneration(t *testing.T) {
+	server := testXdsServer(t)
+	server.config.useInternalListeners = true
+
+	listener := server.getListenerConf("internal-listener", policy.ParserTypeHTTP, 10000, true, false)
+	require.NoError(t, listener.Validate())
+	require.Nil(t, listener.GetAddress())
+	require.NotNil(t, listener.GetInternalListener())
+
+	cluster := getInternalListenerCluster("internal-cluster", "internal-listener")
+	require.NoError(t, cluster.Validate())
+	require.Equal(t, envoy_config_cluster.Cluster_STATIC, cluster.GetType())
+	address := cluster.GetLoadAssignment().GetEndpoints()[0].GetLbEndpoints()[0].GetEndpoint().GetAddress()
+	require.Equal(t, "internal-listener", address.GetEnvoyInternalAddress().GetServerListenerName())
+	require.Equal(t, "envoy.transport_sockets.internal_upstream", cluster.GetTransportSocket().GetName())
+
+	// The default mode keeps the socket address.
+	server.config.useInternalListeners = false
+	listener = server.getListenerConf("socket-listener", policy.ParserTypeHTTP, 10000, true, false)
+	require.NoError(t, listener.Validate())
+	require.NotNil(t, listener.GetAddress())
+	require.Nil(t, listener.GetInternalListener())
+}
+
+func TestAddListenerValidationError(t *testing.T) {
+	server := testXdsServer(t)
+	xdsMetrics := xds.NewXDSMetric()
+	server.config.metrics = xdsMetrics
+
+	// Port values above 65535 fail proto validation.
+	err := server.addListener("invalid-listener", func() *envoy_config_listener.Listener {
+		return &envoy_config_listener.Listener{
+			Name: "invalid-listener",
+			Address: &envoy_config_core.Address{
+				Address: &envoy_config_core.Address_SocketAddress{
+					SocketAddress: &envoy_config_core.SocketAddress{
+						Address: "127.0.0.1",
+						PortSpecifier: &envoy_config_core.SocketAddress_PortValue{
+							PortValue: 1000000,
+						},
+					},
+				},
+			},
+		}
+	}, nil, nil, true)
+
+	require.Error(t, err)
+	var validationErr *ErrListenerValidation
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, "invalid-listener", validationErr.Listener)
+	require.Equal(t, float64(1), xdsMetrics.ValidationErrorCount.WithLabelValues(ListenerTypeURL, "invalid-listener").Get())
+}
+
+func TestAddListenerProxyPortChange(t *testing.T) {
+	s := testXdsServer(t)
+	s.initializeXdsConfigs()
+	s.listenerCount = make(map[string]uint)
+	s.listenerConfs = make(map[string]func() *envoy_config_listener.Listener)
+
+	proxyIDFromCache := func(t *testing.T) uint32 {
+		t.Helper()
+		msg, err := s.listenerCache.Lookup(ListenerTypeURL, "test-listener")
+		require.NoError(t, err)
+		require.NotNil(t, msg)
+		for _, lf := range msg.(*envoy_config_listener.Listener).ListenerFilters {
+			if lf.Name == "cilium.bpf_metadata" {
+				conf, err := lf.GetTypedConfig().UnmarshalNew()
+				require.NoError(t, err)
+				return conf.(*cilium.BpfMetadata).ProxyId
+			}
+		}
+		t.Fatal("bpf_metadata listener filter not found")
+		return 0
+	}
+
+	port := uint16(10001)
+	listenerConf := func() *envoy_config_listener.Listener {
+		return s.getListenerConf("test-listener", policy.ParserTypeKafka, port, false, false)
+	}
+
+	require.NoError(t, s.addListener("test-listener", listenerConf, nil, nil, true))
+	require.Equal(t, uint32(10001), proxyIDFromCache(t))
+
+	// The datapath reallocates the proxy port for the same named listener,
+	// e.g., after a restart. Re-adding the listener reuses it, but must push
+	// the new configuration so that policy matching by proxy ID keeps working.
+	port = 10002
+	require.NoError(t, s.addListener("test-listener", listenerConf, nil, nil, true))
+	require.Equal(t, uint32(10002), proxyIDFromCache(t))
+
+	// The listener is still refcounted as reused.
+	require.Equal(t, uint(2), s.listenerCount["test-listener"])
+	require.Equal(t, 1, s.proxyListeners)
+}
+
+func TestProxyListenersMetric(t *testing.T) {
+	s := testXdsServer(t)
+	s.initializeXdsConfigs()
+	s.listenerCount = make(map[string]uint)
+	s.listenerConfs = make(map[string]func() *envoy_config_listener.Listener)
+	xdsMetrics := xds.NewXDSMetric()
+	s.config.metrics = xdsMetrics
+
+	listenerConf := func() *envoy_config_listener.Listener {
+		return s.getListenerConf("test-listener", policy.ParserTypeKafka, 10001, false, false)
+	}
+
+	require.NoError(t, s.addListener("test-listener", listenerConf, nil, nil, true))
+	require.Equal(t, 1, s.proxyListeners)
+	require.Equal(t, float64(1), xdsMetrics.ProxyListeners.Get())
+
+	// A reused listener does not change the count.
+	require.NoError(t, s.addListener("test-listener", listenerConf, nil, nil, true))
+	require.Equal(t, float64(1), xdsMetrics.ProxyListeners.Get())
+
+	s.removeListener("test-listener", nil, true)
+	require.Equal(t, float64(1), xdsMetrics.ProxyListeners.Get())
+
+	// Removing the last reference deletes the listener.
+	s.removeListener("test-listener", nil, true)
+	require.Equal(t, 0, s.proxyListeners)
+	require.Equal(t, float64(0), xdsMetrics.ProxyListeners.Get())
+}
+
+func TestUseOriginalSourceAddr(t *testing.T) {
+	server := testXdsServer(t)
+	server.config.mayUseOriginalSourceAddrIngress = false
+	server.config.mayUseOriginalSourceAddrEgress = true
+
+	// The per-direction configuration gates the datapath support reported by
+	// the caller.
+	require.False(t, server.useOriginalSourceAddr(true, true))
+	require.True(t, server.useOriginalSourceAddr(false, true))
+
+	// Without datapath support the original source address is never used.
+	require.False(t, server.useOriginalSourceAddr(true, false))
+	require.False(t, server.useOriginalSourceAddr(false, false))
+
+	server.config.mayUseOriginalSourceAddrIngress = true
+	server.config.mayUseOriginalSourceAddrEgress = false
+
+	require.True(t, server.useOriginalSourceAddr(true, true))
+	require.False(t, server.useOriginalSourceAddr(false, true))
+}
+
+func testXdsServer(t testing.TB) *xdsServer {
 	logger := hivetest.Logger(t)
 	return &xdsServer{
 		logger:            logger,
+		tracer:            noop.NewTracerProvider().Tracer(""),
 		l7RulesTranslator: envoypolicy.NewEnvoyL7RulesTranslator(logger, nil),
 	}
 }
+
+func TestListenerLingerConfigByParser(t *testing.T) {
+	s := testXdsServer(t)
+	s.config.httpLingerConfig = 10
+	s.config.lingerConfigByParser = map[policy.L7ParserType]int{
+		policy.ParserTypeKafka: 5,
+		policy.ParserTypeHTTP:  7,
+	}
+
+	lingerTime := func(t *testing.T, kind policy.L7ParserType) *uint32 {
+		t.Helper()
+		listenerConf := s.getListenerConf("test-listener", kind, 10003, false, false)
+		conf := &cilium.BpfMetadata{}
+		require.NoError(t, listenerConf.ListenerFilters[1].GetTypedConfig().UnmarshalTo(conf))
+		return conf.OriginalSourceSoLingerTime
+	}
+
+	// Parser-specific entries take precedence, also for HTTP.
+	require.Equal(t, uint32(5), *lingerTime(t, policy.ParserTypeKafka))
+	require.Equal(t, uint32(7), *lingerTime(t, policy.ParserTypeHTTP))
+
+	// Without an entry, HTTP falls back to the global HTTP linger
+	// configuration and other parser types leave linger disabled.
+	s.config.lingerConfigByParser = nil
+	require.Equal(t, uint32(10), *lingerTime(t, policy.ParserTypeHTTP))
+	require.Nil(t, lingerTime(t, policy.ParserTypeKafka))
+}
+
+func TestAddListenerFailFast(t *testing.T) {
+	s := testXdsServer(t)
+	s.initializeXdsConfigs()
+	s.listenerCount = make(map[string]uint)
+	s.listenerConfs = make(map[string]func() *envoy_config_listener.Listener)
+
+	// Envoy has not subscribed to any resource type on an xDS stream yet.
+	readiness := s.ProxyReadiness()
+	require.Len(t, readiness, len(s.resourceConfig))
+	for typeURL, ready := range readiness {
+		require.False(t, ready, typeURL)
+	}
+
+	// A fail-fast AddListener returns ErrProxyNotReady without touching the
+	// listener cache.
+	err := s.AddListener("fail-fast-listener", policy.ParserTypeHTTP, 10002, false, false, true, nil, nil)
+	require.ErrorIs(t, err, xds.ErrProxyNotReady)
+	msg, err := s.listenerCache.Lookup(ListenerTypeURL, "fail-fast-listener")
+	require.NoError(t, err)
+	require.Nil(t, msg)
+
+	// Without the fail-fast flag the listener is accepted into the cache, to
+	// be served once Envoy connects.
+	require.NoError(t, s.AddListener("fail-fast-listener", policy.ParserTypeHTTP, 10002, false, false, false, nil, nil))
+	msg, err = s.listenerCache.Lookup(ListenerTypeURL, "fail-fast-listener")
+	require.NoError(t, err)
+	require.NotNil(t, msg)
+}
+
+func TestTLSParametersTranslation(t *testing.T) {
+	tls := &policy.TLSContext{
+		TrustedCA:         "ca",
+		CertificateChain:  "cert",
+		PrivateKey:        "key",
+		Secret:            types.NamespacedName{Namespace: "default", Name: "tls-secret"},
+		MinimumTLSVersion: "1.2",
+		MaximumTLSVersion: "1.3",
+		CipherSuites:      []string{"ECDHE-ECDSA-AES128-GCM-SHA256", "ECDHE-RSA-AES128-GCM-SHA256"},
+	}
+
+	checkParameters := func(t *testing.T, envoyTLS *cilium.TLSContext) {
+		t.Helper()
+		require.Equal(t, "1.2", envoyTLS.MinTlsVersion)
+		require.Equal(t, "1.3", envoyTLS.MaxTlsVersion)
+		require.Equal(t, tls.CipherSuites, envoyTLS.CipherSuites)
+	}
+
+	// SDS path: the parameters must accompany the secret reference.
+	envoyTLS := toEnvoyOriginatingTLSContext(tls, "cilium-secrets", true, false)
+	require.Equal(t, "cilium-secrets/default-tls-secret", envoyTLS.ValidationContextSdsSecret)
+	checkParameters(t, envoyTLS)
+
+	envoyTLS = toEnvoyTerminatingTLSContext(tls, "cilium-secrets", true, false)
+	require.Equal(t, "cilium-secrets/default-tls-secret", envoyTLS.TlsSdsSecret)
+	checkParameters(t, envoyTLS)
+
+	// Inline path: the parameters must accompany the inline secret values.
+	inlineTLS := *tls
+	inlineTLS.FromFile = true
+
+	envoyTLS = toEnvoyOriginatingTLSContext(&inlineTLS, "cilium-secrets", true, false)
+	require.Equal(t, "ca", envoyTLS.TrustedCa)
+	checkParameters(t, envoyTLS)
+
+	envoyTLS = toEnvoyTerminatingTLSContext(&inlineTLS, "cilium-secrets", true, false)
+	require.Equal(t, "cert", envoyTLS.CertificateChain)
+	require.Equal(t, "key", envoyTLS.PrivateKey)
+	checkParameters(t, envoyTLS)
+
+	// Inline path with the full TLS context retained.
+	envoyTLS = toEnvoyOriginatingTLSContext(&inlineTLS, "cilium-secrets", true, true)
+	require.Equal(t, "ca", envoyTLS.TrustedCa)
+	require.Equal(t, "cert", envoyTLS.CertificateChain)
+	checkParameters(t, envoyTLS)
+}
+
+func TestAlpnChainSelection(t *testing.T) {
+	s := testXdsServer(t)
+	s.config.useAlpnChainSelection = true
+
+	listenerConf := s.getListenerConf("test-listener", policy.ParserTypeKafka, 8080, false, false)
+	require.NoError(t, listenerConf.Validate())
+
+	// The ALPN chain must come before the default TCP chains so that the
+	// ALPN match takes precedence over the plain "tls" match.
+	alpnChain := listenerConf.FilterChains[0]
+	require.Equal(t, []string{"h2", "http/1.1"}, alpnChain.FilterChainMatch.ApplicationProtocols)
+	require.Equal(t, "tls", alpnChain.FilterChainMatch.TransportProtocol)
+	require.Equal(t, "envoy.filters.network.http_connection_manager", alpnChain.Filters[1].Name)
+
+	// No two chains may carry the same match, or Envoy NACKs the listener.
+	for i, a := range listenerConf.FilterChains {
+		for _, b := range listenerConf.FilterChains[i+1:] {
+			require.False(t, proto.Equal(a.GetFilterChainMatch(), b.GetFilterChainMatch()),
+				"duplicate filter chain match: %v", a.GetFilterChainMatch())
+		}
+	}
+
+	// Without the opt-in, no ALPN chain is generated.
+	s.config.useAlpnChainSelection = false
+	listenerConf = s.getListenerConf("test-listener", policy.ParserTypeKafka, 8080, false, false)
+	require.NoError(t, listenerConf.Validate())
+	for _, chain := range listenerConf.FilterChains {
+		require.NotEqual(t, []string{"h2", "http/1.1"}, chain.FilterChainMatch.ApplicationProtocols)
+	}
+}
+
+func TestGetOriginalDstEgressCluster(t *testing.T) {
+	cluster := GetOriginalDstEgressCluster(OriginalDstEgressClusterName, false)
+	require.NoError(t, cluster.Validate())
+	require.Equal(t, OriginalDstEgressClusterName, cluster.Name)
+	require.Equal(t, envoy_config_cluster.Cluster_ORIGINAL_DST, cluster.GetType())
+	require.Equal(t, envoy_config_cluster.Cluster_CLUSTER_PROVIDED, cluster.LbPolicy)
+	// Header-based overrides are off unless explicitly enabled.
+	require.Nil(t, cluster.GetOriginalDstLbConfig())
+
+	cluster = GetOriginalDstEgressCluster("my-cluster", true)
+	require.NoError(t, cluster.Validate())
+	require.Equal(t, "my-cluster", cluster.Name)
+	require.True(t, cluster.GetOriginalDstLbConfig().GetUseHttpHeader())
+}
+
+func TestTLSConfigDefaults(t *testing.T) {
+	s := testXdsServer(t)
+	s.config.tlsMinVersion = "TLSv1_2"
+	s.config.tlsCipherSuites = []string{"ECDHE-ECDSA-AES128-GCM-SHA256"}
+
+	// The configured defaults apply when the policy leaves the parameters
+	// unset.
+	envoyTLS := s.applyTLSConfigDefaults(&cilium.TLSContext{})
+	require.Equal(t, "TLSv1_2", envoyTLS.MinTlsVersion)
+	require.Equal(t, []string{"ECDHE-ECDSA-AES128-GCM-SHA256"}, envoyTLS.CipherSuites)
+
+	// Parameters set by the policy take precedence over the defaults.
+	envoyTLS = s.applyTLSConfigDefaults(&cilium.TLSContext{
+		MinTlsVersion: "1.3",
+		CipherSuites:  []string{"ECDHE-RSA-AES256-GCM-SHA384"},
+	})
+	require.Equal(t, "1.3", envoyTLS.MinTlsVersion)
+	require.Equal(t, []string{"ECDHE-RSA-AES256-GCM-SHA384"}, envoyTLS.CipherSuites)
+}
+
+func TestFillInUseRemoteAddress(t *testing.T) {
+	s := testXdsServer(t)
+	newListener := func() *envoy_config_listener.Listener {
+		return &envoy_config_listener.Listener{
+			Name:         "test-listener",
+			FilterChains: []*envoy_config_listener.FilterChain{s.getHttpFilterChainProto("test-cluster", "", false, false)},
+		}
+	}
+	getHCM := func(l *envoy_config_listener.Listener) *envoy_config_http.HttpConnectionManager {
+		hcm := &envoy_config_http.HttpConnectionManager{}
+		require.NoError(t, l.FilterChains[0].Filters[1].GetTypedConfig().UnmarshalTo(hcm))
+		return hcm
+	}
+
+	// Without an override the listener configuration is kept as is, here the
+	// generated default of true.
+	l := newListener()
+	fillInUseRemoteAddress(l, nil)
+	require.True(t, getHCM(l).GetUseRemoteAddress().GetValue())
+
+	// An explicit override is applied to the connection manager.
+	override := false
+	l = newListener()
+	fillInUseRemoteAddress(l, &override)
+	hcm := getHCM(l)
+	require.NotNil(t, hcm.UseRemoteAddress)
+	require.False(t, hcm.GetUseRemoteAddress().GetValue())
+}
+
+func TestPolicyUpdateCoalescing(t *testing.T) {
+	s := testXdsServer(t)
+	xdsMetrics := xds.NewXDSMetric()
+	s.config.metrics = xdsMetrics
+	s.config.policyCoalesceWindow = 200 * time.Millisecond
+	s.initializeXdsConfigs()
+	s.localEndpointStore = newLocalEndpointStore()
+	s.secretManager = certificatemanager.NewMockSecretManagerInline()
+	s.coalescedPolicyUpdates = make(map[string]*coalescedPolicyUpdate)
+
+	// A burst of updates for the same endpoint within the window collapses
+	// into a single push.
+	for range 5 {
+		err, _ := s.UpdateNetworkPolicy(ep, L4Policy1, true, true, nil)
+		require.NoError(t, err)
+	}
+
+	// Nothing is pushed while the window is open.
+	res, err := s.networkPolicyCache.GetResources(NetworkPolicyTypeURL, 0, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), res.Version)
+
+	// Once the window closes, the whole burst results in one version bump.
+	require.Eventually(t, func() bool {
+		res, err := s.networkPolicyCache.GetResources(NetworkPolicyTypeURL, 0, "", nil)
+		return err == nil && res.Version == 2
+	}, 2*time.Second, 10*time.Millisecond)
+	require.Equal(t, float64(4), xdsMetrics.CoalescedUpdateCount.WithLabelValues(NetworkPolicyTypeURL).Get())
+
+	// An update waiting for an ACK bypasses the window and is pushed
+	// immediately.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	wg := completion.NewWaitGroup(ctx)
+	s.proxyListeners = 1
+	err, _ = s.UpdateNetworkPolicy(ep, L4Policy1RequiresV2, true, true, wg)
+	require.NoError(t, err)
+	res, err = s.networkPolicyCache.GetResources(NetworkPolicyTypeURL, 0, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), res.Version)
+}
+
+func TestGetNetworkPoliciesWithVersion(t *testing.T) {
+	s := testXdsServer(t)
+	s.initializeXdsConfigs()
+	s.localEndpointStore = newLocalEndpointStore()
+	s.secretManager = certificatemanager.NewMockSecretManagerInline()
+
+	policies, version, err := s.GetNetworkPoliciesWithVersion(nil)
+	require.NoError(t, err)
+	require.Empty(t, policies)
+	require.Equal(t, uint64(1), version)
+
+	err, _ = s.UpdateNetworkPolicy(ep, L4Policy1, true, true, nil)
+	require.NoError(t, err)
+
+	// The returned version reflects the cache version bump of the update.
+	policies, version, err = s.GetNetworkPoliciesWithVersion(nil)
+	require.NoError(t, err)
+	require.Contains(t, policies, ep.GetIPv4Address())
+	require.Equal(t, uint64(2), version)
+}
+
+// histogramSampleCount gathers the registry and returns the number of samples
+// recorded for the histogram with the given fully-qualified name and label
+// pairs.
+func histogramSampleCount(t *testing.T, registry *prometheus.Registry, name string, labelPairs ...string) uint64 {
+	t.Helper()
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := make(map[string]string, len(m.GetLabel()))
+			for _, lp := range m.GetLabel() {
+				labels[lp.GetName()] = lp.GetValue()
+			}
+			matches := true
+			for i := 0; i < len(labelPairs); i += 2 {
+				if labels[labelPairs[i]] != labelPairs[i+1] {
+					matches = false
+					break
+				}
+			}
+			if matches {
+				return m.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
+
+func TestUpdateDurationMetrics(t *testing.T) {
+	s := testXdsServer(t)
+	xdsMetrics := xds.NewXDSMetric()
+	s.config.metrics = xdsMetrics
+	s.initializeXdsConfigs()
+	s.localEndpointStore = newLocalEndpointStore()
+	s.secretManager = certificatemanager.NewMockSecretManagerInline()
+	s.coalescedPolicyUpdates = make(map[string]*coalescedPolicyUpdate)
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(
+		xdsMetrics.PolicyUpdateSyncDuration,
+		xdsMetrics.PolicyAckDuration,
+		xdsMetrics.ResourcesUpdateDuration,
+	)
+
+	// The synchronous portion of a policy update is recorded even when no
+	// ACK is waited for.
+	err, _ := s.UpdateNetworkPolicy(ep, L4Policy1, true, true, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), histogramSampleCount(t, registry, "cilium_xds_policy_update_sync_duration_seconds"))
+
+	// An update waiting for an ACK that never arrives is recorded as a
+	// timeout once the completions are cancelled.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	wg := completion.NewWaitGroup(ctx)
+	s.proxyListeners = 1
+	err, _ = s.UpdateNetworkPolicy(ep, L4Policy1RequiresV2, true, true, wg)
+	require.NoError(t, err)
+	require.Error(t, wg.Wait())
+	require.Equal(t, uint64(1), histogramSampleCount(t, registry, "cilium_xds_policy_ack_duration_seconds", "result", "timeout"))
+
+	// Upserting and updating resources records one sample each, labeled by
+	// operation and result.
+	require.NoError(t, s.UpsertEnvoyResources(context.Background(), Resources{}))
+	require.Equal(t, uint64(1), histogramSampleCount(t, registry, "cilium_xds_resources_update_duration_seconds", "operation", "upsert", "result", "acked"))
+	require.NoError(t, s.UpdateEnvoyResources(context.Background(), Resources{}, Resources{}))
+	require.Equal(t, uint64(1), histogramSampleCount(t, registry, "cilium_xds_resources_update_duration_seconds", "operation", "update", "result", "acked"))
+}
+
+// rotationRecordingSecretManager records the rotation callbacks registered
+// with OnSecretRotated and allows triggering them from tests.
+type rotationRecordingSecretManager struct {
+	certificatemanager.SecretManager
+	mutex     lock.Mutex
+	callbacks map[string][]func()
+}
+
+func (m *rotationRecordingSecretManager) OnSecretRotated(secretName string, callback func()) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.callbacks[secretName] = append(m.callbacks[secretName], callback)
+}
+
+func (m *rotationRecordingSecretManager) rotate(secretName string) {
+	m.mutex.Lock()
+	callbacks := slices.Clone(m.callbacks[secretName])
+	m.mutex.Unlock()
+	for _, callback := range callbacks {
+		callback()
+	}
+}
+
+func TestNetworkPolicySecretRotation(t *testing.T) {
+	s := testXdsServer(t)
+	s.initializeXdsConfigs()
+	sm := &rotationRecordingSecretManager{
+		SecretManager: certificatemanager.NewMockSecretManagerSDS(),
+		callbacks:     map[string][]func(){},
+	}
+	s.secretManager = sm
+	s.secretToPolicies = make(map[string][]uint64)
+	s.policyToSecrets = make(map[uint64][]string)
+	s.rotationWatchedSecrets = make(map[string]struct{})
+
+	networkPolicy := &cilium.NetworkPolicy{
+		EndpointIps: []string{IPv4Addr},
+		EndpointId:  42,
+		IngressPerPortPolicies: []*cilium.PortNetworkPolicy{{
+			Port:     443,
+			Protocol: envoy_config_core.SocketAddress_TCP,
+			Rules: []*cilium.PortNetworkPolicyRule{{
+				DownstreamTlsContext: &cilium.TLSContext{TlsSdsSecret: "cilium-secrets/default-term"},
+				UpstreamTlsContext:   &cilium.TLSContext{ValidationContextSdsSecret: "cilium-secrets/default-orig"},
+			}},
+		}},
+	}
+	require.Equal(t, []string{"cilium-secrets/default-orig", "cilium-secrets/default-term"},
+		networkPolicySDSSecrets(networkPolicy))
+
+	// Simulate the policy being pushed: recording the secret references
+	// registers one rotation callback per secret.
+	version, updated, _ := s.networkPolicyCache.Upsert(NetworkPolicyTypeURL, "42", networkPolicy)
+	require.True(t, updated)
+	s.mutex.Lock()
+	s.updateSecretReferencesLocked(42, networkPolicySDSSecrets(networkPolicy))
+	s.mutex.Unlock()
+	require.Len(t, sm.callbacks, 2)
+
+	// Rotating a referenced secret marks the policy as modified in a new
+	// version, forcing a re-push.
+	sm.rotate("cilium-secrets/default-term")
+	res, err := s.networkPolicyCache.GetResources(NetworkPolicyTypeURL, version, "", []string{"42"})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, version+1, res.Version)
+
+	// Re-pushing an unchanged policy does not duplicate the references or
+	// register further callbacks.
+	s.mutex.Lock()
+	s.updateSecretReferencesLocked(42, networkPolicySDSSecrets(networkPolicy))
+	s.mutex.Unlock()
+	require.Equal(t, []uint64{42}, s.secretToPolicies["cilium-secrets/default-term"])
+	require.Len(t, sm.callbacks["cilium-secrets/default-term"], 1)
+
+	// Once the policy is removed, rotation no longer touches the cache.
+	s.mutex.Lock()
+	s.updateSecretReferencesLocked(42, nil)
+	s.mutex.Unlock()
+	require.Empty(t, s.secretToPolicies)
+	require.Empty(t, s.policyToSecrets)
+
+	sm.rotate("cilium-secrets/default-term")
+	res, err = s.networkPolicyCache.GetResources(NetworkPolicyTypeURL, res.Version, "", []string{"42"})
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
+
+func TestHttpHeaderSizeLimits(t *testing.T) {
+	s := testXdsServer(t)
+	s.config.httpMaxRequestHeadersKb = 60
+	s.config.httpMaxResponseHeadersKb = 100
+
+	chain := s.getHttpFilterChainProto("test-cluster", "", false, false)
+	hcm := &envoy_config_http.HttpConnectionManager{}
+	require.NoError(t, chain.Filters[1].GetTypedConfig().UnmarshalTo(hcm))
+	require.Equal(t, uint32(60), hcm.GetMaxRequestHeadersKb().GetValue())
+	require.Equal(t, uint32(100), hcm.GetCommonHttpProtocolOptions().GetMaxResponseHeadersKb().GetValue())
+
+	// Zero leaves the Envoy defaults in place.
+	s.config.httpMaxRequestHeadersKb = 0
+	s.config.httpMaxResponseHeadersKb = 0
+	chain = s.getHttpFilterChainProto("test-cluster", "", false, false)
+	hcm = &envoy_config_http.HttpConnectionManager{}
+	require.NoError(t, chain.Filters[1].GetTypedConfig().UnmarshalTo(hcm))
+	require.Nil(t, hcm.GetMaxRequestHeadersKb())
+	require.Nil(t, hcm.GetCommonHttpProtocolOptions())
+}
+
+func TestHttpForwardTrailers(t *testing.T) {
+	// Trailers are dropped by default; httpForwardTrailers enables their
+	// forwarding for HTTP/1.1.
+	s := testXdsServer(t)
+	chain := s.getHttpFilterChainProto("test-cluster", "", false, false)
+	hcm := &envoy_config_http.HttpConnectionManager{}
+	require.NoError(t, chain.Filters[1].GetTypedConfig().UnmarshalTo(hcm))
+	require.Nil(t, hcm.GetHttpProtocolOptions())
+
+	s.config.httpForwardTrailers = true
+	chain = s.getHttpFilterChainProto("test-cluster", "", false, false)
+	hcm = &envoy_config_http.HttpConnectionManager{}
+	require.NoError(t, chain.Filters[1].GetTypedConfig().UnmarshalTo(hcm))
+	require.True(t, hcm.GetHttpProtocolOptions().GetEnableTrailers())
+}
+
+func TestHttpRequestMirrorPolicy(t *testing.T) {
+	s := testXdsServer(t)
+	s.config.mirrorClusterName = "shadow-cluster"
+	s.config.mirrorPercent = 50
+
+	chain := s.getHttpFilterChainProto("test-cluster", "", false, false)
+	hcm := &envoy_config_http.HttpConnectionManager{}
+	require.NoError(t, chain.Filters[1].GetTypedConfig().UnmarshalTo(hcm))
+	for _, route := range hcm.GetRouteConfig().GetVirtualHosts()[0].GetRoutes() {
+		policies := route.GetRoute().GetRequestMirrorPolicies()
+		require.Len(t, policies, 1)
+		require.Equal(t, "shadow-cluster", policies[0].GetCluster())
+		require.Equal(t, uint32(50), policies[0].GetRuntimeFraction().GetDefaultValue().GetNumerator())
+	}
+
+	// Percentages above 100 are capped.
+	s.config.mirrorPercent = 250
+	chain = s.getHttpFilterChainProto("test-cluster", "", false, false)
+	hcm = &envoy_config_http.HttpConnectionManager{}
+	require.NoError(t, chain.Filters[1].GetTypedConfig().UnmarshalTo(hcm))
+	policies := hcm.GetRouteConfig().GetVirtualHosts()[0].GetRoutes()[0].GetRoute().GetRequestMirrorPolicies()
+	require.Equal(t, uint32(100), policies[0].GetRuntimeFraction().GetDefaultValue().GetNumerator())
+
+	// The shadow cluster the policies point at is a valid cluster resource.
+	cluster := getMirrorClusterResource("shadow-cluster")
+	require.NoError(t, cluster.Validate())
+	require.Equal(t, "shadow-cluster", cluster.GetName())
+
+	// No mirroring without a configured cluster.
+	s.config.mirrorClusterName = ""
+	chain = s.getHttpFilterChainProto("test-cluster", "", false, false)
+	hcm = &envoy_config_http.HttpConnectionManager{}
+	require.NoError(t, chain.Filters[1].GetTypedConfig().UnmarshalTo(hcm))
+	require.Empty(t, hcm.GetRouteConfig().GetVirtualHosts()[0].GetRoutes()[0].GetRoute().GetRequestMirrorPolicies())
+}
+
+func TestHttpRetryBufferLimit(t *testing.T) {
+	s := testXdsServer(t)
+	s.config.httpRetryBufferLimitBytes = 65536
+
+	chain := s.getHttpFilterChainProto("test-cluster", "", false, false)
+	hcm := &envoy_config_http.HttpConnectionManager{}
+	require.NoError(t, chain.Filters[1].GetTypedConfig().UnmarshalTo(hcm))
+	for _, route := range hcm.GetRouteConfig().GetVirtualHosts()[0].GetRoutes() {
+		require.Equal(t, uint32(65536), route.GetPerRequestBufferLimitBytes().GetValue())
+
+		retryPolicy := route.GetRoute().GetRetryPolicy()
+		require.Equal(t, "envoy.retry_priorities.previous_priorities", retryPolicy.GetRetryPriority().GetName())
+		require.Equal(t, int64(3), retryPolicy.GetHostSelectionRetryMaxAttempts())
+	}
+
+	// Zero keeps the previous behavior: no buffering, retries replayed to
+	// any host.
+	s.config.httpRetryBufferLimitBytes = 0
+	chain = s.getHttpFilterChainProto("test-cluster", "", false, false)
+	hcm = &envoy_config_http.HttpConnectionManager{}
+	require.NoError(t, chain.Filters[1].GetTypedConfig().UnmarshalTo(hcm))
+	for _, route := range hcm.GetRouteConfig().GetVirtualHosts()[0].GetRoutes() {
+		require.Nil(t, route.GetPerRequestBufferLimitBytes())
+		require.Nil(t, route.GetRoute().GetRetryPolicy().GetRetryPriority())
+	}
+}
+
+func TestPerListenerStatPrefix(t *testing.T) {
+	s := testXdsServer(t)
+
+	hcmStatPrefix := func(t *testing.T, chain *envoy_config_listener.FilterChain) string {
+		t.Helper()
+		hcm := &envoy_config_http.HttpConnectionManager{}
+		require.NoError(t, chain.Filters[1].GetTypedConfig().UnmarshalTo(hcm))
+		return hcm.GetStatPrefix()
+	}
+	tcpStatPrefix := func(t *testing.T, chain *envoy_config_listener.FilterChain) string {
+		t.Helper()
+		tcpProxy := &envoy_config_tcp.TcpProxy{}
+		require.NoError(t, chain.Filters[1].GetTypedConfig().UnmarshalTo(tcpProxy))
+		return tcpProxy.GetStatPrefix()
+	}
+
+	// An empty stat prefix falls back to the shared defaults.
+	require.Equal(t, "proxy", hcmStatPrefix(t, s.getHttpFilterChainProto("test-cluster", "", false, false)))
+	require.Equal(t, "tcp_proxy", tcpStatPrefix(t, s.getTcpFilterChainProto("test-cluster", "", "", nil, false)))
+
+	// An explicit stat prefix namespaces the filter stats.
+	require.Equal(t, "my-listener", hcmStatPrefix(t, s.getHttpFilterChainProto("test-cluster", "my-listener", false, false)))
+	require.Equal(t, "my-listener", tcpStatPrefix(t, s.getTcpFilterChainProto("test-cluster", "my-listener", "", nil, false)))
+
+	// When configured, generated listeners use their name as the prefix.
+	s.config.useListenerStatPrefix = true
+	listenerConf := s.getListenerConf("test-listener", policy.ParserTypeHTTP, 8080, false, false)
+	require.NoError(t, listenerConf.Validate())
+	for _, chain := range listenerConf.FilterChains {
+		require.Equal(t, "test-listener", hcmStatPrefix(t, chain))
+	}
+
+	// Without the opt-in the shared prefixes are kept.
+	s.config.useListenerStatPrefix = false
+	listenerConf = s.getListenerConf("test-listener", policy.ParserTypeHTTP, 8080, false, false)
+	for _, chain := range listenerConf.FilterChains {
+		require.Equal(t, "proxy", hcmStatPrefix(t, chain))
+	}
+}
+
+func TestTcpProxyTunnelMode(t *testing.T) {
+	s := testXdsServer(t)
+
+	tcpProxyConfig := func(t *testing.T, chain *envoy_config_listener.FilterChain) *envoy_config_tcp.TcpProxy {
+		t.Helper()
+		tcpProxy := &envoy_config_tcp.TcpProxy{}
+		require.NoError(t, chain.Filters[1].GetTypedConfig().UnmarshalTo(tcpProxy))
+		return tcpProxy
+	}
+
+	// Without a tunnel mode the payload goes directly to the payload cluster.
+	tcpProxy := tcpProxyConfig(t, s.getTcpFilterChainProto("payload-cluster", "", "", nil, false))
+	require.Nil(t, tcpProxy.GetTunnelingConfig())
+	require.Equal(t, "payload-cluster", tcpProxy.GetCluster())
+
+	// pre_connect establishes the tunnel with an HTTP/1.1 CONNECT request.
+	s.config.tcpProxyTunnelMode = tcpTunnelModePreConnect
+	s.config.tcpProxyTunnelHost = "proxy.example.com:3128"
+	tcpProxy = tcpProxyConfig(t, s.getTcpFilterChainProto("payload-cluster", "", "", nil, false))
+	require.Equal(t, "proxy.example.com:3128", tcpProxy.GetTunnelingConfig().GetHostname())
+	require.False(t, tcpProxy.GetTunnelingConfig().GetUsePost())
+	require.Equal(t, "payload-cluster", tcpProxy.GetCluster())
+
+	// post_connect uses a POST request instead.
+	s.config.tcpProxyTunnelMode = tcpTunnelModePostConnect
+	tcpProxy = tcpProxyConfig(t, s.getTcpFilterChainProto("payload-cluster", "", "", nil, false))
+	require.True(t, tcpProxy.GetTunnelingConfig().GetUsePost())
+
+	// The tunnel endpoint cluster is configured independently from the
+	// payload cluster.
+	s.config.tcpProxyTunnelCluster = "tunnel-cluster"
+	chain := s.getTcpFilterChainProto("payload-cluster", "", "", nil, false)
+	require.NoError(t, chain.Validate())
+	tcpProxy = tcpProxyConfig(t, chain)
+	require.Equal(t, "tunnel-cluster", tcpProxy.GetCluster())
+}
+
+func TestTransactionalUpsert(t *testing.T) {
+	s := testXdsServer(t)
+	s.config.useTransactionalUpserts = true
+	s.initializeXdsConfigs()
+
+	resources := Resources{
+		Clusters: []*envoy_config_cluster.Cluster{{Name: "cluster-1"}, {Name: "cluster-2"}},
+		Routes:   []*envoy_config_route.RouteConfiguration{{Name: "route-1"}, {Name: "route-2"}},
+	}
+	require.NoError(t, s.UpsertEnvoyResources(context.Background(), resources))
+
+	// Each type's resources are committed with exactly one version bump, so
+	// Envoy observes them in a single DiscoveryResponse per type.
+	for _, tc := range []struct {
+		typeURL string
+		names   []string
+	}{
+		{ClusterTypeURL, []string{"cluster-1", "cluster-2"}},
+		{RouteTypeURL, []string{"route-1", "route-2"}},
+	} {
+		res, err := s.resourceConfig[tc.typeURL].Source.GetResources(tc.typeURL, 0, "", nil)
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), res.Version, "unexpected version for %s", tc.typeURL)
+		require.ElementsMatch(t, tc.names, res.ResourceNames)
+	}
+}
+
+func TestEnvoyResourcesDiff(t *testing.T) {
+	listener := func(name string, port uint32) *envoy_config_listener.Listener {
+		return &envoy_config_listener.Listener{
+			Name: name,
+			Address: &envoy_config_core.Address{
+				Address: &envoy_config_core.Address_SocketAddress{
+					SocketAddress: &envoy_config_core.SocketAddress{
+						PortSpecifier: &envoy_config_core.SocketAddress_PortValue{PortValue: port},
+					},
+				},
+			},
+		}
+	}
+	names := func(res Resources) (names []string) {
+		for _, l := range res.Listeners {
+			names = append(names, l.Name)
+		}
+		for _, r := range res.Routes {
+			names = append(names, r.Name)
+		}
+		for _, c := range res.Clusters {
+			names = append(names, c.Name)
+		}
+		for _, e := range res.Endpoints {
+			names = append(names, e.ClusterName)
+		}
+		for _, s := range res.Secrets {
+			names = append(names, s.Name)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name       string
+		old, new   Resources
+		wantAdd    []string
+		wantDelete []string
+	}{
+		{
+			name: "unchanged resources are upserted but not deleted",
+			old: Resources{
+				Listeners: []*envoy_config_listener.Listener{listener("listener-1", 8080)},
+				Clusters:  []*envoy_config_cluster.Cluster{{Name: "cluster-1"}},
+			},
+			new: Resources{
+				Listeners: []*envoy_config_listener.Listener{listener("listener-1", 8080)},
+				Clusters:  []*envoy_config_cluster.Cluster{{Name: "cluster-1"}},
+			},
+			wantAdd: []string{"listener-1", "cluster-1"},
+		},
+		{
+			name: "added resources only show up in toAdd",
+			old: Resources{
+				Listeners: []*envoy_config_listener.Listener{listener("listener-1", 8080)},
+			},
+			new: Resources{
+				Listeners: []*envoy_config_listener.Listener{listener("listener-1", 8080), listener("listener-2", 9090)},
+				Routes:    []*envoy_config_route.RouteConfiguration{{Name: "route-1"}},
+			},
+			wantAdd: []string{"listener-1", "listener-2", "route-1"},
+		},
+		{
+			name: "removed listener is deleted",
+			old: Resources{
+				Listeners: []*envoy_config_listener.Listener{listener("listener-1", 8080), listener("listener-2", 9090)},
+			},
+			new: Resources{
+				Listeners: []*envoy_config_listener.Listener{listener("listener-1", 8080)},
+			},
+			wantAdd:    []string{"listener-1"},
+			wantDelete: []string{"listener-2"},
+		},
+		{
+			name: "listener changing port is both deleted and upserted",
+			old: Resources{
+				Listeners: []*envoy_config_listener.Listener{listener("listener-1", 8080)},
+			},
+			new: Resources{
+				Listeners: []*envoy_config_listener.Listener{listener("listener-1", 8081)},
+			},
+			wantAdd:    []string{"listener-1"},
+			wantDelete: []string{"listener-1"},
+		},
+		{
+			name: "removed routes, clusters, endpoints and secrets are deleted",
+			old: Resources{
+				Routes:    []*envoy_config_route.RouteConfiguration{{Name: "route-1"}, {Name: "route-2"}},
+				Clusters:  []*envoy_config_cluster.Cluster{{Name: "cluster-1"}, {Name: "cluster-2"}},
+				Endpoints: []*envoy_config_endpoint.ClusterLoadAssignment{{ClusterName: "cluster-1"}, {ClusterName: "cluster-2"}},
+				Secrets:   []*envoy_config_tls.Secret{{Name: "secret-1"}, {Name: "secret-2"}},
+			},
+			new: Resources{
+				Routes:    []*envoy_config_route.RouteConfiguration{{Name: "route-1"}},
+				Clusters:  []*envoy_config_cluster.Cluster{{Name: "cluster-1"}},
+				Endpoints: []*envoy_config_endpoint.ClusterLoadAssignment{{ClusterName: "cluster-1"}},
+				Secrets:   []*envoy_config_tls.Secret{{Name: "secret-1"}},
+			},
+			wantAdd:    []string{"route-1", "cluster-1", "cluster-1", "secret-1"},
+			wantDelete: []string{"route-2", "cluster-2", "cluster-2", "secret-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toAdd, toDelete := EnvoyResourcesDiff(tt.old, tt.new)
+			require.Equal(t, tt.wantAdd, names(toAdd))
+			require.Equal(t, tt.wantDelete, names(toDelete))
+		})
+	}
+}
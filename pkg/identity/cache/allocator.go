@@ -98,6 +98,10 @@ type CachingIdentityAllocator struct {
 	// performed before failing. This is mainly introduced for testing purposes.
 	maxAllocAttempts int
 
+	// idSelectionStrategy determines how the underlying allocator picks
+	// candidate IDs from its local pool. Empty leaves the allocator default.
+	idSelectionStrategy idpool.SelectionStrategy
+
 	// timeout for identity allocation operations.
 	timeout time.Duration
 
@@ -109,6 +113,7 @@ type AllocatorConfig struct {
 	EnableOperatorManageCIDs bool
 	Timeout                  time.Duration
 	SyncInterval             time.Duration
+	IDSelectionStrategy      idpool.SelectionStrategy
 	maxAllocAttempts         int
 }
 
@@ -313,6 +318,9 @@ func (m *CachingIdentityAllocator) InitIdentityAllocator(client clientset.Interf
 		if m.maxAllocAttempts > 0 {
 			allocOptions = append(allocOptions, allocator.WithMaxAllocAttempts(m.maxAllocAttempts))
 		}
+		if m.idSelectionStrategy != "" {
+			allocOptions = append(allocOptions, allocator.WithIDSelectionStrategy(m.idSelectionStrategy))
+		}
 		a, err := allocator.NewAllocator(m.logger, &key.GlobalIdentity{}, backend, allocOptions...)
 		if err != nil {
 			logging.Fatal(m.logger, fmt.Sprintf("Unable to initialize IdentityAllocator with backend %s", option.Config.IdentityAllocationMode), logfields.Error, err)
@@ -320,6 +328,10 @@ func (m *CachingIdentityAllocator) InitIdentityAllocator(client clientset.Interf
 
 		m.IdentityAllocator = a
 		close(m.globalIdentityAllocatorInitialized)
+
+		if option.Config.Debug {
+			m.registerDebugHandler()
+		}
 	}(m.owner, m.events, minID, maxID)
 
 	return m.globalIdentityAllocatorInitialized
@@ -386,6 +398,7 @@ func NewCachingIdentityAllocator(logger *slog.Logger, owner IdentityAllocatorOwn
 		events:                             make(allocator.AllocatorEventChan, eventsQueueSize),
 		operatorIDManagement:               config.EnableOperatorManageCIDs,
 		maxAllocAttempts:                   config.maxAllocAttempts,
+		idSelectionStrategy:                config.IDSelectionStrategy,
 		timeout:                            config.Timeout,
 		syncInterval:                       config.SyncInterval,
 	}
@@ -449,6 +462,19 @@ func (m *CachingIdentityAllocator) WaitForInitialGlobalIdentities(ctx context.Co
 	return m.IdentityAllocator.WaitForInitialSync(ctx)
 }
 
+// CheckHealth verifies connectivity of the global identity allocator to its
+// backing store. It returns nil as long as the global allocator has not been
+// initialized, as local identity allocation does not depend on a backend.
+func (m *CachingIdentityAllocator) CheckHealth(ctx context.Context) error {
+	select {
+	case <-m.globalIdentityAllocatorInitialized:
+	default:
+		return nil
+	}
+
+	return m.IdentityAllocator.CheckHealth(ctx)
+}
+
 var ErrNonLocalIdentity = fmt.Errorf("labels would result in global identity")
 
 // AllocateLocalIdentity works the same as AllocateIdentity, but it guarantees that the allocated
@@ -567,6 +593,16 @@ func (m *CachingIdentityAllocator) AllocateIdentity(ctx context.Context, lbls la
 
 	idp, allocated, isNewLocally, err := m.IdentityAllocator.Allocate(ctx, &key.GlobalIdentity{LabelArray: lbls.LabelArray()})
 	if err != nil {
+		// Translate allocator-internal failures into errors meaningful to
+		// API consumers, keeping the chain intact for errors.Is matching.
+		switch {
+		case errors.Is(err, allocator.ErrIDSpaceExhausted):
+			return nil, false, fmt.Errorf("no more identities available for labels %s: %w", lbls, err)
+		case errors.Is(err, allocator.ErrKeyConflict):
+			return nil, false, fmt.Errorf("identity for labels %s is being allocated concurrently, try again: %w", lbls, err)
+		case errors.Is(err, allocator.ErrBackendTimeout):
+			return nil, false, fmt.Errorf("timed out allocating identity for labels %s: %w", lbls, err)
+		}
 		return nil, false, err
 	}
 	if idp > identity.MaxNumericIdentity {
@@ -841,6 +877,11 @@ func (m *CachingIdentityAllocator) Release(ctx context.Context, id *identity.Ide
 	// remote nodes, so we can't rely on the locally computed
 	// "lastUse".
 	released, err = m.IdentityAllocator.Release(ctx, &key.GlobalIdentity{LabelArray: id.LabelArray})
+	if errors.Is(err, allocator.ErrNotAllocatedLocally) {
+		// The identity was already released; this cleanup path is
+		// intentionally idempotent.
+		return false, nil
+	}
 	if released {
 		for labelSource := range id.Labels.CollectSources() {
 			metrics.IdentityLabelSources.WithLabelValues(labelSource).Dec()
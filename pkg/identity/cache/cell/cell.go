@@ -5,10 +5,12 @@ package identitycachecell
 
 import (
 	"cmp"
+	"context"
 	"log/slog"
 	"net"
 
 	"github.com/cilium/hive/cell"
+	"github.com/cilium/hive/job"
 	"github.com/cilium/stream"
 	"github.com/spf13/pflag"
 
@@ -43,6 +45,10 @@ type CachingIdentityAllocator interface {
 
 	InitIdentityAllocator(versioned.Interface, kvstore.Client) <-chan struct{}
 
+	// CheckHealth verifies connectivity of the allocator to its backing
+	// store, returning an error when the backend does not respond.
+	CheckHealth(ctx context.Context) error
+
 	// RestoreLocalIdentities reads in the checkpointed local allocator state
 	// from disk and allocates a reference to every previously existing identity.
 	//
@@ -64,6 +70,7 @@ type identityAllocatorParams struct {
 
 	Log       *slog.Logger
 	Lifecycle cell.Lifecycle
+	JobGroup  job.Group
 	IDUpdater policycell.IdentityUpdater
 
 	IdentityHandlers []identity.UpdateIdentities `group:"identity-handlers"`
@@ -92,6 +99,10 @@ func (c config) Flags(flags *pflag.FlagSet) {
 	flags.Duration("identity-allocation-sync-interval", c.IdentityAllocationSyncInterval, "Periodic synchronization interval of the allocated identities")
 }
 
+// backendHealthCheckInterval is the interval in which the connectivity of the
+// allocator to its backing store is verified.
+const backendHealthCheckInterval = 30 * time.Second
+
 var defaultConfig = config{
 	IdentityManagementMode:         option.IdentityManagementModeAgent,
 	IdentityAllocationTimeout:      2 * time.Minute,
@@ -139,6 +150,11 @@ func newIdentityAllocator(params identityAllocatorParams) identityAllocatorOut {
 		},
 	})
 
+	// Periodically verify allocator backend connectivity, degrading the
+	// module health (and thereby the agent health reporting) when the
+	// backend does not respond.
+	params.JobGroup.Add(job.Timer("allocator-health-check", idAlloc.CheckHealth, backendHealthCheckInterval))
+
 	return identityAllocatorOut{
 		IdentityAllocator:      idAlloc,
 		CacheIdentityAllocator: idAlloc,
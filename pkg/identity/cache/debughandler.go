@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package cache
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/cilium/cilium/pkg/logging/logfields"
+)
+
+// remoteCachesDebugPath is the HTTP path under which a summary of the remote
+// cluster identity caches is exposed for debugging.
+const remoteCachesDebugPath = "/debug/identity/remote-caches"
+
+// snapshotDebugPath is the HTTP path under which a snapshot of the key↔ID
+// associations known to the identity allocator is exposed for debugging.
+const snapshotDebugPath = "/debug/identity/snapshot"
+
+// registerDebugHandler registers the identity debugging handlers on the
+// default HTTP mux. Only called when debug mode is enabled.
+func (m *CachingIdentityAllocator) registerDebugHandler() {
+	http.HandleFunc(remoteCachesDebugPath, m.remoteCachesHandler)
+	http.HandleFunc(snapshotDebugPath, m.snapshotHandler)
+}
+
+// remoteCachesHandler returns a JSON-marshaled summary of the state of all
+// registered remote identity caches, one entry per remote cluster.
+func (m *CachingIdentityAllocator) remoteCachesHandler(w http.ResponseWriter, r *http.Request) {
+	if m.IdentityAllocator == nil {
+		http.Error(w, "identity allocator not initialized", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(m.IdentityAllocator.DumpRemoteCaches()); err != nil {
+		m.logger.Warn("Failed to write remote cache summary response",
+			logfields.Error, err,
+		)
+	}
+}
+
+// snapshotHandler returns a JSON-marshaled snapshot of the key↔ID
+// associations known to the identity allocator, including the remote cluster
+// caches. Pass ?verbose=true to include the local reference counts.
+func (m *CachingIdentityAllocator) snapshotHandler(w http.ResponseWriter, r *http.Request) {
+	if m.IdentityAllocator == nil {
+		http.Error(w, "identity allocator not initialized", http.StatusServiceUnavailable)
+		return
+	}
+
+	verbose, _ := strconv.ParseBool(r.URL.Query().Get("verbose"))
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(m.IdentityAllocator.Snapshot(verbose)); err != nil {
+		m.logger.Warn("Failed to write identity snapshot response",
+			logfields.Error, err,
+		)
+	}
+}
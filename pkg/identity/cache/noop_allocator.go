@@ -35,6 +35,10 @@ func (n *NoopIdentityAllocator) WaitForInitialGlobalIdentities(context.Context)
 	return nil
 }
 
+func (n *NoopIdentityAllocator) CheckHealth(context.Context) error {
+	return nil
+}
+
 func (n *NoopIdentityAllocator) AllocateIdentity(ctx context.Context, lbls labels.Labels, notifyOwner bool, oldNID identity.NumericIdentity) (*identity.Identity, bool, error) {
 	n.logger.Debug(
 		"Assigning a fixed identity that is not based on labels, because network policies are disabled",
@@ -113,6 +117,10 @@ func (n *NoopRemoteIDCache) Synced() bool {
 	return true
 }
 
+func (n *NoopRemoteIDCache) Status() allocator.RemoteCacheStatus {
+	return allocator.RemoteCacheStatus{Synced: true}
+}
+
 func (n *NoopRemoteIDCache) Watch(ctx context.Context, onSync func(context.Context)) {
 	onSync(ctx)
 }
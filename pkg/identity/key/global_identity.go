@@ -35,6 +35,16 @@ func (gi *GlobalIdentity) GetKey() string {
 	return str.String()
 }
 
+// IsEqualTo reports whether other represents the same set of labels as gi.
+// The label arrays are compared directly, avoiding the string allocations
+// that comparing the GetKey representations would incur.
+func (gi *GlobalIdentity) IsEqualTo(other allocator.AllocatorKey) bool {
+	if o, ok := other.(*GlobalIdentity); ok {
+		return gi.LabelArray.Equals(o.LabelArray)
+	}
+	return other != nil && gi.GetKey() == other.GetKey()
+}
+
 // GetAsMap encodes a GlobalIdentity a map of keys to values. The keys will
 // include a source delimted by a ':'. This output is pareable by PutKeyFromMap.
 func (gi *GlobalIdentity) GetAsMap() map[string]string {
@@ -69,3 +69,37 @@ func TestGetCIDKeyFromLabels(t *testing.T) {
 		})
 	}
 }
+
+// BenchmarkKeyEquality compares looking up whether two identities carry the
+// same labels through IsEqualTo against comparing their encoded GetKey
+// representations, which allocates a string per operand on every comparison.
+func BenchmarkKeyEquality(b *testing.B) {
+	logger := hivetest.Logger(b)
+	labelsfilter.ParseLabelPrefixCfg(logger, nil, nil, "")
+
+	lbls := map[string]string{
+		"k8s:app":                         "foo",
+		"k8s:io.kubernetes.pod.namespace": "default",
+		"k8s:tier":                        "backend",
+	}
+	key := GetCIDKeyFromLabels(lbls, "")
+	other := GetCIDKeyFromLabels(lbls, "")
+
+	b.Run("IsEqualTo", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if !key.IsEqualTo(other) {
+				b.Fatal("keys expected to be equal")
+			}
+		}
+	})
+
+	b.Run("GetKey", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if key.GetKey() != other.GetKey() {
+				b.Fatal("keys expected to be equal")
+			}
+		}
+	})
+}
@@ -4,6 +4,7 @@
 package idpool
 
 import (
+	"math/rand/v2"
 	"strconv"
 
 	"github.com/cilium/cilium/pkg/lock"
@@ -15,6 +16,24 @@ type ID uint64
 // NoID is a special ID that represents "no ID available"
 const NoID ID = 0
 
+// SelectionStrategy determines which of the available IDs a pool hands out
+// next.
+type SelectionStrategy string
+
+const (
+	// SelectionSequential hands out available IDs in a deterministic
+	// order, ascending from the smallest ID on a freshly initialized pool.
+	// Pools initialized identically on several nodes propose the same
+	// candidate IDs in the same order.
+	SelectionSequential SelectionStrategy = "sequential"
+
+	// SelectionRandom hands out an ID picked uniformly at random from the
+	// set of available IDs, so that identically initialized pools on
+	// different nodes are unlikely to propose the same candidate ID at the
+	// same time.
+	SelectionRandom SelectionStrategy = "random-within-free-set"
+)
+
 // String returns the string representation of an allocated ID
 func (i ID) String() string {
 	return strconv.FormatUint(uint64(i), 10)
@@ -64,16 +83,23 @@ type IDPool struct {
 	idCache *idCache
 }
 
-// NewIDPool returns a new ID pool
+// NewIDPool returns a new ID pool using the sequential selection strategy
 func NewIDPool(minID ID, maxID ID) *IDPool {
+	return NewIDPoolWithStrategy(minID, maxID, SelectionSequential)
+}
+
+// NewIDPoolWithStrategy returns a new ID pool handing out available IDs
+// according to the given selection strategy
+func NewIDPoolWithStrategy(minID ID, maxID ID, strategy SelectionStrategy) *IDPool {
 	return &IDPool{
 		minID:   minID,
 		maxID:   maxID,
-		idCache: newIDCache(minID, maxID),
+		idCache: newIDCache(minID, maxID, strategy),
 	}
 }
 
-// LeaseAvailableID returns an available ID at random from the pool.
+// LeaseAvailableID returns an available ID from the pool, chosen according to
+// the pool's selection strategy.
 // Returns an ID or NoID if no there is no available ID in the pool.
 func (p *IDPool) LeaseAvailableID() ID {
 	p.mutex.Lock()
@@ -82,8 +108,9 @@ func (p *IDPool) LeaseAvailableID() ID {
 	return p.idCache.leaseAvailableID()
 }
 
-// AllocateID returns a random available ID. Unlike LeaseAvailableID, the ID is
-// immediately marked for use and there is no need to call Use().
+// AllocateID returns an available ID chosen according to the pool's selection
+// strategy. Unlike LeaseAvailableID, the ID is immediately marked for use and
+// there is no need to call Use().
 func (p *IDPool) AllocateID() ID {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
@@ -116,6 +143,20 @@ func (p *IDPool) Use(id ID) bool {
 	return p.idCache.use(id)
 }
 
+// UseRange makes every ID in the range [minID, maxID] unavailable in the
+// pool, acquiring the pool lock only once. Leased IDs in the range are
+// treated as if Use() was called on them, and available IDs as if Remove()
+// was called. This is intended for bulk warm-up after a restart, where
+// marking hundreds of restored IDs as used one by one would contend on the
+// pool lock. Returns the number of IDs made unavailable as a result of
+// this call.
+func (p *IDPool) UseRange(minID ID, maxID ID) int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.idCache.useRange(minID, maxID)
+}
+
 // Insert makes an unavailable ID available in the pool
 // and has no effect otherwise. Returns true if the ID
 // was added back to the pool.
@@ -126,6 +167,33 @@ func (p *IDPool) Insert(id ID) bool {
 	return p.idCache.insert(id)
 }
 
+// AvailableIDs returns the number of IDs currently available for
+// lease in the pool.
+func (p *IDPool) AvailableIDs() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return len(p.idCache.free)
+}
+
+// LeasedIDs returns the number of IDs currently leased from the pool but
+// neither used nor released yet.
+func (p *IDPool) LeasedIDs() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return len(p.idCache.leased)
+}
+
+// IsLeased returns whether the ID is currently leased from the pool.
+func (p *IDPool) IsLeased(id ID) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	_, ok := p.idCache.leased[id]
+	return ok
+}
+
 // Remove makes an ID unavailable in the pool.
 // Returns true if the ID was previously available in the pool.
 func (p *IDPool) Remove(id ID) bool {
@@ -136,39 +204,98 @@ func (p *IDPool) Remove(id ID) bool {
 }
 
 type idCache struct {
-	// ids is a slice of IDs available in this idCache.
-	ids map[ID]struct{}
+	// strategy determines which available ID is handed out next.
+	strategy SelectionStrategy
+
+	// minID and maxID delimit the range scanned by the sequential
+	// strategy.
+	minID, maxID ID
+
+	// next is the cursor of the sequential strategy: the first candidate
+	// considered for the next selection. It advances past every handed out
+	// ID and wraps around at maxID, so that IDs released back into the
+	// pool are not proposed again right away.
+	next ID
+
+	// free is the slice of IDs available in this idCache, in no
+	// particular order.
+	free []ID
+
+	// index is the position of each available ID in the free slice,
+	// allowing removal of an arbitrary ID in constant time.
+	index map[ID]int
 
 	// leased is the set of IDs that are leased in this idCache.
 	leased map[ID]struct{}
 }
 
-func newIDCache(minID ID, maxID ID) *idCache {
+func newIDCache(minID ID, maxID ID, strategy SelectionStrategy) *idCache {
 	n := max(int(maxID-minID+1), 0)
 
 	c := &idCache{
-		ids:    make(map[ID]struct{}, n),
-		leased: make(map[ID]struct{}),
+		strategy: strategy,
+		minID:    minID,
+		maxID:    maxID,
+		next:     minID,
+		free:     make([]ID, 0, n),
+		index:    make(map[ID]int, n),
+		leased:   make(map[ID]struct{}),
 	}
 
 	for id := minID; id < maxID+1; id++ {
-		c.ids[id] = struct{}{}
+		c.appendFree(id)
 	}
 
 	return c
 }
 
-// allocateID returns a random available ID without leasing it
+// appendFree adds id at the end of the free slice.
+func (c *idCache) appendFree(id ID) {
+	c.index[id] = len(c.free)
+	c.free = append(c.free, id)
+}
+
+// removeAt removes the available ID at position i in the free slice and
+// returns it. The last element takes over the freed position.
+func (c *idCache) removeAt(i int) ID {
+	id := c.free[i]
+	last := len(c.free) - 1
+	c.free[i] = c.free[last]
+	c.index[c.free[i]] = i
+	c.free = c.free[:last]
+	delete(c.index, id)
+	return id
+}
+
+// allocateID returns an available ID chosen according to the selection
+// strategy, without leasing it
 func (c *idCache) allocateID() ID {
-	for id := range c.ids {
-		delete(c.ids, id)
-		return id
+	if len(c.free) == 0 {
+		return NoID
+	}
+
+	if c.strategy == SelectionRandom {
+		return c.removeAt(rand.IntN(len(c.free)))
 	}
 
-	return NoID
+	for range c.maxID - c.minID + 1 {
+		id := c.next
+		if c.next == c.maxID {
+			c.next = c.minID
+		} else {
+			c.next++
+		}
+		if i, ok := c.index[id]; ok {
+			return c.removeAt(i)
+		}
+	}
+
+	// Only IDs inserted from outside the [minID, maxID] range are left.
+	return c.removeAt(len(c.free) - 1)
 }
 
-// leaseAvailableID returns a random available ID.
+// leaseAvailableID returns an available ID chosen according to the selection
+// strategy.
 func (c *idCache) leaseAvailableID() ID {
 	id := c.allocateID()
 	if id == NoID {
@@ -207,10 +334,30 @@ func (c *idCache) use(id ID) bool {
 	return true
 }
 
+// useRange makes all IDs in the range [minID, maxID] unavailable,
+// whether they are currently available or leased, and has no effect on
+// IDs that are already unavailable. Returns the number of IDs made
+// unavailable.
+func (c *idCache) useRange(minID ID, maxID ID) int {
+	n := 0
+	for id := minID; id <= maxID; id++ {
+		if _, exists := c.leased[id]; exists {
+			delete(c.leased, id)
+			n++
+			continue
+		}
+		if i, ok := c.index[id]; ok {
+			c.removeAt(i)
+			n++
+		}
+	}
+	return n
+}
+
 // insert adds the ID into the cache if it is currently unavailable.
 // Returns true if the ID was added to the cache.
 func (c *idCache) insert(id ID) bool {
-	if _, ok := c.ids[id]; ok {
+	if _, ok := c.index[id]; ok {
 		return false
 	}
 
@@ -218,7 +365,7 @@ func (c *idCache) insert(id ID) bool {
 		return false
 	}
 
-	c.ids[id] = struct{}{}
+	c.appendFree(id)
 	return true
 }
 
@@ -227,8 +374,8 @@ func (c *idCache) insert(id ID) bool {
 func (c *idCache) remove(id ID) bool {
 	delete(c.leased, id)
 
-	if _, ok := c.ids[id]; ok {
-		delete(c.ids, id)
+	if i, ok := c.index[id]; ok {
+		c.removeAt(i)
 		return true
 	}
 
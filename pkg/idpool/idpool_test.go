@@ -18,6 +18,23 @@ func TestLeaseAvailableID(t *testing.T) {
 	leaseAllIDs(p, minID, maxID, t)
 }
 
+func TestSelectionStrategies(t *testing.T) {
+	minID, maxID := 1, 100
+
+	// The sequential strategy hands out ascending IDs, in the same order
+	// on identically initialized pools.
+	p0 := NewIDPoolWithStrategy(ID(minID), ID(maxID), SelectionSequential)
+	p1 := NewIDPool(ID(minID), ID(maxID))
+	for i := minID; i <= maxID; i++ {
+		require.Equal(t, ID(i), p0.LeaseAvailableID())
+		require.Equal(t, ID(i), p1.LeaseAvailableID())
+	}
+
+	// The random strategy still hands out every ID exactly once.
+	p2 := NewIDPoolWithStrategy(ID(minID), ID(maxID), SelectionRandom)
+	leaseAllIDs(p2, minID, maxID, t)
+}
+
 func TestInsertIDs(t *testing.T) {
 	minID, maxID := 2, 6
 	p := NewIDPool(ID(minID), ID(maxID))
@@ -75,6 +92,36 @@ func TestInsertRemoveIDs(t *testing.T) {
 	require.ElementsMatch(t, evenIDs, actualIDs)
 }
 
+func TestUseRange(t *testing.T) {
+	minID, maxID := 1, 10
+	p := NewIDPool(ID(minID), ID(maxID))
+
+	// All IDs in the range are made unavailable in one call.
+	require.Equal(t, 5, p.UseRange(ID(3), ID(7)))
+	// A second call has no effect.
+	require.Equal(t, 0, p.UseRange(ID(3), ID(7)))
+
+	// Only the IDs outside the used range are still leasable.
+	actualIDs := make([]int, 0)
+	for range 5 {
+		id := p.LeaseAvailableID()
+		require.NotEqual(t, NoID, id)
+		actualIDs = append(actualIDs, int(id))
+	}
+	require.Equal(t, NoID, p.LeaseAvailableID())
+	require.ElementsMatch(t, []int{1, 2, 8, 9, 10}, actualIDs)
+
+	// Leased IDs in the range are made unavailable as well.
+	p = NewIDPool(ID(minID), ID(maxID))
+	leasedID := p.LeaseAvailableID()
+	require.NotEqual(t, NoID, leasedID)
+	require.Equal(t, maxID-minID+1, p.UseRange(ID(minID), ID(maxID)))
+	// The ID is no longer leased, so releasing it has no effect and it can
+	// be inserted back into the pool.
+	require.False(t, p.Release(leasedID))
+	require.True(t, p.Insert(leasedID))
+}
+
 func TestReleaseID(t *testing.T) {
 	minID, maxID := 1, 5
 	p := NewIDPool(ID(minID), ID(maxID))
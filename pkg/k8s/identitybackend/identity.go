@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strconv"
 	"sync/atomic"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
@@ -160,8 +161,14 @@ func (c *crdBackend) RunLocksGC(_ context.Context, _ map[string]kvstore.Value) (
 	return nil, nil
 }
 
-func (c *crdBackend) RunGC(context.Context, *rate.Limiter, map[string]uint64, idpool.ID, idpool.ID) (map[string]uint64, *allocator.GCStats, error) {
-	return nil, nil, nil
+func (c *crdBackend) RunGC(context.Context, *rate.Limiter, map[string]uint64, time.Duration, bool, idpool.ID, idpool.ID, string) (map[string]uint64, string, []allocator.GCCandidate, *allocator.GCStats, error) {
+	return nil, "", nil, nil, nil
+}
+
+// MigrateKeys is a no-op for the CRD backend, which does not store
+// versionable kvstore keys.
+func (c *crdBackend) MigrateKeys(context.Context, uint8, uint8) error {
+	return nil
 }
 
 // UpdateKey refreshes the reference that this node is using this key->ID
@@ -328,6 +335,23 @@ func (c *crdBackend) GetByID(ctx context.Context, id idpool.ID) (allocator.Alloc
 	return c.KeyFunc(identity.SecurityLabels), nil
 }
 
+// GetByIDBatch returns the keys associated with the given IDs. The CRD
+// backend resolves IDs from the local identity store, so the batch lookup
+// does not cause any apiserver round-trips.
+func (c *crdBackend) GetByIDBatch(ctx context.Context, ids []idpool.ID) (map[idpool.ID]allocator.AllocatorKey, error) {
+	keys := make(map[idpool.ID]allocator.AllocatorKey, len(ids))
+	for _, id := range ids {
+		key, err := c.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if key != nil {
+			keys[id] = key
+		}
+	}
+	return keys, nil
+}
+
 // Release dissociates this node from using the identity bound to the given ID.
 // When an identity has no references it may be garbage collected.
 func (c *crdBackend) Release(ctx context.Context, id idpool.ID, key allocator.AllocatorKey) (err error) {
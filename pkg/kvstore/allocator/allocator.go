@@ -10,6 +10,7 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cilium/cilium/pkg/allocator"
 	"github.com/cilium/cilium/pkg/idpool"
@@ -70,6 +71,30 @@ type kvstoreBackend struct {
 	backend kvstore.BackendOperations
 
 	keyType allocator.AllocatorKey
+
+	// firstSeen tracks when RunGC first observed each master key at its
+	// current ModRevision. The kvstore does not expose creation timestamps,
+	// so the key age enforced by the GC grace period is measured from the
+	// first time a given revision of the key was listed. Only accessed from
+	// RunGC, which is invoked from a single GC loop.
+	firstSeen map[string]firstSeenAt
+
+	// gcSeen accumulates the master keys listed by the pages of the current
+	// GC round, so that firstSeen entries of keys which no longer exist can
+	// be pruned once the round completes. Only accessed from RunGC.
+	gcSeen map[string]struct{}
+}
+
+// runGCPageSize bounds the number of master keys listed and processed per
+// RunGC call, to keep the individual range requests against the kvstore small
+// even when the identity space is very large.
+const runGCPageSize = 1000
+
+// firstSeenAt records when a given revision of a master key was first listed
+// by RunGC.
+type firstSeenAt struct {
+	modRevision uint64
+	at          time.Time
 }
 
 func prefixMatchesKey(prefix, key string) bool {
@@ -101,6 +126,7 @@ func NewKVStoreBackend(logger *slog.Logger, c KVStoreBackendConfiguration) (allo
 		suffix:      c.Suffix,
 		keyType:     c.Typ,
 		backend:     c.Backend,
+		firstSeen:   map[string]firstSeenAt{},
 	}, nil
 }
 
@@ -270,6 +296,24 @@ func (k *kvstoreBackend) GetByID(ctx context.Context, id idpool.ID) (allocator.A
 	return k.keyType.PutKey(string(v)), nil
 }
 
+// GetByIDBatch returns the keys associated with the given IDs. The ID prefix
+// is listed in a single range scan and the requested IDs are picked out of
+// the result, instead of issuing one kvstore round-trip per ID.
+func (k *kvstoreBackend) GetByIDBatch(ctx context.Context, ids []idpool.ID) (map[idpool.ID]allocator.AllocatorKey, error) {
+	pairs, err := k.backend.ListPrefix(ctx, k.idPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[idpool.ID]allocator.AllocatorKey, len(ids))
+	for _, id := range ids {
+		if v, ok := pairs[path.Join(k.idPrefix, id.String())]; ok {
+			keys[id] = k.keyType.PutKey(string(v.Data))
+		}
+	}
+	return keys, nil
+}
+
 // UpdateKey refreshes the record that this node is using this key -> id
 // mapping. When reliablyMissing is set it will also recreate missing master or
 // slave keys.
@@ -437,24 +481,49 @@ func (k *kvstoreBackend) RunLocksGC(ctx context.Context, staleKeysPrevRound map[
 	return staleKeys, nil
 }
 
-// RunGC scans the kvstore for unused master keys and removes them
+// RunGC scans one page of the kvstore for unused master keys and removes them
 func (k *kvstoreBackend) RunGC(
 	ctx context.Context,
 	rateLimit *rate.Limiter,
 	staleKeysPrevRound map[string]uint64,
+	minKeyAge time.Duration,
+	dryRun bool,
 	minID, maxID idpool.ID,
-) (map[string]uint64, *allocator.GCStats, error) {
+	continuation string,
+) (map[string]uint64, string, []allocator.GCCandidate, *allocator.GCStats, error) {
 
-	// fetch list of all /id/ keys
-	allocated, err := k.backend.ListPrefix(ctx, k.idPrefix)
+	// fetch one page of /id/ keys, resuming where the previous call left off
+	allocated, resumeKey, err := k.backend.ListPrefixPaged(ctx, k.idPrefix, continuation, runGCPageSize)
 	if err != nil {
-		return nil, nil, fmt.Errorf("list failed: %w", err)
+		return nil, "", nil, nil, fmt.Errorf("list failed: %w", err)
+	}
+
+	now := time.Now()
+
+	if continuation == "" || k.gcSeen == nil {
+		k.gcSeen = make(map[string]struct{}, len(allocated))
+	}
+	for key := range allocated {
+		k.gcSeen[key] = struct{}{}
+	}
+	if resumeKey == "" {
+		// The round is complete: forget keys that no longer exist, so that
+		// the first-seen map does not grow without bound and a reallocated
+		// key starts with a fresh timestamp.
+		for key := range k.firstSeen {
+			if _, ok := k.gcSeen[key]; !ok {
+				delete(k.firstSeen, key)
+			}
+		}
+		k.gcSeen = nil
 	}
 
 	totalEntries := len(allocated)
 	deletedEntries := 0
+	skippedYoung := 0
 
 	staleKeys := map[string]uint64{}
+	var candidates []allocator.GCCandidate
 
 	min := uint64(minID)
 	max := uint64(maxID)
@@ -477,21 +546,44 @@ func (k *kvstoreBackend) RunGC(
 			continue
 		}
 
-		if identityID, err := strconv.ParseUint(items[len(items)-1], 10, 64); err != nil {
+		identityID, err := strconv.ParseUint(items[len(items)-1], 10, 64)
+		if err != nil {
 			k.logger.Warn(
 				"Parse identity failed, skipping",
 				logfields.Error, err,
 				logfields.Key, key,
 			)
 			continue
-		} else {
-			// We should not GC those identities that are out of our scope
-			if identityID < min || identityID > max {
+		}
+
+		// We should not GC those identities that are out of our scope
+		if identityID < min || identityID > max {
+			k.logger.Debug(
+				"Skipping this key",
+				logfields.Key, key,
+				logfields.Reason, reasonOutOfRange,
+			)
+			continue
+		}
+
+		if minKeyAge > 0 {
+			seen, ok := k.firstSeen[key]
+			if !ok || seen.modRevision != v.ModRevision {
+				// First sighting of this revision of the key, start
+				// counting its age from now.
+				seen = firstSeenAt{modRevision: v.ModRevision, at: now}
+				k.firstSeen[key] = seen
+			}
+			if now.Sub(seen.at) < minKeyAge {
+				// A key this young may still be waiting for the slave key
+				// write of the allocating node, skip it regardless of its
+				// reference count.
 				k.logger.Debug(
 					"Skipping this key",
 					logfields.Key, key,
-					logfields.Reason, reasonOutOfRange,
+					logfields.Reason, "younger than the minimum key age",
 				)
+				skippedYoung++
 				continue
 			}
 		}
@@ -537,7 +629,22 @@ func (k *kvstoreBackend) RunGC(
 				// between GC calls. We should not mark it as stale keys yet,
 				// but the next GC call will do it.
 				if modRev == v.ModRevision {
-					if err := k.backend.DeleteIfLocked(ctx, key, lock); err != nil {
+					if dryRun {
+						// Report the key instead of deleting it. Keep it
+						// marked as stale so that the next run, dry or real,
+						// reaches the same conclusion.
+						candidates = append(candidates, allocator.GCCandidate{
+							ID:     idpool.ID(identityID),
+							Key:    key,
+							Reason: "unused since the previous garbage collection round",
+						})
+						staleKeys[key] = v.ModRevision
+						k.logger.Info(
+							"Would delete unused allocator master key in KVStore (dry run)",
+							logfields.Key, key,
+							logfields.Identity, path.Base(key),
+						)
+					} else if err := k.backend.DeleteIfLocked(ctx, key, lock); err != nil {
 						k.logger.Warn(
 							"Unable to delete unused allocator master key",
 							logfields.Error, err,
@@ -571,16 +678,17 @@ func (k *kvstoreBackend) RunGC(
 			// for a long period of time.
 			err = rateLimit.Wait(ctx)
 			if err != nil {
-				return nil, nil, err
+				return nil, "", nil, nil, err
 			}
 		}
 	}
 
 	gcStats := &allocator.GCStats{
-		Alive:   totalEntries - deletedEntries,
-		Deleted: deletedEntries,
+		Alive:        totalEntries - deletedEntries,
+		Deleted:      deletedEntries,
+		SkippedYoung: skippedYoung,
 	}
-	return staleKeys, gcStats, nil
+	return staleKeys, resumeKey, candidates, gcStats, nil
 }
 
 func (k *kvstoreBackend) keyToID(key string) (id idpool.ID, err error) {
@@ -622,6 +730,81 @@ func (k *kvstoreBackend) ListIDs(ctx context.Context) (identityIDs []idpool.ID,
 	return identityIDs, nil
 }
 
+// MigrateKeys rewrites all master key values and slave key paths carrying the
+// old key serialization version to carry the new one. Keys already carrying
+// the new version and keys carrying neither version are left untouched, so a
+// partially completed migration can simply be re-run. When migrating from the
+// unversioned format, keys carrying any version prefix are left untouched.
+func (k *kvstoreBackend) MigrateKeys(ctx context.Context, oldVersion, newVersion uint8) error {
+	oldPrefix := allocator.KeyVersionPrefix(oldVersion)
+	newPrefix := allocator.KeyVersionPrefix(newVersion)
+	if oldPrefix == newPrefix {
+		return nil
+	}
+
+	// Master keys store the canonical key representation as their value.
+	masterKeys, err := k.backend.ListPrefix(ctx, k.idPrefix)
+	if err != nil {
+		return fmt.Errorf("unable to list master keys: %w", err)
+	}
+	migratedMasters := 0
+	for keyPath, v := range masterKeys {
+		value := string(v.Data)
+		if newPrefix != "" && strings.HasPrefix(value, newPrefix) {
+			continue
+		}
+		if !strings.HasPrefix(value, oldPrefix) {
+			continue
+		}
+		// The unversioned encoding carries no prefix and thus matches any
+		// value. Do not touch keys carrying some other version prefix.
+		if oldPrefix == "" && allocator.HasKeyVersionPrefix(value) {
+			continue
+		}
+		newValue := newPrefix + strings.TrimPrefix(value, oldPrefix)
+		if err := k.backend.Update(ctx, keyPath, []byte(newValue), false); err != nil {
+			return fmt.Errorf("unable to migrate master key '%s': %w", keyPath, err)
+		}
+		migratedMasters++
+	}
+
+	// Slave keys carry the canonical key representation in their path, between
+	// the value prefix and the node suffix, so migrating one means creating
+	// the key under its new path and deleting the old one.
+	slaveKeys, err := k.backend.ListPrefix(ctx, k.valuePrefix)
+	if err != nil {
+		return fmt.Errorf("unable to list slave keys: %w", err)
+	}
+	migratedSlaves := 0
+	for keyPath, v := range slaveKeys {
+		suffix := strings.TrimPrefix(keyPath, k.valuePrefix+"/")
+		if newPrefix != "" && strings.HasPrefix(suffix, newPrefix) {
+			continue
+		}
+		if !strings.HasPrefix(suffix, oldPrefix) {
+			continue
+		}
+		// As for master keys, the unversioned encoding matches any suffix.
+		if oldPrefix == "" && allocator.HasKeyVersionPrefix(suffix) {
+			continue
+		}
+		newPath := path.Join(k.valuePrefix, newPrefix+strings.TrimPrefix(suffix, oldPrefix))
+		if err := k.backend.Update(ctx, newPath, v.Data, true); err != nil {
+			return fmt.Errorf("unable to create migrated slave key '%s': %w", newPath, err)
+		}
+		if err := k.backend.Delete(ctx, keyPath); err != nil {
+			return fmt.Errorf("unable to delete old slave key '%s': %w", keyPath, err)
+		}
+		migratedSlaves++
+	}
+
+	k.logger.Info(
+		"Migrated allocator keys to new key version",
+		logfields.Count, migratedMasters+migratedSlaves,
+	)
+	return nil
+}
+
 func (k *kvstoreBackend) ListAndWatch(ctx context.Context, handler allocator.CacheMutations) {
 	events := k.backend.ListAndWatch(ctx, k.idPrefix)
 	for event := range events {
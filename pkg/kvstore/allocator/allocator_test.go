@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/cilium/hive/hivetest"
+	"github.com/cilium/statedb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/util/rand"
@@ -40,6 +41,10 @@ var (
 type TestAllocatorKey string
 
 func (t TestAllocatorKey) GetKey() string { return string(t) }
+func (t TestAllocatorKey) IsEqualTo(other allocator.AllocatorKey) bool {
+	o, ok := other.(TestAllocatorKey)
+	return ok && t == o
+}
 func (t TestAllocatorKey) GetAsMap() map[string]string {
 	return map[string]string{string(t): string(t)}
 }
@@ -220,10 +225,10 @@ func BenchmarkGC(b *testing.B) {
 	rateLimiter := rate.NewLimiter(10*time.Second, 100)
 
 	keysToDelete := map[string]uint64{}
-	keysToDelete, _, err = allocator.RunGC(context.Background(), rateLimiter, keysToDelete)
+	keysToDelete, _, _, err = allocator.RunGC(context.Background(), rateLimiter, keysToDelete, 0, false)
 	require.NoError(b, err)
 	require.Len(b, keysToDelete, 1)
-	keysToDelete, _, err = allocator.RunGC(context.Background(), rateLimiter, keysToDelete)
+	keysToDelete, _, _, err = allocator.RunGC(context.Background(), rateLimiter, keysToDelete, 0, false)
 	require.NoError(b, err)
 	require.Empty(b, keysToDelete)
 
@@ -289,11 +294,11 @@ func BenchmarkGCShouldSkipOutOfRangeIdentities(b *testing.B) {
 	rateLimiter := rate.NewLimiter(10*time.Second, 100)
 
 	keysToDelete := map[string]uint64{}
-	keysToDelete, _, err = allocator1.RunGC(context.Background(), rateLimiter, keysToDelete)
+	keysToDelete, _, _, err = allocator1.RunGC(context.Background(), rateLimiter, keysToDelete, 0, false)
 	require.NoError(b, err)
 	// But, only one will be filtered out and GC'ed
 	require.Len(b, keysToDelete, 1)
-	keysToDelete, _, err = allocator1.RunGC(context.Background(), rateLimiter, keysToDelete)
+	keysToDelete, _, _, err = allocator1.RunGC(context.Background(), rateLimiter, keysToDelete, 0, false)
 	require.NoError(b, err)
 	require.Empty(b, keysToDelete)
 
@@ -377,7 +382,7 @@ func TestAllocateCached(t *testing.T) {
 	staleKeysPreviousRound := map[string]uint64{}
 	rateLimiter := rate.NewLimiter(10*time.Second, 100)
 	// running the GC should not evict any entries
-	staleKeysPreviousRound, _, err = a.RunGC(context.Background(), rateLimiter, staleKeysPreviousRound)
+	staleKeysPreviousRound, _, _, err = a.RunGC(context.Background(), rateLimiter, staleKeysPreviousRound, 0, false)
 	require.NoError(t, err)
 
 	v, err := client.ListPrefix(context.TODO(), path.Join(allocatorName, "id"))
@@ -391,9 +396,9 @@ func TestAllocateCached(t *testing.T) {
 	}
 
 	// running the GC should evict all entries
-	staleKeysPreviousRound, _, err = a.RunGC(context.Background(), rateLimiter, staleKeysPreviousRound)
+	staleKeysPreviousRound, _, _, err = a.RunGC(context.Background(), rateLimiter, staleKeysPreviousRound, 0, false)
 	require.NoError(t, err)
-	_, _, err = a.RunGC(context.Background(), rateLimiter, staleKeysPreviousRound)
+	_, _, _, err = a.RunGC(context.Background(), rateLimiter, staleKeysPreviousRound, 0, false)
 	require.NoError(t, err)
 
 	v, err = client.ListPrefix(context.TODO(), path.Join(allocatorName, "id"))
@@ -405,6 +410,192 @@ func TestAllocateCached(t *testing.T) {
 	a2.Delete()
 }
 
+// gcFakeBackend wraps the in-memory kvstore client with a no-op lock
+// implementation, as the in-memory client does not support LockPath.
+type gcFakeBackend struct {
+	kvstore.BackendOperations
+}
+
+func (b gcFakeBackend) LockPath(ctx context.Context, path string) (kvstore.KVLocker, error) {
+	return gcFakeLock{}, nil
+}
+
+type gcFakeLock struct{}
+
+func (gcFakeLock) Unlock(context.Context) error { return nil }
+func (gcFakeLock) Comparator() any              { return nil }
+
+func TestRunGCKeyMinAge(t *testing.T) {
+	client := gcFakeBackend{kvstore.NewInMemoryClient(statedb.New(), "__local__")}
+
+	allocatorName := randomTestName()
+	backend, err := NewKVStoreBackend(hivetest.Logger(t), KVStoreBackendConfiguration{allocatorName, "a", TestAllocatorKey(""), client})
+	require.NoError(t, err)
+	kb := backend.(*kvstoreBackend)
+
+	runGC := func(staleKeys map[string]uint64) (map[string]uint64, *allocator.GCStats) {
+		rateLimiter := rate.NewLimiter(10*time.Second, 100)
+		staleKeys, resumeKey, _, gcStats, err := backend.RunGC(context.Background(), rateLimiter, staleKeys, time.Hour, false, idpool.ID(1), idpool.ID(256), "")
+		require.NoError(t, err)
+		require.Empty(t, resumeKey)
+		return staleKeys, gcStats
+	}
+
+	// backdate makes the given master key appear older than the minimum age.
+	backdate := func(key string) {
+		seen := kb.firstSeen[key]
+		seen.at = seen.at.Add(-2 * time.Hour)
+		kb.firstSeen[key] = seen
+	}
+
+	// Create an unused master key, with no slave keys referencing it.
+	masterKey := path.Join(allocatorName, "id", "1")
+	require.NoError(t, client.Update(context.Background(), masterKey, []byte("foo"), false))
+
+	// A key younger than the minimum age is not even marked as stale.
+	staleKeys, gcStats := runGC(nil)
+	require.Empty(t, staleKeys)
+	require.Equal(t, 1, gcStats.SkippedYoung)
+	require.Equal(t, 1, gcStats.Alive)
+
+	// Once past the minimum age, the key is marked as stale as usual.
+	backdate(masterKey)
+	staleKeys, gcStats = runGC(staleKeys)
+	require.Len(t, staleKeys, 1)
+	require.Equal(t, 0, gcStats.SkippedYoung)
+
+	// Rewriting the master key bumps its ModRevision and restarts its age,
+	// protecting it again even though it was already marked as stale.
+	require.NoError(t, client.Update(context.Background(), masterKey, []byte("foo"), false))
+	staleKeys, gcStats = runGC(staleKeys)
+	require.Empty(t, staleKeys)
+	require.Equal(t, 1, gcStats.SkippedYoung)
+
+	// An old enough key goes through the usual two-round deletion.
+	backdate(masterKey)
+	staleKeys, _ = runGC(staleKeys)
+	require.Len(t, staleKeys, 1)
+	_, gcStats = runGC(staleKeys)
+	require.Equal(t, 1, gcStats.Deleted)
+
+	v, err := client.ListPrefix(context.Background(), path.Join(allocatorName, "id"))
+	require.NoError(t, err)
+	require.Empty(t, v)
+
+	// The first-seen entry of the deleted key is garbage collected in turn.
+	runGC(nil)
+	require.Empty(t, kb.firstSeen)
+}
+
+func TestRunGCDryRun(t *testing.T) {
+	client := gcFakeBackend{kvstore.NewInMemoryClient(statedb.New(), "__local__")}
+
+	allocatorName := randomTestName()
+	backend, err := NewKVStoreBackend(hivetest.Logger(t), KVStoreBackendConfiguration{allocatorName, "a", TestAllocatorKey(""), client})
+	require.NoError(t, err)
+
+	runGC := func(dryRun bool, staleKeys map[string]uint64) (map[string]uint64, []allocator.GCCandidate, *allocator.GCStats) {
+		rateLimiter := rate.NewLimiter(10*time.Second, 100)
+		staleKeys, resumeKey, candidates, gcStats, err := backend.RunGC(context.Background(), rateLimiter, staleKeys, 0, dryRun, idpool.ID(1), idpool.ID(256), "")
+		require.NoError(t, err)
+		require.Empty(t, resumeKey)
+		return staleKeys, candidates, gcStats
+	}
+
+	// Master key 1 is unused; master key 2 is still referenced by a slave key.
+	unusedKey := path.Join(allocatorName, "id", "1")
+	usedKey := path.Join(allocatorName, "id", "2")
+	require.NoError(t, client.Update(context.Background(), unusedKey, []byte("foo"), false))
+	require.NoError(t, client.Update(context.Background(), usedKey, []byte("bar"), false))
+	require.NoError(t, client.Update(context.Background(), path.Join(allocatorName, "value", "bar", "node1"), []byte("2"), false))
+
+	// The first round only marks the unused key as stale, dry run or not.
+	staleKeys, candidates, _ := runGC(true, nil)
+	require.Len(t, staleKeys, 1)
+	require.Contains(t, staleKeys, unusedKey)
+	require.Empty(t, candidates)
+
+	// The second dry round reports the unused key instead of deleting it.
+	dryStaleKeys, candidates, gcStats := runGC(true, staleKeys)
+	require.Len(t, candidates, 1)
+	require.Equal(t, idpool.ID(1), candidates[0].ID)
+	require.Equal(t, unusedKey, candidates[0].Key)
+	require.NotEmpty(t, candidates[0].Reason)
+	require.Equal(t, 0, gcStats.Deleted)
+
+	// Both master keys are still present.
+	v, err := client.ListPrefix(context.Background(), path.Join(allocatorName, "id"))
+	require.NoError(t, err)
+	require.Len(t, v, 2)
+
+	// The key stays marked as stale, so further rounds reach the same
+	// conclusion whether they are dry or real.
+	require.Equal(t, staleKeys, dryStaleKeys)
+
+	// A real run on the same state deletes exactly the reported candidate.
+	_, candidates, gcStats = runGC(false, dryStaleKeys)
+	require.Empty(t, candidates)
+	require.Equal(t, 1, gcStats.Deleted)
+
+	v, err = client.ListPrefix(context.Background(), path.Join(allocatorName, "id"))
+	require.NoError(t, err)
+	require.Len(t, v, 1)
+	require.Contains(t, v, usedKey)
+}
+
+func TestMigrateKeys(t *testing.T) {
+	client := kvstore.NewInMemoryClient(statedb.New(), "__local__")
+
+	allocatorName := randomTestName()
+	backend, err := NewKVStoreBackend(hivetest.Logger(t), KVStoreBackendConfiguration{allocatorName, "a", TestAllocatorKey(""), client})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// An unversioned master/slave key pair, one already carrying v2 and one
+	// carrying an unrelated version v3.
+	require.NoError(t, client.Update(ctx, path.Join(allocatorName, "id", "1"), []byte("foo"), false))
+	require.NoError(t, client.Update(ctx, path.Join(allocatorName, "value", "foo", "node1"), []byte("1"), false))
+	require.NoError(t, client.Update(ctx, path.Join(allocatorName, "id", "2"), []byte("v2/bar"), false))
+	require.NoError(t, client.Update(ctx, path.Join(allocatorName, "value", "v2/bar", "node1"), []byte("2"), false))
+	require.NoError(t, client.Update(ctx, path.Join(allocatorName, "id", "3"), []byte("v3/baz"), false))
+	require.NoError(t, client.Update(ctx, path.Join(allocatorName, "value", "v3/baz", "node1"), []byte("3"), false))
+
+	require.NoError(t, backend.MigrateKeys(ctx, 0, 2))
+
+	// The unversioned master key value and slave key path now carry the v2
+	// prefix; the already versioned pairs are untouched, including the one
+	// carrying a version other than the migration target.
+	masters, err := client.ListPrefix(ctx, path.Join(allocatorName, "id"))
+	require.NoError(t, err)
+	require.Len(t, masters, 3)
+	require.Equal(t, "v2/foo", string(masters[path.Join(allocatorName, "id", "1")].Data))
+	require.Equal(t, "v2/bar", string(masters[path.Join(allocatorName, "id", "2")].Data))
+	require.Equal(t, "v3/baz", string(masters[path.Join(allocatorName, "id", "3")].Data))
+
+	slaves, err := client.ListPrefix(ctx, path.Join(allocatorName, "value"))
+	require.NoError(t, err)
+	require.Len(t, slaves, 3)
+	require.Contains(t, slaves, path.Join(allocatorName, "value", "v2/foo", "node1"))
+	require.Contains(t, slaves, path.Join(allocatorName, "value", "v2/bar", "node1"))
+	require.Contains(t, slaves, path.Join(allocatorName, "value", "v3/baz", "node1"))
+
+	// Re-running the migration after a partial failure is a safe no-op.
+	require.NoError(t, backend.MigrateKeys(ctx, 0, 2))
+	slaves, err = client.ListPrefix(ctx, path.Join(allocatorName, "value"))
+	require.NoError(t, err)
+	require.Len(t, slaves, 3)
+
+	// Migrating to version 0 strips the prefix again, leaving the v3 pair
+	// alone.
+	require.NoError(t, backend.MigrateKeys(ctx, 2, 0))
+	masters, err = client.ListPrefix(ctx, path.Join(allocatorName, "id"))
+	require.NoError(t, err)
+	require.Equal(t, "foo", string(masters[path.Join(allocatorName, "id", "1")].Data))
+	require.Equal(t, "bar", string(masters[path.Join(allocatorName, "id", "2")].Data))
+	require.Equal(t, "v3/baz", string(masters[path.Join(allocatorName, "id", "3")].Data))
+}
+
 func TestKeyToID(t *testing.T) {
 	testutils.IntegrationTest(t)
 	client := kvstore.SetupDummyWithConfigOpts(t, "etcd", etcdOpts)
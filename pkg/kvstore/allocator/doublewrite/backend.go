@@ -7,6 +7,7 @@ import (
 	"context"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/cilium/cilium/pkg/allocator"
 	"github.com/cilium/cilium/pkg/idpool"
@@ -191,10 +192,18 @@ func (d *doubleWriteBackend) RunGC(
 	ctx context.Context,
 	rateLimit *rate.Limiter,
 	staleKeysPrevRound map[string]uint64,
+	minKeyAge time.Duration,
+	dryRun bool,
 	minID, maxID idpool.ID,
-) (map[string]uint64, *allocator.GCStats, error) {
+	continuation string,
+) (map[string]uint64, string, []allocator.GCCandidate, *allocator.GCStats, error) {
 	// This is a no-op for the CRD backend
-	return d.kvstoreBackend.RunGC(ctx, rateLimit, staleKeysPrevRound, minID, maxID)
+	return d.kvstoreBackend.RunGC(ctx, rateLimit, staleKeysPrevRound, minKeyAge, dryRun, minID, maxID, continuation)
+}
+
+func (d *doubleWriteBackend) MigrateKeys(ctx context.Context, oldVersion, newVersion uint8) error {
+	// This is a no-op for the CRD backend
+	return d.kvstoreBackend.MigrateKeys(ctx, oldVersion, newVersion)
 }
 
 func (d *doubleWriteBackend) UpdateKey(ctx context.Context, id idpool.ID, key allocator.AllocatorKey, reliablyMissing bool) error {
@@ -277,6 +286,13 @@ func (d *doubleWriteBackend) GetByID(ctx context.Context, id idpool.ID) (allocat
 	return d.crdBackend.GetByID(ctx, id)
 }
 
+func (d *doubleWriteBackend) GetByIDBatch(ctx context.Context, ids []idpool.ID) (map[idpool.ID]allocator.AllocatorKey, error) {
+	if d.readFromKVStore {
+		return d.kvstoreBackend.GetByIDBatch(ctx, ids)
+	}
+	return d.crdBackend.GetByIDBatch(ctx, ids)
+}
+
 func (d *doubleWriteBackend) Release(ctx context.Context, id idpool.ID, key allocator.AllocatorKey) (err error) {
 	kvStoreErr := d.kvstoreBackend.Release(ctx, id, key)
 	if kvStoreErr != nil {
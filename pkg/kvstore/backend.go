@@ -179,6 +179,12 @@ type BackendOperations interface {
 	// ListPrefixIfLocked returns a list of keys matching the prefix only if the client is still holding the given lock.
 	ListPrefixIfLocked(ctx context.Context, prefix string, lock KVLocker) (KeyValuePairs, error)
 
+	// ListPrefixPaged returns up to limit keys matching the prefix which sort
+	// strictly after fromKey, in key order. The returned resume key is the last
+	// key of the page if more keys remain, or empty once the listing is
+	// complete; passing it back as fromKey continues the listing.
+	ListPrefixPaged(ctx context.Context, prefix, fromKey string, limit int) (KeyValuePairs, string, error)
+
 	// Close closes the kvstore client
 	Close()
 
@@ -1557,6 +1557,61 @@ func (e *etcdClient) ListPrefix(ctx context.Context, prefix string) (v KeyValueP
 	return pairs, nil
 }
 
+// ListPrefixPaged returns up to limit keys matching the prefix which sort
+// strictly after fromKey, bounding the size of the individual range responses
+// when iterating over very large prefixes.
+func (e *etcdClient) ListPrefixPaged(ctx context.Context, prefix, fromKey string, limit int) (v KeyValuePairs, resumeKey string, err error) {
+	if traceEnabled {
+		defer func() {
+			Trace(e.logger, "ListPrefixPaged",
+				logfields.Error, err,
+				fieldPrefix, prefix,
+				fieldNumEntries, len(v),
+			)
+		}()
+	}
+	lr, err := e.limiter.Wait(ctx)
+	if err != nil {
+		return nil, "", Hint(err)
+	}
+	defer func(duration *spanstat.SpanStat) {
+		increaseMetric(prefix, metricRead, "ListPrefixPaged", duration.EndError(err).Total(), err)
+	}(spanstat.Start())
+
+	start := prefix
+	if fromKey != "" {
+		// Resume immediately after the last key of the previous page. "\x00"
+		// is the smallest possible suffix, so no key can sort in between.
+		start = fromKey + "\x00"
+	}
+	getR, err := e.client.Get(ctx, start,
+		client.WithRange(client.GetPrefixRangeEnd(prefix)),
+		client.WithSort(client.SortByKey, client.SortAscend),
+		client.WithLimit(int64(limit)),
+	)
+	if err != nil {
+		lr.Error(err, -1)
+		return nil, "", Hint(err)
+	}
+	lr.Done()
+
+	pairs := KeyValuePairs(make(map[string]Value, getR.Count))
+	for i := int64(0); i < getR.Count; i++ {
+		pairs[string(getR.Kvs[i].Key)] = Value{
+			Data:        getR.Kvs[i].Value,
+			ModRevision: uint64(getR.Kvs[i].ModRevision),
+			LeaseID:     getR.Kvs[i].Lease,
+		}
+
+	}
+
+	if getR.More && len(getR.Kvs) > 0 {
+		resumeKey = string(getR.Kvs[len(getR.Kvs)-1].Key)
+	}
+
+	return pairs, resumeKey, nil
+}
+
 // Close closes the etcd session
 func (e *etcdClient) Close() {
 	close(e.stopStatusChecker)
@@ -196,6 +196,27 @@ func (c *inMemoryClient) ListPrefixIfLocked(ctx context.Context, prefix string,
 	return c.ListPrefix(ctx, prefix)
 }
 
+// ListPrefixPaged implements BackendOperations.
+func (c *inMemoryClient) ListPrefixPaged(ctx context.Context, prefix, fromKey string, limit int) (KeyValuePairs, string, error) {
+	kvs := KeyValuePairs{}
+	lastKey := ""
+	for obj, rev := range c.table.Prefix(c.db.ReadTxn(), inMemoryKeyIndex.Query(prefix)) {
+		if fromKey != "" && obj.key <= fromKey {
+			continue
+		}
+		if limit > 0 && len(kvs) == limit {
+			return kvs, lastKey, nil
+		}
+		kvs[obj.key] = Value{
+			Data:        obj.value,
+			ModRevision: rev,
+			LeaseID:     0,
+		}
+		lastKey = obj.key
+	}
+	return kvs, "", nil
+}
+
 // LockPath implements BackendOperations.
 func (c *inMemoryClient) LockPath(ctx context.Context, path string) (KVLocker, error) {
 	panic("unimplemented")
@@ -338,6 +338,15 @@ var (
 	// from multiple sources and thus might be counted in multiple buckets
 	IdentityLabelSources = NoOpGaugeVec
 
+	// IdentityEventsDropped is the number of identity allocator events dropped
+	// because the event channel was full
+	IdentityEventsDropped = NoOpCounter
+
+	// IdentityDegradedAllocations is the number of identity allocations served
+	// from local state (warmup snapshot or allocator cache) while the
+	// allocator backend was unavailable
+	IdentityDegradedAllocations = NoOpCounter
+
 	// Events
 
 	// EventTS is the time in seconds since epoch that we last received an
@@ -636,6 +645,8 @@ type LegacyMetrics struct {
 	PolicyIncrementalUpdateDuration  metric.Vec[metric.Observer]
 	Identity                         metric.Vec[metric.Gauge]
 	IdentityLabelSources             metric.Vec[metric.Gauge]
+	IdentityEventsDropped            metric.Counter
+	IdentityDegradedAllocations      metric.Counter
 	EventTS                          metric.Vec[metric.Gauge]
 	EventLagK8s                      metric.Gauge
 	ProxyRedirects                   metric.Vec[metric.Gauge]
@@ -817,6 +828,22 @@ func NewLegacyMetrics() *LegacyMetrics {
 			Help:      "Number of identities which contain at least one label of the given label source",
 		}, []string{LabelSource}),
 
+		IdentityEventsDropped: metric.NewCounter(metric.CounterOpts{
+			ConfigName: Namespace + "_identity_events_dropped_total",
+
+			Namespace: Namespace,
+			Name:      "identity_events_dropped_total",
+			Help:      "Number of identity allocator events dropped because the event channel was full",
+		}),
+
+		IdentityDegradedAllocations: metric.NewCounter(metric.CounterOpts{
+			ConfigName: Namespace + "_identity_degraded_allocations_total",
+
+			Namespace: Namespace,
+			Name:      "identity_degraded_allocations_total",
+			Help:      "Number of identity allocations served from local state while the allocator backend was unavailable",
+		}),
+
 		EventTS: metric.NewGaugeVec(metric.GaugeOpts{
 			ConfigName: Namespace + "_event_ts",
 			Namespace:  Namespace,
@@ -1300,6 +1327,8 @@ func NewLegacyMetrics() *LegacyMetrics {
 	PolicyIncrementalUpdateDuration = lm.PolicyIncrementalUpdateDuration
 	Identity = lm.Identity
 	IdentityLabelSources = lm.IdentityLabelSources
+	IdentityEventsDropped = lm.IdentityEventsDropped
+	IdentityDegradedAllocations = lm.IdentityDegradedAllocations
 	EventTS = lm.EventTS
 	EventLagK8s = lm.EventLagK8s
 	ProxyRedirects = lm.ProxyRedirects
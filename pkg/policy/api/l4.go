@@ -128,6 +128,31 @@ type TLSContext struct {
 	//
 	// +kubebuilder:validation:Optional
 	PrivateKey string `json:"privateKey,omitempty"`
+
+	// MinimumTLSVersion is the minimum TLS protocol version the proxy
+	// negotiates for intercepted connections. If omitted, the proxy default
+	// is used.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum="1.0";"1.1";"1.2";"1.3"
+	MinimumTLSVersion string `json:"minimumTLSVersion,omitempty"`
+
+	// MaximumTLSVersion is the maximum TLS protocol version the proxy
+	// negotiates for intercepted connections. If omitted, the proxy default
+	// is used. Must not be lower than MinimumTLSVersion, if both are given.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum="1.0";"1.1";"1.2";"1.3"
+	MaximumTLSVersion string `json:"maximumTLSVersion,omitempty"`
+
+	// CipherSuites restricts the cipher suites the proxy offers for TLS 1.2
+	// and earlier on intercepted connections. Each entry must be a cipher
+	// suite supported by the proxy, e.g. "ECDHE-ECDSA-AES128-GCM-SHA256".
+	// TLS 1.3 cipher suites are not configurable. If omitted, the proxy
+	// default cipher suites are used.
+	//
+	// +kubebuilder:validation:Optional
+	CipherSuites []string `json:"cipherSuites,omitempty"`
 }
 
 // EnvoyConfig defines a reference to a CiliumEnvoyConfig or CiliumClusterwideEnvoyConfig
@@ -183,8 +208,12 @@ type Listener struct {
 //   - `*.cilium.io` matches exactly one subdomain of cilium at that level www.cilium.io and blog.cilium.io match, cilium.io and google.com do not.
 //   - `**.cilium.io` matches more than one subdomain of cilium, e.g. sub1.sub2.cilium.io and sub.cilium.io match, cilium.io do not.
 //
+// A name prefixed with "~" is instead interpreted as an RE2 regular expression,
+// matched against the whole server name presented in the TLS handshake, e.g.
+// `~.*\.internal\.corp`.
+//
 // +kubebuilder:validation:MaxLength=255
-// +kubebuilder:validation:Pattern=`^(\*?\*\.)?([-a-zA-Z0-9_]+\.?)+$`
+// +kubebuilder:validation:Pattern=`^(~.+|(\*?\*\.)?([-a-zA-Z0-9_]+\.?)+)$`
 // +kubebuilder:validation:OneOf
 type ServerName string
 
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/netip"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -30,6 +31,31 @@ var (
 	enableDefaultDenyDefault = true
 )
 
+// tlsVersions are the TLS protocol versions accepted in a TLSContext, in
+// increasing order of preference. These match the versions configurable in
+// the Envoy proxy.
+var tlsVersions = []string{"1.0", "1.1", "1.2", "1.3"}
+
+// supportedCipherSuites are the TLS 1.2 and earlier cipher suites that the
+// Envoy proxy (BoringSSL) supports configuring. TLS 1.3 cipher suites are
+// not configurable.
+var supportedCipherSuites = map[string]struct{}{
+	"ECDHE-ECDSA-AES128-GCM-SHA256": {},
+	"ECDHE-RSA-AES128-GCM-SHA256":   {},
+	"ECDHE-ECDSA-AES256-GCM-SHA384": {},
+	"ECDHE-RSA-AES256-GCM-SHA384":   {},
+	"ECDHE-ECDSA-CHACHA20-POLY1305": {},
+	"ECDHE-RSA-CHACHA20-POLY1305":   {},
+	"ECDHE-ECDSA-AES128-SHA":        {},
+	"ECDHE-RSA-AES128-SHA":          {},
+	"ECDHE-ECDSA-AES256-SHA":        {},
+	"ECDHE-RSA-AES256-SHA":          {},
+	"AES128-GCM-SHA256":             {},
+	"AES256-GCM-SHA384":             {},
+	"AES128-SHA":                    {},
+	"AES256-SHA":                    {},
+}
+
 // Sanitize validates and sanitizes a policy rule. Minor edits such as capitalization
 // of the protocol name are automatically fixed up.
 // As part of `EndpointSelector` sanitization we also convert the label keys to internal
@@ -557,6 +583,38 @@ func (pr *L7Rules) sanitize(ports []PortProtocol) error {
 	return nil
 }
 
+// sanitize validates the TLS parameters of a TLSContext against what the
+// Envoy proxy supports. The secret references are validated at policy
+// resolution time instead, when the secret contents are available.
+func (t *TLSContext) sanitize() error {
+	if t == nil {
+		return nil
+	}
+
+	minVersion := -1
+	if t.MinimumTLSVersion != "" {
+		minVersion = slices.Index(tlsVersions, t.MinimumTLSVersion)
+		if minVersion < 0 {
+			return fmt.Errorf("unsupported TLS version %q", t.MinimumTLSVersion)
+		}
+	}
+	if t.MaximumTLSVersion != "" {
+		maxVersion := slices.Index(tlsVersions, t.MaximumTLSVersion)
+		if maxVersion < 0 {
+			return fmt.Errorf("unsupported TLS version %q", t.MaximumTLSVersion)
+		}
+		if maxVersion < minVersion {
+			return fmt.Errorf("maximum TLS version %q is lower than minimum TLS version %q", t.MaximumTLSVersion, t.MinimumTLSVersion)
+		}
+	}
+	for _, cipher := range t.CipherSuites {
+		if _, ok := supportedCipherSuites[cipher]; !ok {
+			return fmt.Errorf("unsupported cipher suite %q", cipher)
+		}
+	}
+	return nil
+}
+
 // It is not allowed to configure an ingress listener, but we still
 // have some unit tests relying on this. So, allow overriding this check in the unit tests.
 var TestAllowIngressListener = false
@@ -573,6 +631,22 @@ func (pr *PortRule) sanitize(ingress bool) error {
 	if slices.Contains(pr.ServerNames, "") {
 		return errEmptyServerName
 	}
+	for _, sn := range pr.ServerNames {
+		// Server names prefixed with '~' are regular expressions. Reject
+		// patterns that would not compile on the proxy side already here.
+		if pattern, ok := strings.CutPrefix(string(sn), "~"); ok {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("invalid server name regex %q: %w", pattern, err)
+			}
+		}
+	}
+
+	if err := pr.TerminatingTLS.sanitize(); err != nil {
+		return fmt.Errorf("terminatingTLS: %w", err)
+	}
+	if err := pr.OriginatingTLS.sanitize(); err != nil {
+		return fmt.Errorf("originatingTLS: %w", err)
+	}
 
 	if len(pr.Ports) > maxPorts {
 		return fmt.Errorf("too many ports, the max is %d", maxPorts)
@@ -303,6 +303,48 @@ func TestL7RulesWithNonTCPProtocols(t *testing.T) {
 	err = invalidPortRule.Sanitize()
 	require.ErrorIs(t, err, errEmptyServerName)
 
+	// Rule is valid because '~' prefixed ServerNames are matched as regular
+	// expressions.
+	validPortRule = Rule{
+		EndpointSelector: WildcardEndpointSelector,
+		Egress: []EgressRule{
+			{
+				EgressCommonRule: EgressCommonRule{
+					ToEndpoints: []EndpointSelector{WildcardEndpointSelector},
+				},
+				ToPorts: []PortRule{{
+					Ports: []PortProtocol{
+						{Port: "443", Protocol: ProtoTCP},
+					},
+					ServerNames: []ServerName{`~.*\.internal\.corp`, "foo.bar.com"},
+				}},
+			},
+		},
+	}
+	err = validPortRule.Sanitize()
+	require.NoError(t, err)
+
+	// Rule is invalid because the regular expression does not compile.
+	invalidPortRule = Rule{
+		EndpointSelector: WildcardEndpointSelector,
+		Egress: []EgressRule{
+			{
+				EgressCommonRule: EgressCommonRule{
+					ToEndpoints: []EndpointSelector{WildcardEndpointSelector},
+				},
+				ToPorts: []PortRule{{
+					Ports: []PortProtocol{
+						{Port: "443", Protocol: ProtoTCP},
+					},
+					ServerNames: []ServerName{`~*.internal.corp`},
+				}},
+			},
+		},
+	}
+	err = invalidPortRule.Sanitize()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid server name regex")
+
 	//  Rule is invalid because ServerNames with L7 rules are not allowed without TLS termination.
 	invalidPortRule = Rule{
 		EndpointSelector: WildcardEndpointSelector,
@@ -494,6 +536,69 @@ func TestL7RulesWithNonTCPProtocols(t *testing.T) {
 	require.Equal(t, "Listener is not allowed with L7 rules (myCustomListener)", err.Error())
 }
 
+// This test ensures that the TLS parameters of a TLSContext are validated
+// against what the Envoy proxy supports.
+func TestTLSContextSanitize(t *testing.T) {
+	mkRule := func(tls *TLSContext) Rule {
+		return Rule{
+			EndpointSelector: WildcardEndpointSelector,
+			Egress: []EgressRule{
+				{
+					EgressCommonRule: EgressCommonRule{
+						ToEndpoints: []EndpointSelector{WildcardEndpointSelector},
+					},
+					ToPorts: []PortRule{{
+						Ports: []PortProtocol{
+							{Port: "443", Protocol: ProtoTCP},
+						},
+						OriginatingTLS: tls,
+					}},
+				},
+			},
+		}
+	}
+
+	// Valid: supported version bounds and cipher suites.
+	validRule := mkRule(&TLSContext{
+		Secret:            &Secret{Name: "test-secret"},
+		MinimumTLSVersion: "1.2",
+		MaximumTLSVersion: "1.3",
+		CipherSuites: []string{
+			"ECDHE-ECDSA-AES128-GCM-SHA256",
+			"ECDHE-RSA-AES128-GCM-SHA256",
+		},
+	})
+	require.NoError(t, validRule.Sanitize())
+
+	// Invalid: unknown TLS version.
+	invalidRule := mkRule(&TLSContext{
+		Secret:            &Secret{Name: "test-secret"},
+		MinimumTLSVersion: "1.4",
+	})
+	err := invalidRule.Sanitize()
+	require.Error(t, err)
+	require.ErrorContains(t, err, `unsupported TLS version "1.4"`)
+
+	// Invalid: maximum version lower than minimum version.
+	invalidRule = mkRule(&TLSContext{
+		Secret:            &Secret{Name: "test-secret"},
+		MinimumTLSVersion: "1.3",
+		MaximumTLSVersion: "1.2",
+	})
+	err = invalidRule.Sanitize()
+	require.Error(t, err)
+	require.ErrorContains(t, err, `maximum TLS version "1.2" is lower than minimum TLS version "1.3"`)
+
+	// Invalid: cipher suite not supported by the proxy.
+	invalidRule = mkRule(&TLSContext{
+		Secret:       &Secret{Name: "test-secret"},
+		CipherSuites: []string{"TLS_RSA_WITH_NULL_MD5"},
+	})
+	err = invalidRule.Sanitize()
+	require.Error(t, err)
+	require.ErrorContains(t, err, `unsupported cipher suite "TLS_RSA_WITH_NULL_MD5"`)
+}
+
 // This test ensures that L7 rules reject unspecified ports.
 func TestL7RuleRejectsEmptyPort(t *testing.T) {
 	invalidL7PortRule := Rule{
@@ -1144,6 +1144,11 @@ func (in *TLSContext) DeepCopyInto(out *TLSContext) {
 		*out = new(Secret)
 		**out = **in
 	}
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
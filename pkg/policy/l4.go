@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log/slog"
 	"math/bits"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -51,11 +52,29 @@ type TLSContext struct {
 	// FromFile is true if the values in the keys above were read from the filesystem
 	// and not a Kubernetes Secret
 	FromFile bool
+	// MinimumTLSVersion and MaximumTLSVersion constrain the TLS protocol
+	// versions negotiated for intercepted connections, e.g. "1.2". Empty
+	// values leave the proxy defaults in place.
+	MinimumTLSVersion string `json:"minimumTLSVersion,omitempty"`
+	MaximumTLSVersion string `json:"maximumTLSVersion,omitempty"`
+	// CipherSuites restricts the TLS 1.2 and earlier cipher suites offered
+	// for intercepted connections. Empty leaves the proxy defaults in place.
+	CipherSuites []string `json:"cipherSuites,omitempty"`
 }
 
 // Equal returns true if 'a' and 'b' have the same contents.
 func (a *TLSContext) Equal(b *TLSContext) bool {
-	return a == nil && b == nil || a != nil && b != nil && *a == *b
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.TrustedCA == b.TrustedCA &&
+		a.CertificateChain == b.CertificateChain &&
+		a.PrivateKey == b.PrivateKey &&
+		a.Secret == b.Secret &&
+		a.FromFile == b.FromFile &&
+		a.MinimumTLSVersion == b.MinimumTLSVersion &&
+		a.MaximumTLSVersion == b.MaximumTLSVersion &&
+		slices.Equal(a.CipherSuites, b.CipherSuites)
 }
 
 // MarshalJSON marsahls a redacted version of the TLSContext. We want
@@ -73,6 +92,10 @@ func (t *TLSContext) MarshalJSON() ([]byte, error) {
 	if t.PrivateKey != "" {
 		redacted.PrivateKey = "[redacted]"
 	}
+	// The TLS parameters are not sensitive, keep them visible.
+	redacted.MinimumTLSVersion = t.MinimumTLSVersion
+	redacted.MaximumTLSVersion = t.MaximumTLSVersion
+	redacted.CipherSuites = t.CipherSuites
 	return json.Marshal(&redacted)
 }
 
@@ -930,11 +953,14 @@ func (l4 *L4Filter) getCerts(policyCtx PolicyContext, tls *api.TLSContext, direc
 	}
 
 	return &TLSContext{
-		TrustedCA:        ca,
-		CertificateChain: public,
-		PrivateKey:       private,
-		FromFile:         inlineSecrets,
-		Secret:           k8sTypes.NamespacedName(*tls.Secret),
+		TrustedCA:         ca,
+		CertificateChain:  public,
+		PrivateKey:        private,
+		FromFile:          inlineSecrets,
+		Secret:            k8sTypes.NamespacedName(*tls.Secret),
+		MinimumTLSVersion: tls.MinimumTLSVersion,
+		MaximumTLSVersion: tls.MaximumTLSVersion,
+		CipherSuites:      slices.Clone(tls.CipherSuites),
 	}, nil
 }
 
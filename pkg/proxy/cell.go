@@ -102,9 +102,10 @@ func newProxy(params proxyParams) *Proxy {
 type envoyProxyIntegrationParams struct {
 	cell.In
 
-	IptablesManager datapath.IptablesManager
-	XdsServer       envoy.XDSServer
-	AdminClient     *envoy.EnvoyAdminClient
+	IptablesManager  datapath.IptablesManager
+	XdsServer        envoy.XDSServer
+	AdminClient      *envoy.EnvoyAdminClient
+	EnvoyProxyConfig envoy.ProxyConfig
 }
 
 func newEnvoyProxyIntegration(params envoyProxyIntegrationParams) *envoyProxyIntegration {
@@ -113,9 +114,10 @@ func newEnvoyProxyIntegration(params envoyProxyIntegrationParams) *envoyProxyInt
 	}
 
 	return &envoyProxyIntegration{
-		xdsServer:       params.XdsServer,
-		iptablesManager: params.IptablesManager,
-		adminClient:     params.AdminClient,
+		xdsServer:         params.XdsServer,
+		iptablesManager:   params.IptablesManager,
+		adminClient:       params.AdminClient,
+		internalListeners: params.EnvoyProxyConfig.EnvoyInternalListeners,
 	}
 }
 
@@ -5,6 +5,7 @@ package endpoint
 
 import (
 	"github.com/cilium/cilium/pkg/container/versioned"
+	"github.com/cilium/cilium/pkg/labels"
 	"github.com/cilium/cilium/pkg/proxy/accesslog"
 	"github.com/cilium/cilium/pkg/u8proto"
 )
@@ -19,6 +20,25 @@ type EndpointInfoSource interface {
 	GetNamedPort(ingress bool, name string, proto u8proto.U8proto) uint16
 }
 
+// NetworkPolicyStats are the per-direction counts of the elements of the
+// network policy pushed to the proxy for an endpoint. They track the size of
+// the policy rather than its contents, as the element counts drive the
+// proxy's memory usage.
+type NetworkPolicyStats struct {
+	// PortPolicies is the number of per-port policies.
+	PortPolicies int64
+
+	// Rules is the total number of rules across all per-port policies.
+	Rules int64
+
+	// RemoteIdentities is the total number of remote identities referenced
+	// by the rules.
+	RemoteIdentities int64
+
+	// L7Rules is the total number of L7 rules across all rules.
+	L7Rules int64
+}
+
 // EndpointUpdater returns information about an endpoint being proxied and
 // is called back to update the endpoint when proxy events occur.
 // This is a subset of `Endpoint`.
@@ -29,6 +49,11 @@ type EndpointUpdater interface {
 	// has applied a policy.
 	OnProxyPolicyUpdate(policyRevision uint64)
 
+	// OnNetworkPolicyStatsUpdate is called with the per-direction counts of
+	// the network policy generated for the endpoint whenever it is pushed
+	// to the proxy.
+	OnNetworkPolicyStatsUpdate(ingress, egress NetworkPolicyStats)
+
 	// UpdateProxyStatistics updates the Endpoint's proxy statistics to account
 	// for a new observed flow with the given characteristics.
 	UpdateProxyStatistics(proxyType, l4Protocol string, port, proxyPort uint16, ingress, request bool, verdict accesslog.FlowVerdict)
@@ -41,4 +66,13 @@ type EndpointUpdater interface {
 	// GetListenerProxyPort returns the proxy port for the given listener reference.
 	// Returns zero if the proxy port does not exist (yet).
 	GetListenerProxyPort(listener string) uint16
+
+	// GetVisibilityPorts returns the ports in the given traffic direction
+	// that are redirected to the proxy for L7 visibility only, without
+	// policy enforcement. May be empty.
+	GetVisibilityPorts(ingress bool) []uint16
+
+	// GetIdentityLabels returns the sorted labels of the endpoint's security
+	// identity, or nil if the endpoint has no identity yet.
+	GetIdentityLabels() labels.LabelArray
 }
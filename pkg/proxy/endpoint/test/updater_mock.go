@@ -6,15 +6,23 @@ package test
 import (
 	"github.com/cilium/cilium/pkg/container/versioned"
 	"github.com/cilium/cilium/pkg/fqdn/restore"
+	"github.com/cilium/cilium/pkg/labels"
 	"github.com/cilium/cilium/pkg/proxy/accesslog"
+	"github.com/cilium/cilium/pkg/proxy/endpoint"
 	"github.com/cilium/cilium/pkg/u8proto"
 )
 
 type ProxyUpdaterMock struct {
-	Id            uint64
-	Ipv4          string
-	Ipv6          string
-	VersionHandle *versioned.VersionHandle
+	Id                     uint64
+	Ipv4                   string
+	Ipv6                   string
+	VersionHandle          *versioned.VersionHandle
+	IngressVisibilityPorts []uint16
+	EgressVisibilityPorts  []uint16
+	IdentityLabels         labels.LabelArray
+
+	IngressPolicyStats endpoint.NetworkPolicyStats
+	EgressPolicyStats  endpoint.NetworkPolicyStats
 }
 
 func (m *ProxyUpdaterMock) GetPolicyNames() []string {
@@ -39,6 +47,11 @@ func (m *ProxyUpdaterMock) GetNamedPort(bool, string, u8proto.U8proto) uint16 {
 
 func (m *ProxyUpdaterMock) OnProxyPolicyUpdate(policyRevision uint64) {}
 
+func (m *ProxyUpdaterMock) OnNetworkPolicyStatsUpdate(ingress, egress endpoint.NetworkPolicyStats) {
+	m.IngressPolicyStats = ingress
+	m.EgressPolicyStats = egress
+}
+
 func (m *ProxyUpdaterMock) UpdateProxyStatistics(proxyType, l4Protocol string, port, proxyPort uint16, ingress, request bool,
 	verdict accesslog.FlowVerdict) {
 }
@@ -52,3 +65,14 @@ func (m *ProxyUpdaterMock) GetPolicyVersionHandle() *versioned.VersionHandle {
 func (m *ProxyUpdaterMock) GetListenerProxyPort(listener string) uint16 {
 	return 0
 }
+
+func (m *ProxyUpdaterMock) GetVisibilityPorts(ingress bool) []uint16 {
+	if ingress {
+		return m.IngressVisibilityPorts
+	}
+	return m.EgressVisibilityPorts
+}
+
+func (m *ProxyUpdaterMock) GetIdentityLabels() labels.LabelArray {
+	return m.IdentityLabels
+}
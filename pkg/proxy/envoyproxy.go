@@ -33,6 +33,9 @@ type envoyProxyIntegration struct {
 	adminClient     *envoy.EnvoyAdminClient
 	xdsServer       envoy.XDSServer
 	iptablesManager datapath.IptablesManager
+	// internalListeners is true when redirect listeners are created as Envoy
+	// internal listeners that do not bind a TCP port (experimental).
+	internalListeners bool
 }
 
 // createRedirect creates a redirect with corresponding proxy configuration. This will launch a proxy instance.
@@ -51,12 +54,11 @@ func (p *envoyProxyIntegration) createRedirect(r Redirect, wg *completion.WaitGr
 		adminClient:  p.adminClient,
 	}
 
+	// Whether the original source address is actually used per direction is
+	// decided by the xDS server configuration; here we only report datapath
+	// support.
 	mayUseOriginalSourceAddr := p.iptablesManager.SupportsOriginalSourceAddr()
-	// Only use original source address for egress
-	if l.Ingress {
-		mayUseOriginalSourceAddr = false
-	}
-	err := p.xdsServer.AddListener(redirect.listenerName, policy.L7ParserType(l.ProxyType), l.ProxyPort, l.Ingress, mayUseOriginalSourceAddr, wg, cb)
+	err := p.xdsServer.AddListener(redirect.listenerName, policy.L7ParserType(l.ProxyType), l.ProxyPort, l.Ingress, mayUseOriginalSourceAddr, false, wg, cb)
 
 	return redirect, err
 }
@@ -5,11 +5,13 @@ package proxy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/cilium/cilium/api/v1/models"
 	"github.com/cilium/cilium/pkg/completion"
+	"github.com/cilium/cilium/pkg/envoy"
 	"github.com/cilium/cilium/pkg/identity"
 	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging/logfields"
@@ -155,6 +157,12 @@ func (p *Proxy) createNewRedirect(
 		return 0, proxyTypeNotFoundError(types.ProxyType(l4.GetL7Parser()), l4.GetListener(), l4.GetIngress()), nil
 	}
 
+	// Envoy internal listeners do not bind a TCP socket; the allocated
+	// number then only acts as a proxy identifier.
+	if pp.ProxyType != types.ProxyTypeDNS && p.envoyIntegration.internalListeners {
+		pp.Internal = true
+	}
+
 	redirect := initRedirect(p.logger, epID, ppName, pp, l4.GetPort(), l4.GetProtocol())
 
 	scopedLog := p.logger.With(
@@ -210,6 +218,13 @@ func (p *Proxy) createNewRedirect(
 		if err == nil {
 			break
 		}
+
+		// Listener validation failures are permanent; retrying with another
+		// proxy port can never succeed.
+		var validationErr *envoy.ErrListenerValidation
+		if errors.As(err, &validationErr) {
+			break
+		}
 	}
 
 	if err != nil {
@@ -355,6 +370,9 @@ func (p *Proxy) GetStatusModel() *models.ProxyStatus {
 	if option.Config.ExternalEnvoyProxy {
 		result.EnvoyDeploymentMode = "external"
 	}
+	if p.envoyIntegration != nil && p.envoyIntegration.xdsServer != nil {
+		result.EnvoyResourceReadiness = p.envoyIntegration.xdsServer.ProxyReadiness()
+	}
 	return result
 }
 
@@ -51,6 +51,10 @@ type ProxyPort struct {
 	Ingress bool `json:"ingress"`
 	// ProxyPort is the desired proxy listening port number.
 	ProxyPort uint16 `json:"port"`
+	// Internal is true when the proxy listener is an Envoy internal
+	// listener that does not bind a TCP socket. The port number then only
+	// acts as a proxy identifier and does not occupy a local port.
+	Internal bool `json:"internal,omitempty"`
 	// isStatic is true when the listener on the proxy port is incapable
 	// of stopping and/or being reconfigured with a new proxy port once it has been
 	// first started. Set 'true' by SetProxyPort(), which is only called for
@@ -200,9 +204,14 @@ func (p *ProxyPorts) isPortAvailable(openLocalPorts map[uint16]struct{}, port ui
 // allocatePort checks to see if the given 'port' is available and allocates a new random
 // proxy port if not.
 // Returns a non-zero allocated port if successful, or 0 and error if not.
-func (p *ProxyPorts) allocatePort(port, min, max uint16) (uint16, error) {
-	// Get a snapshot of the TCP and UDP ports already open locally.
-	openLocalPorts := p.GetOpenLocalPorts()
+func (p *ProxyPorts) allocatePort(port, min, max uint16, internal bool) (uint16, error) {
+	// Get a snapshot of the TCP and UDP ports already open locally. Internal
+	// listeners do not bind a socket, so for them only the allocation table
+	// needs to be consulted.
+	var openLocalPorts map[uint16]struct{}
+	if !internal {
+		openLocalPorts = p.GetOpenLocalPorts()
+	}
 
 	if port != 0 && p.isPortAvailable(openLocalPorts, port, false) {
 		return port, nil
@@ -247,7 +256,7 @@ func (p *ProxyPorts) AllocatePort(pp *ProxyPort, retry bool) (err error) {
 
 		// Check if pp.proxyPort is available and find another available proxy port
 		// if not.
-		pp.ProxyPort, err = p.allocatePort(pp.ProxyPort, p.rangeMin, p.rangeMax)
+		pp.ProxyPort, err = p.allocatePort(pp.ProxyPort, p.rangeMin, p.rangeMax, pp.Internal)
 	}
 
 	// Mark proxy port as reserved and configured, regardless if it was restored or
@@ -284,7 +293,7 @@ func (p *ProxyPorts) AllocateCRDProxyPort(name string) (uint16, error) {
 		if pp.rulesPort != 0 && !p.allocatedPorts[pp.rulesPort] {
 			pp.ProxyPort = pp.rulesPort
 		} else {
-			pp.ProxyPort, err = p.allocatePort(pp.rulesPort, p.rangeMin, p.rangeMax)
+			pp.ProxyPort, err = p.allocatePort(pp.rulesPort, p.rangeMin, p.rangeMax, pp.Internal)
 			if err != nil {
 				return 0, err
 			}
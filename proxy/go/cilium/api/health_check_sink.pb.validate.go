@@ -0,0 +1,149 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: cilium/api/health_check_sink.proto
+
+package cilium
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on HealthCheckEventPipeSink with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *HealthCheckEventPipeSink) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on HealthCheckEventPipeSink with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// HealthCheckEventPipeSinkMultiError, or nil if none found.
+func (m *HealthCheckEventPipeSink) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *HealthCheckEventPipeSink) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetPath()) < 1 {
+		err := HealthCheckEventPipeSinkValidationError{
+			field:  "Path",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return HealthCheckEventPipeSinkMultiError(errors)
+	}
+
+	return nil
+}
+
+// HealthCheckEventPipeSinkMultiError is an error wrapping multiple validation
+// errors returned by HealthCheckEventPipeSink.ValidateAll() if the designated
+// constraints aren't met.
+type HealthCheckEventPipeSinkMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m HealthCheckEventPipeSinkMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m HealthCheckEventPipeSinkMultiError) AllErrors() []error { return m }
+
+// HealthCheckEventPipeSinkValidationError is the validation error returned by
+// HealthCheckEventPipeSink.Validate if the designated constraints aren't met.
+type HealthCheckEventPipeSinkValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e HealthCheckEventPipeSinkValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e HealthCheckEventPipeSinkValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e HealthCheckEventPipeSinkValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e HealthCheckEventPipeSinkValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e HealthCheckEventPipeSinkValidationError) ErrorName() string {
+	return "HealthCheckEventPipeSinkValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e HealthCheckEventPipeSinkValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sHealthCheckEventPipeSink.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = HealthCheckEventPipeSinkValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = HealthCheckEventPipeSinkValidationError{}
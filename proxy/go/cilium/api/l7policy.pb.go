@@ -0,0 +1,134 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: cilium/api/l7policy.proto
+
+package cilium
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type L7Policy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path to the unix domain socket for the cilium access log.
+	AccessLogPath string `protobuf:"bytes,1,opt,name=access_log_path,json=accessLogPath,proto3" json:"access_log_path,omitempty"`
+	// HTTP response body message for 403 status code.
+	// If empty, "Access denied" will be used.
+	Denied_403Body string `protobuf:"bytes,3,opt,name=denied_403_body,json=denied403Body,proto3" json:"denied_403_body,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *L7Policy) Reset() {
+	*x = L7Policy{}
+	mi := &file_cilium_api_l7policy_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *L7Policy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*L7Policy) ProtoMessage() {}
+
+func (x *L7Policy) ProtoReflect() protoreflect.Message {
+	mi := &file_cilium_api_l7policy_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use L7Policy.ProtoReflect.Descriptor instead.
+func (*L7Policy) Descriptor() ([]byte, []int) {
+	return file_cilium_api_l7policy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *L7Policy) GetAccessLogPath() string {
+	if x != nil {
+		return x.AccessLogPath
+	}
+	return ""
+}
+
+func (x *L7Policy) GetDenied_403Body() string {
+	if x != nil {
+		return x.Denied_403Body
+	}
+	return ""
+}
+
+var File_cilium_api_l7policy_proto protoreflect.FileDescriptor
+
+const file_cilium_api_l7policy_proto_rawDesc = "" +
+	"\n" +
+	"\x19cilium/api/l7policy.proto\x12\x06cilium\"Z\n" +
+	"\bL7Policy\x12&\n" +
+	"\x0faccess_log_path\x18\x01 \x01(\tR\raccessLogPath\x12&\n" +
+	"\x0fdenied_403_body\x18\x03 \x01(\tR\rdenied403BodyB.Z,github.com/cilium/proxy/go/cilium/api;ciliumb\x06proto3"
+
+var (
+	file_cilium_api_l7policy_proto_rawDescOnce sync.Once
+	file_cilium_api_l7policy_proto_rawDescData []byte
+)
+
+func file_cilium_api_l7policy_proto_rawDescGZIP() []byte {
+	file_cilium_api_l7policy_proto_rawDescOnce.Do(func() {
+		file_cilium_api_l7policy_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cilium_api_l7policy_proto_rawDesc), len(file_cilium_api_l7policy_proto_rawDesc)))
+	})
+	return file_cilium_api_l7policy_proto_rawDescData
+}
+
+var file_cilium_api_l7policy_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_cilium_api_l7policy_proto_goTypes = []any{
+	(*L7Policy)(nil), // 0: cilium.L7Policy
+}
+var file_cilium_api_l7policy_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_cilium_api_l7policy_proto_init() }
+func file_cilium_api_l7policy_proto_init() {
+	if File_cilium_api_l7policy_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cilium_api_l7policy_proto_rawDesc), len(file_cilium_api_l7policy_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_cilium_api_l7policy_proto_goTypes,
+		DependencyIndexes: file_cilium_api_l7policy_proto_depIdxs,
+		MessageInfos:      file_cilium_api_l7policy_proto_msgTypes,
+	}.Build()
+	File_cilium_api_l7policy_proto = out.File
+	file_cilium_api_l7policy_proto_goTypes = nil
+	file_cilium_api_l7policy_proto_depIdxs = nil
+}
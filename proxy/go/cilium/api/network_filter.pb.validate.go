@@ -0,0 +1,142 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: cilium/api/network_filter.proto
+
+package cilium
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on NetworkFilter with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *NetworkFilter) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on NetworkFilter with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in NetworkFilterMultiError, or
+// nil if none found.
+func (m *NetworkFilter) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *NetworkFilter) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Proxylib
+
+	// no validation rules for ProxylibParams
+
+	// no validation rules for AccessLogPath
+
+	if len(errors) > 0 {
+		return NetworkFilterMultiError(errors)
+	}
+
+	return nil
+}
+
+// NetworkFilterMultiError is an error wrapping multiple validation errors
+// returned by NetworkFilter.ValidateAll() if the designated constraints
+// aren't met.
+type NetworkFilterMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m NetworkFilterMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m NetworkFilterMultiError) AllErrors() []error { return m }
+
+// NetworkFilterValidationError is the validation error returned by
+// NetworkFilter.Validate if the designated constraints aren't met.
+type NetworkFilterValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e NetworkFilterValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e NetworkFilterValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e NetworkFilterValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e NetworkFilterValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e NetworkFilterValidationError) ErrorName() string { return "NetworkFilterValidationError" }
+
+// Error satisfies the builtin error interface
+func (e NetworkFilterValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sNetworkFilter.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = NetworkFilterValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = NetworkFilterValidationError{}
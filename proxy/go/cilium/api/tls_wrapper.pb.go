@@ -0,0 +1,152 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: cilium/api/tls_wrapper.proto
+
+package cilium
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Empty configuration messages for Cilium TLS wrapper to make Envoy happy
+type UpstreamTlsWrapperContext struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpstreamTlsWrapperContext) Reset() {
+	*x = UpstreamTlsWrapperContext{}
+	mi := &file_cilium_api_tls_wrapper_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpstreamTlsWrapperContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpstreamTlsWrapperContext) ProtoMessage() {}
+
+func (x *UpstreamTlsWrapperContext) ProtoReflect() protoreflect.Message {
+	mi := &file_cilium_api_tls_wrapper_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpstreamTlsWrapperContext.ProtoReflect.Descriptor instead.
+func (*UpstreamTlsWrapperContext) Descriptor() ([]byte, []int) {
+	return file_cilium_api_tls_wrapper_proto_rawDescGZIP(), []int{0}
+}
+
+type DownstreamTlsWrapperContext struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownstreamTlsWrapperContext) Reset() {
+	*x = DownstreamTlsWrapperContext{}
+	mi := &file_cilium_api_tls_wrapper_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownstreamTlsWrapperContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownstreamTlsWrapperContext) ProtoMessage() {}
+
+func (x *DownstreamTlsWrapperContext) ProtoReflect() protoreflect.Message {
+	mi := &file_cilium_api_tls_wrapper_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownstreamTlsWrapperContext.ProtoReflect.Descriptor instead.
+func (*DownstreamTlsWrapperContext) Descriptor() ([]byte, []int) {
+	return file_cilium_api_tls_wrapper_proto_rawDescGZIP(), []int{1}
+}
+
+var File_cilium_api_tls_wrapper_proto protoreflect.FileDescriptor
+
+const file_cilium_api_tls_wrapper_proto_rawDesc = "" +
+	"\n" +
+	"\x1ccilium/api/tls_wrapper.proto\x12\x06cilium\"\x1b\n" +
+	"\x19UpstreamTlsWrapperContext\"\x1d\n" +
+	"\x1bDownstreamTlsWrapperContextB.Z,github.com/cilium/proxy/go/cilium/api;ciliumb\x06proto3"
+
+var (
+	file_cilium_api_tls_wrapper_proto_rawDescOnce sync.Once
+	file_cilium_api_tls_wrapper_proto_rawDescData []byte
+)
+
+func file_cilium_api_tls_wrapper_proto_rawDescGZIP() []byte {
+	file_cilium_api_tls_wrapper_proto_rawDescOnce.Do(func() {
+		file_cilium_api_tls_wrapper_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cilium_api_tls_wrapper_proto_rawDesc), len(file_cilium_api_tls_wrapper_proto_rawDesc)))
+	})
+	return file_cilium_api_tls_wrapper_proto_rawDescData
+}
+
+var file_cilium_api_tls_wrapper_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_cilium_api_tls_wrapper_proto_goTypes = []any{
+	(*UpstreamTlsWrapperContext)(nil),   // 0: cilium.UpstreamTlsWrapperContext
+	(*DownstreamTlsWrapperContext)(nil), // 1: cilium.DownstreamTlsWrapperContext
+}
+var file_cilium_api_tls_wrapper_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_cilium_api_tls_wrapper_proto_init() }
+func file_cilium_api_tls_wrapper_proto_init() {
+	if File_cilium_api_tls_wrapper_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cilium_api_tls_wrapper_proto_rawDesc), len(file_cilium_api_tls_wrapper_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_cilium_api_tls_wrapper_proto_goTypes,
+		DependencyIndexes: file_cilium_api_tls_wrapper_proto_depIdxs,
+		MessageInfos:      file_cilium_api_tls_wrapper_proto_msgTypes,
+	}.Build()
+	File_cilium_api_tls_wrapper_proto = out.File
+	file_cilium_api_tls_wrapper_proto_goTypes = nil
+	file_cilium_api_tls_wrapper_proto_depIdxs = nil
+}
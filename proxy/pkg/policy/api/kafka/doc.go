@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+// +k8s:openapi-gen=true
+// +deepequal-gen=package
+
+// Package kafka defines the Kafka API of the Cilium network policy interface
+// +groupName=policy
+package kafka